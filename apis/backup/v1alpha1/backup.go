@@ -0,0 +1,79 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupSpec defines the desired state of Backup
+type BackupSpec struct {
+	// Node is the node to back up
+	Node NodeReference `json:"node"`
+	// Schedule is cron schedule of backups, a single backup is taken if not provided
+	Schedule string `json:"schedule,omitempty"`
+	// Retention is the number of volume snapshots to keep, all snapshots are kept if not provided
+	// +kubebuilder:validation:Minimum=1
+	Retention int `json:"retention,omitempty"`
+	// VolumeSnapshotClassName is CSI volume snapshot class, default class is used if not provided
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// BackupPhase is backup lifecycle phase
+// +kubebuilder:validation:Enum=Pending;InProgress;Completed;Failed
+type BackupPhase string
+
+const (
+	// BackupPending is when no backup has been taken yet
+	BackupPending BackupPhase = "Pending"
+	// BackupInProgress is when the node is suspended and its volume snapshot is being taken
+	BackupInProgress BackupPhase = "InProgress"
+	// BackupCompleted is when the last volume snapshot has been taken
+	BackupCompleted BackupPhase = "Completed"
+	// BackupFailed is when the last volume snapshot has failed, it's taken again after retry interval
+	BackupFailed BackupPhase = "Failed"
+)
+
+// BackupStatus defines the observed state of Backup
+type BackupStatus struct {
+	// Phase is backup lifecycle phase
+	Phase BackupPhase `json:"phase,omitempty"`
+	// Message is human readable details about the last backup
+	Message string `json:"message,omitempty"`
+	// Current is the volume snapshot being taken
+	Current string `json:"current,omitempty"`
+	// Snapshots are taken volume snapshots, oldest first
+	Snapshots []string `json:"snapshots,omitempty"`
+	// LastBackupTime is the last time a volume snapshot has been taken and ready to use
+	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
+	// NextBackupTime is the next scheduled backup time, or retry time of failed backup
+	NextBackupTime *metav1.Time `json:"nextBackupTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// Backup is the Schema for the backups API
+// +kubebuilder:printcolumn:name="Kind",type=string,JSONPath=".spec.node.kind"
+// +kubebuilder:printcolumn:name="Node",type=string,JSONPath=".spec.node.name"
+// +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="LastBackup",type=date,JSONPath=".status.lastBackupTime"
+type Backup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupSpec   `json:"spec,omitempty"`
+	Status BackupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BackupList contains a list of Backup
+type BackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Backup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Backup{}, &BackupList{})
+}
@@ -0,0 +1,75 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/helpers/cron"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-backup-kotal-io-v1alpha1-backup,mutating=false,failurePolicy=fail,groups=backup.kotal.io,resources=backups,versions=v1alpha1,name=validate-backup-v1alpha1-backup.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Backup{}
+
+// validate is the shared validation logic
+func (r *Backup) validate() (errors field.ErrorList) {
+	path := field.NewPath("spec")
+
+	errors = append(errors, r.Spec.Node.validate(path.Child("node"))...)
+
+	if r.Spec.Schedule != "" {
+		if _, err := cron.Parse(r.Spec.Schedule); err != nil {
+			msg := fmt.Sprintf("must be a valid cron schedule: %s", err)
+			errors = append(errors, field.Invalid(path.Child("schedule"), r.Spec.Schedule, msg))
+		}
+	}
+
+	return
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *Backup) ValidateCreate() error {
+	var allErrors field.ErrorList
+
+	backuplog.Info("validate create", "name", r.Name)
+
+	allErrors = append(allErrors, r.validate()...)
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *Backup) ValidateUpdate(old runtime.Object) error {
+	var allErrors field.ErrorList
+	oldBackup := old.(*Backup)
+
+	backuplog.Info("validate update", "name", r.Name)
+
+	allErrors = append(allErrors, r.validate()...)
+
+	if oldBackup.Spec.Node != r.Spec.Node {
+		err := field.Forbidden(field.NewPath("spec").Child("node"), "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *Backup) ValidateDelete() error {
+	backuplog.Info("validate delete", "name", r.Name)
+	return nil
+}
@@ -0,0 +1,155 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/kotalco/kotal/apis/shared"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Backup validation", func() {
+	node := NodeReference{
+		APIVersion: "ethereum.kotal.io/v1alpha1",
+		Kind:       "Node",
+		Name:       "my-node",
+	}
+
+	createCases := []struct {
+		Title  string
+		Backup *Backup
+		Errors field.ErrorList
+	}{
+		{
+			Title: "unsupported node api version",
+			Backup: &Backup{
+				Spec: BackupSpec{
+					Node: NodeReference{
+						APIVersion: "apps/v1",
+						Kind:       "StatefulSet",
+						Name:       "my-node",
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				field.NotSupported(field.NewPath("spec").Child("node").Child("apiVersion"), "apps/v1", []string{
+					"bitcoin.kotal.io/v1alpha1",
+					"chainlink.kotal.io/v1alpha1",
+					"ethereum.kotal.io/v1alpha1",
+					"ethereum2.kotal.io/v1alpha1",
+					"filecoin.kotal.io/v1alpha1",
+					"ipfs.kotal.io/v1alpha1",
+					"near.kotal.io/v1alpha1",
+					"polkadot.kotal.io/v1alpha1",
+					"stacks.kotal.io/v1alpha1",
+				}),
+			},
+		},
+		{
+			Title: "unsupported node kind",
+			Backup: &Backup{
+				Spec: BackupSpec{
+					Node: NodeReference{
+						APIVersion: "ethereum2.kotal.io/v1alpha1",
+						Kind:       "Node",
+						Name:       "my-node",
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.node.kind",
+					BadValue: "Node",
+					Detail:   "must be one of BeaconNode, Validator",
+				},
+			},
+		},
+		{
+			Title: "invalid schedule",
+			Backup: &Backup{
+				Spec: BackupSpec{
+					Node:     node,
+					Schedule: "0 25 * * *",
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.schedule",
+					BadValue: "0 25 * * *",
+					Detail:   "must be a valid cron schedule: hour 25 is out of range 0-23",
+				},
+			},
+		},
+	}
+
+	updateCases := []struct {
+		Title     string
+		OldBackup *Backup
+		NewBackup *Backup
+		Errors    field.ErrorList
+	}{
+		{
+			Title: "updating node",
+			OldBackup: &Backup{
+				Spec: BackupSpec{
+					Node: node,
+				},
+			},
+			NewBackup: &Backup{
+				Spec: BackupSpec{
+					Node: NodeReference{
+						APIVersion: "ethereum.kotal.io/v1alpha1",
+						Kind:       "Node",
+						Name:       "my-other-node",
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:   field.ErrorTypeForbidden,
+					Field:  "spec.node",
+					Detail: "field is immutable",
+				},
+			},
+		},
+	}
+
+	Context("While creating backup", func() {
+		for _, c := range createCases {
+			func() {
+				cc := c
+				It(fmt.Sprintf("Should validate %s", cc.Title), func() {
+					err := cc.Backup.ValidateCreate()
+
+					errStatus := err.(*errors.StatusError)
+
+					causes := shared.ErrorsToCauses(cc.Errors)
+
+					Expect(errStatus.ErrStatus.Details.Causes).To(ContainElements(causes))
+				})
+			}()
+		}
+	})
+
+	Context("While updating backup", func() {
+		for _, c := range updateCases {
+			func() {
+				cc := c
+				It(fmt.Sprintf("Should validate %s", cc.Title), func() {
+					err := cc.NewBackup.ValidateUpdate(cc.OldBackup)
+
+					errStatus := err.(*errors.StatusError)
+
+					causes := shared.ErrorsToCauses(cc.Errors)
+
+					Expect(errStatus.ErrStatus.Details.Causes).To(ContainElements(causes))
+				})
+			}()
+		}
+	})
+
+})
@@ -0,0 +1,63 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// NodeReference is a reference to a Kotal node in the same namespace
+type NodeReference struct {
+	// APIVersion is node API group and version, e.g. ethereum.kotal.io/v1alpha1
+	APIVersion string `json:"apiVersion"`
+	// Kind is node kind, e.g. Node, BeaconNode, Validator, Peer or ClusterPeer
+	Kind string `json:"kind"`
+	// Name is node name
+	Name string `json:"name"`
+}
+
+// nodeKinds is supported node kinds by API group version
+var nodeKinds = map[string][]string{
+	"bitcoin.kotal.io/v1alpha1":   {"Node"},
+	"chainlink.kotal.io/v1alpha1": {"Node"},
+	"ethereum.kotal.io/v1alpha1":  {"Node"},
+	"ethereum2.kotal.io/v1alpha1": {"BeaconNode", "Validator"},
+	"filecoin.kotal.io/v1alpha1":  {"Node"},
+	"ipfs.kotal.io/v1alpha1":      {"Peer", "ClusterPeer"},
+	"near.kotal.io/v1alpha1":      {"Node"},
+	"polkadot.kotal.io/v1alpha1":  {"Node"},
+	"stacks.kotal.io/v1alpha1":    {"Node"},
+}
+
+// GroupVersionKind returns node group, version and kind
+func (n *NodeReference) GroupVersionKind() schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(n.APIVersion, n.Kind)
+}
+
+// validate validates node reference is a supported Kotal node
+func (n *NodeReference) validate(path *field.Path) (errors field.ErrorList) {
+	kinds, ok := nodeKinds[n.APIVersion]
+	if !ok {
+		versions := []string{}
+		for version := range nodeKinds {
+			versions = append(versions, version)
+		}
+		sort.Strings(versions)
+		errors = append(errors, field.NotSupported(path.Child("apiVersion"), n.APIVersion, versions))
+		return
+	}
+
+	for _, kind := range kinds {
+		if kind == n.Kind {
+			return
+		}
+	}
+
+	msg := fmt.Sprintf("must be one of %s", strings.Join(kinds, ", "))
+	errors = append(errors, field.Invalid(path.Child("kind"), n.Kind, msg))
+
+	return
+}
@@ -0,0 +1,71 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RestoreSpec defines the desired state of Restore
+type RestoreSpec struct {
+	// Node is the node to restore
+	Node NodeReference `json:"node"`
+	// BackupName is the backup whose latest volume snapshot is restored
+	BackupName string `json:"backupName,omitempty"`
+	// SnapshotName is the volume snapshot to restore, overrides backup latest snapshot
+	SnapshotName string `json:"snapshotName,omitempty"`
+}
+
+// RestorePhase is restore lifecycle phase
+// +kubebuilder:validation:Enum=Pending;InProgress;Completed;Failed
+type RestorePhase string
+
+const (
+	// RestorePending is when the node or the volume snapshot doesn't exist yet
+	RestorePending RestorePhase = "Pending"
+	// RestoreInProgress is when the node is suspended and its storage is being recreated from the volume snapshot
+	RestoreInProgress RestorePhase = "InProgress"
+	// RestoreCompleted is when node storage has been recreated from the volume snapshot
+	RestoreCompleted RestorePhase = "Completed"
+	// RestoreFailed is when node storage can't be restored
+	RestoreFailed RestorePhase = "Failed"
+)
+
+// RestoreStatus defines the observed state of Restore
+type RestoreStatus struct {
+	// Phase is restore lifecycle phase
+	Phase RestorePhase `json:"phase,omitempty"`
+	// Message is human readable details about the restore
+	Message string `json:"message,omitempty"`
+	// SnapshotName is the restored volume snapshot
+	SnapshotName string `json:"snapshotName,omitempty"`
+	// CompletionTime is the time node storage has been restored
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// Restore is the Schema for the restores API
+// +kubebuilder:printcolumn:name="Kind",type=string,JSONPath=".spec.node.kind"
+// +kubebuilder:printcolumn:name="Node",type=string,JSONPath=".spec.node.name"
+// +kubebuilder:printcolumn:name="Snapshot",type=string,JSONPath=".status.snapshotName"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+type Restore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RestoreSpec   `json:"spec,omitempty"`
+	Status RestoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RestoreList contains a list of Restore
+type RestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Restore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Restore{}, &RestoreList{})
+}
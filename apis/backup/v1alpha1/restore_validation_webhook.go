@@ -0,0 +1,69 @@
+package v1alpha1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-backup-kotal-io-v1alpha1-restore,mutating=false,failurePolicy=fail,groups=backup.kotal.io,resources=restores,versions=v1alpha1,name=validate-backup-v1alpha1-restore.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Restore{}
+
+// validate is the shared validation logic
+func (r *Restore) validate() (errors field.ErrorList) {
+	path := field.NewPath("spec")
+
+	errors = append(errors, r.Spec.Node.validate(path.Child("node"))...)
+
+	if r.Spec.BackupName == "" && r.Spec.SnapshotName == "" {
+		errors = append(errors, field.Required(path.Child("snapshotName"), "must provide backupName or snapshotName"))
+	}
+
+	return
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *Restore) ValidateCreate() error {
+	var allErrors field.ErrorList
+
+	restorelog.Info("validate create", "name", r.Name)
+
+	allErrors = append(allErrors, r.validate()...)
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *Restore) ValidateUpdate(old runtime.Object) error {
+	var allErrors field.ErrorList
+	oldRestore := old.(*Restore)
+
+	restorelog.Info("validate update", "name", r.Name)
+
+	allErrors = append(allErrors, r.validate()...)
+
+	// restore is a one-off operation
+	if oldRestore.Spec != r.Spec {
+		err := field.Forbidden(field.NewPath("spec"), "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *Restore) ValidateDelete() error {
+	restorelog.Info("validate delete", "name", r.Name)
+	return nil
+}
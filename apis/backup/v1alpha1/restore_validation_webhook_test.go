@@ -0,0 +1,106 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/kotalco/kotal/apis/shared"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Restore validation", func() {
+	node := NodeReference{
+		APIVersion: "filecoin.kotal.io/v1alpha1",
+		Kind:       "Node",
+		Name:       "my-node",
+	}
+
+	createCases := []struct {
+		Title   string
+		Restore *Restore
+		Errors  field.ErrorList
+	}{
+		{
+			Title: "missing backup and snapshot",
+			Restore: &Restore{
+				Spec: RestoreSpec{
+					Node: node,
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:   field.ErrorTypeRequired,
+					Field:  "spec.snapshotName",
+					Detail: "must provide backupName or snapshotName",
+				},
+			},
+		},
+	}
+
+	updateCases := []struct {
+		Title      string
+		OldRestore *Restore
+		NewRestore *Restore
+		Errors     field.ErrorList
+	}{
+		{
+			Title: "updating snapshot",
+			OldRestore: &Restore{
+				Spec: RestoreSpec{
+					Node:         node,
+					SnapshotName: "my-node-snapshot",
+				},
+			},
+			NewRestore: &Restore{
+				Spec: RestoreSpec{
+					Node:         node,
+					SnapshotName: "my-node-other-snapshot",
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:   field.ErrorTypeForbidden,
+					Field:  "spec",
+					Detail: "field is immutable",
+				},
+			},
+		},
+	}
+
+	Context("While creating restore", func() {
+		for _, c := range createCases {
+			func() {
+				cc := c
+				It(fmt.Sprintf("Should validate %s", cc.Title), func() {
+					err := cc.Restore.ValidateCreate()
+
+					errStatus := err.(*errors.StatusError)
+
+					causes := shared.ErrorsToCauses(cc.Errors)
+
+					Expect(errStatus.ErrStatus.Details.Causes).To(ContainElements(causes))
+				})
+			}()
+		}
+	})
+
+	Context("While updating restore", func() {
+		for _, c := range updateCases {
+			func() {
+				cc := c
+				It(fmt.Sprintf("Should validate %s", cc.Title), func() {
+					err := cc.NewRestore.ValidateUpdate(cc.OldRestore)
+
+					errStatus := err.(*errors.StatusError)
+
+					causes := shared.ErrorsToCauses(cc.Errors)
+
+					Expect(errStatus.ErrStatus.Details.Causes).To(ContainElements(causes))
+				})
+			}()
+		}
+	})
+
+})
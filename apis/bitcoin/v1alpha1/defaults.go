@@ -9,6 +9,8 @@ const (
 	DefaultMainnetP2PPort uint = 8333
 	// DefaultTestnetP2PPort is the default p2p port for testnet
 	DefaultTestnetP2PPort uint = 18333
+	// DefaultMetricsPort is the default prometheus metrics exporter port
+	DefaultMetricsPort uint = 9332
 	// DefaultHost is the default JSON-RPC server host
 	DefaultHost = "0.0.0.0"
 )
@@ -42,13 +42,44 @@ type NodeSpec struct {
 	Wallet bool `json:"wallet,omitempty"`
 	// TransactionIndex maintains a full tx index
 	TransactionIndex bool `json:"txIndex,omitempty"`
+	// Metrics is node prometheus metrics exporter
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+	// Bootstrap is node data bootstrapping from a remote snapshot archive
+	Bootstrap *shared.Bootstrap `json:"bootstrap,omitempty"`
+	// Expose is node service exposure outside the cluster
+	Expose *shared.Expose `json:"expose,omitempty"`
+	// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+	Ingress *shared.Ingress `json:"ingress,omitempty"`
+	// RPCGateway is authenticating and rate-limiting JSON-RPC gateway sidecar in front of node RPC port
+	RPCGateway *shared.RPCGateway `json:"rpcGateway,omitempty"`
+	// Replicas is node pods count behind node service, each replica has its own data volume
+	// it must be 1 if node is configured with keys or accounts that can't be shared between replicas
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	Replicas uint `json:"replicas,omitempty"`
+	// UpdateStrategy is node pods update strategy, canary updates require more than one replica
+	UpdateStrategy *shared.UpdateStrategy `json:"updateStrategy,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
 }
 
 // NodeStatus defines the observed state of Node
 type NodeStatus struct {
-	Client string `json:"client,omitempty"`
+	shared.Status `json:",inline"`
+	Client        string `json:"client,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -57,6 +88,7 @@ type NodeStatus struct {
 // Node is the Schema for the nodes API
 // +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
 // +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
 type Node struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -1,6 +1,10 @@
 package v1alpha1
 
-import "sigs.k8s.io/controller-runtime/pkg/webhook"
+import (
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
+)
 
 // +kubebuilder:webhook:path=/mutate-bitcoin-kotal-io-v1alpha1-node,mutating=true,failurePolicy=fail,groups=bitcoin.kotal.io,resources=nodes,verbs=create;update,versions=v1alpha1,name=mutate-bitcoin-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
 
@@ -26,6 +30,12 @@ func (r *Node) DefaultNodeResources() {
 	if r.Spec.Resources.Storage == "" {
 		r.Spec.Resources.Storage = DefaultNodeStorageRequest
 	}
+
+	if r.Spec.Resources.RetentionPolicy == "" {
+		r.Spec.Resources.RetentionPolicy = shared.DefaultRetentionPolicy
+	}
+
+	r.Spec.Resources.AutoExpand.Default()
 }
 
 // Default implements webhook.Defaulter so a webhook will be registered for the type
@@ -60,4 +70,10 @@ func (r *Node) Default() {
 		r.Spec.P2PHost = DefaultHost
 	}
 
+	r.Spec.Metrics.Default(DefaultMetricsPort, DefaultHost)
+
+	r.Spec.RPCGateway.Default()
+
+	r.Spec.UpdateStrategy.Default()
+
 }
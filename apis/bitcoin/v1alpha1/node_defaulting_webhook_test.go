@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"github.com/kotalco/kotal/apis/shared"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,6 +27,7 @@ var _ = Describe("Bitcoin node defaulting", func() {
 		Expect(node.Spec.Memory).To(Equal(DefaultNodeMemoryRequest))
 		Expect(node.Spec.MemoryLimit).To(Equal(DefaultNodeMemoryLimit))
 		Expect(node.Spec.Storage).To(Equal(DefaultNodeStorageRequest))
+		Expect(node.Spec.RetentionPolicy).To(Equal(shared.DefaultRetentionPolicy))
 
 	})
 })
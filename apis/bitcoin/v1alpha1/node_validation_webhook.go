@@ -6,19 +6,55 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-bitcoin-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=bitcoin.kotal.io,resources=nodes,versions=v1alpha1,name=validate-bitcoin-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
 
 var _ webhook.Validator = &Node{}
 
+// validate shared validation logic for create and update resources
+func (r *Node) validate() field.ErrorList {
+	var nodeErrors field.ErrorList
+
+	// metrics exporter collects metrics using JSON-RPC
+	if r.Spec.Metrics.Enabled {
+		if !r.Spec.RPC {
+			err := field.Invalid(field.NewPath("spec").Child("metrics").Child("enabled"), r.Spec.Metrics.Enabled, "must be false if rpc is disabled")
+			nodeErrors = append(nodeErrors, err)
+		} else if len(r.Spec.RPCUsers) == 0 {
+			err := field.Invalid(field.NewPath("spec").Child("metrics").Child("enabled"), r.Spec.Metrics.Enabled, "must be false if no rpc users are provided")
+			nodeErrors = append(nodeErrors, err)
+		}
+	}
+
+	// bitcoin rpc server uses Authorization header for basic authentication
+	if r.Spec.RPCGateway != nil && r.Spec.RPCGateway.Auth == shared.JWTAuth {
+		err := field.Invalid(field.NewPath("spec").Child("rpcGateway").Child("auth"), r.Spec.RPCGateway.Auth, "must be APIKey for bitcoin nodes")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	return nodeErrors
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *Node) ValidateCreate() error {
 	var allErrors field.ErrorList
 
 	nodelog.Info("validate create", "name", r.Name)
 
+	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, shared.ValidateImage(r.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&r.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&r.Spec.ExtraConfig, "bitcoincore", r.ManagedFlags(), false)...)
+	allErrors = append(allErrors, shared.ValidateIngress(r.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(r.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateRPCGateway(r.Spec.RPCGateway, r.Spec.RPC, r.Spec.P2PPort, r.Spec.RPCPort)...)
+	allErrors = append(allErrors, shared.ValidateExpose(r.Spec.Expose, r.Spec.P2PPort)...)
+	allErrors = append(allErrors, shared.ValidateUpdateStrategy(r.Spec.UpdateStrategy, r.Spec.Replicas)...)
+	allErrors = append(allErrors, shared.ValidateBootstrap(r.Spec.Bootstrap)...)
 
 	if len(allErrors) == 0 {
 		return nil
@@ -34,7 +70,18 @@ func (r *Node) ValidateUpdate(old runtime.Object) error {
 
 	nodelog.Info("validate update", "name", r.Name)
 
+	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, shared.ValidateImage(r.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&r.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&r.Spec.ExtraConfig, "bitcoincore", r.ManagedFlags(), false)...)
+	allErrors = append(allErrors, shared.ValidateIngress(r.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(r.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateRPCGateway(r.Spec.RPCGateway, r.Spec.RPC, r.Spec.P2PPort, r.Spec.RPCPort)...)
+	allErrors = append(allErrors, shared.ValidateExpose(r.Spec.Expose, r.Spec.P2PPort)...)
+	allErrors = append(allErrors, shared.ValidateUpdateStrategy(r.Spec.UpdateStrategy, r.Spec.Replicas)...)
+	allErrors = append(allErrors, shared.ValidateReplicasUpdate(r.Spec.Replicas, oldNode.Spec.Replicas)...)
+	allErrors = append(allErrors, shared.ValidateBootstrap(r.Spec.Bootstrap)...)
 
 	if r.Spec.Network != oldNode.Spec.Network {
 		err := field.Invalid(field.NewPath("spec").Child("network"), r.Spec.Network, "field is immutable")
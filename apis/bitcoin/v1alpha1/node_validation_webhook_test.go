@@ -16,7 +16,34 @@ var _ = Describe("Bitcoin node validation", func() {
 		Title  string
 		Node   *Node
 		Errors field.ErrorList
-	}{}
+	}{
+		{
+			Title: "rpc gateway using JWT auth",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network: "mainnet",
+					RPC:     true,
+					RPCPort: 8332,
+					RPCGateway: &shared.RPCGateway{
+						Port:       8080,
+						Auth:       shared.JWTAuth,
+						SecretName: "rpc-jwt",
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.rpcGateway.auth",
+					BadValue: shared.JWTAuth,
+					Detail:   "must be APIKey for bitcoin nodes",
+				},
+			},
+		},
+	}
 
 	updateCases := []struct {
 		Title   string
@@ -0,0 +1,112 @@
+package v1beta1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BitcoinNetwork is Bitcoin network
+type BitcoinNetwork string
+
+const (
+	Mainnet BitcoinNetwork = "mainnet"
+	Testnet BitcoinNetwork = "testnet"
+)
+
+// RPCUsers is JSON-RPC users credentials
+type RPCUser struct {
+	// Username is JSON-RPC username
+	Username string `json:"username"`
+	// PasswordSecretName is k8s secret name holding JSON-RPC user password
+	PasswordSecretName string `json:"passwordSecretName"`
+}
+
+// NodeSpec defines the desired state of Node
+type NodeSpec struct {
+	// Network is Bitcoin network to join and sync
+	// +kubebuilder:validation:Enum=mainnet;testnet
+	Network BitcoinNetwork `json:"network"`
+	// P2PPort is p2p communications port
+	P2PPort uint `json:"p2pPort,omitempty"`
+	// P2PHost is p2p communication host
+	P2PHost string `json:"p2pHost,omitempty"`
+	// RPC enables JSON-RPC server
+	RPC bool `json:"rpc,omitempty"`
+	// RPCPort is JSON-RPC server port
+	RPCPort uint `json:"rpcPort,omitempty"`
+	// RPCHost is JSON-RPC server host
+	RPCHost string `json:"rpcHost,omitempty"`
+	// RPCUsers is JSON-RPC users credentials
+	RPCUsers []RPCUser `json:"rpcUsers,omitempty"`
+	// Wallet load wallet and enables wallet RPC calls
+	Wallet bool `json:"wallet,omitempty"`
+	// TransactionIndex maintains a full tx index
+	TransactionIndex bool `json:"txIndex,omitempty"`
+	// Metrics is node prometheus metrics exporter
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+	// Bootstrap is node data bootstrapping from a remote snapshot archive
+	Bootstrap *shared.Bootstrap `json:"bootstrap,omitempty"`
+	// Expose is node service exposure outside the cluster
+	Expose *shared.Expose `json:"expose,omitempty"`
+	// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+	Ingress *shared.Ingress `json:"ingress,omitempty"`
+	// RPCGateway is authenticating and rate-limiting JSON-RPC gateway sidecar in front of node RPC port
+	RPCGateway *shared.RPCGateway `json:"rpcGateway,omitempty"`
+	// Replicas is node pods count behind node service, each replica has its own data volume
+	// it must be 1 if node is configured with keys or accounts that can't be shared between replicas
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	Replicas uint `json:"replicas,omitempty"`
+	// UpdateStrategy is node pods update strategy, canary updates require more than one replica
+	UpdateStrategy *shared.UpdateStrategy `json:"updateStrategy,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
+	// Resources is node compute and storage resources
+	shared.Resources `json:"resources,omitempty"`
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
+}
+
+// NodeStatus defines the observed state of Node
+type NodeStatus struct {
+	shared.Status `json:",inline"`
+	Client        string `json:"client,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// Node is the Schema for the nodes API
+// +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
+// +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+type Node struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeSpec   `json:"spec,omitempty"`
+	Status NodeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeList contains a list of Node
+type NodeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Node `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Node{}, &NodeList{})
+}
@@ -0,0 +1,42 @@
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	"github.com/kotalco/kotal/apis/shared"
+)
+
+// ConvertTo converts this node to the hub version (v1alpha1)
+func (src *Node) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Node)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	if err := shared.ConvertJSON(&src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+
+	if err := shared.ConvertJSON(&src.Status, &dst.Status); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this node
+func (dst *Node) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Node)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	if err := shared.ConvertJSON(&src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+
+	if err := shared.ConvertJSON(&src.Status, &dst.Status); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package v1beta1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/mutate-bitcoin-kotal-io-v1beta1-node,mutating=true,failurePolicy=fail,groups=bitcoin.kotal.io,resources=nodes,verbs=create;update,versions=v1beta1,name=mutate-bitcoin-v1beta1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &Node{}
+
+// Default defaults node the same way as the hub version
+// node that can't be defaulted is rejected by the validation webhook
+func (r *Node) Default() {
+	hub, err := r.defaultedHub()
+	if err != nil {
+		nodelog.Error(err, "unable to default node", "name", r.Name)
+		return
+	}
+
+	// conversion from the defaulted hub version is checked by defaultedHub
+	_ = r.ConvertFrom(hub)
+}
+
+// defaultedHub returns the hub version of node defaulted
+// error is returned if node can't be converted to the hub version, or from the defaulted hub version
+func (r *Node) defaultedHub() (*v1alpha1.Node, error) {
+	hub := &v1alpha1.Node{}
+	if err := r.ConvertTo(hub); err != nil {
+		return nil, fmt.Errorf("unable to convert to hub version: %w", err)
+	}
+
+	hub.Default()
+
+	if err := r.DeepCopy().ConvertFrom(hub); err != nil {
+		return nil, fmt.Errorf("unable to convert from defaulted hub version: %w", err)
+	}
+
+	return hub, nil
+}
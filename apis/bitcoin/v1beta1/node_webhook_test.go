@@ -0,0 +1,38 @@
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+)
+
+var _ = Describe("Bitcoin node webhooks", func() {
+
+	It("should default and validate node by the hub version", func() {
+		node := &Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-node",
+				Namespace: "default",
+			},
+			Spec: NodeSpec{
+				Network: Mainnet,
+			},
+		}
+
+		hub := &v1alpha1.Node{}
+		Expect(node.ConvertTo(hub)).To(Succeed())
+		hub.Default()
+
+		node.Default()
+
+		defaulted := &v1alpha1.Node{}
+		Expect(node.ConvertTo(defaulted)).To(Succeed())
+		Expect(defaulted.Spec).To(Equal(hub.Spec))
+
+		Expect(node.ValidateCreate()).To(Succeed())
+		Expect(node.ValidateUpdate(node.DeepCopy())).To(Succeed())
+	})
+
+})
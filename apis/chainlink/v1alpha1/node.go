@@ -46,13 +46,33 @@ type NodeSpec struct {
 	// Logging is logging verboisty level
 	// +kubebuilder:validation:Enum=debug;info;warn;error;panic
 	Logging shared.VerbosityLevel `json:"logging,omitempty"`
+	// Metrics is node prometheus metrics exporter
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+	// Expose is node service exposure outside the cluster
+	Expose *shared.Expose `json:"expose,omitempty"`
+	// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+	Ingress *shared.Ingress `json:"ingress,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
 }
 
 // NodeStatus defines the observed state of Node
 type NodeStatus struct {
-	Client string `json:"client,omitempty"`
+	shared.Status `json:",inline"`
+	Client        string `json:"client,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -62,6 +82,7 @@ type NodeStatus struct {
 // +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
 // +kubebuilder:printcolumn:name="EthereumChainId",type=number,JSONPath=".spec.ethereumChainId"
 // +kubebuilder:printcolumn:name="LinkContractAddress",type=string,JSONPath=".spec.linkContractAddress",priority=10
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
 type Node struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -41,6 +41,12 @@ func (r *Node) Default() {
 		r.Spec.Storage = DefaultNodeStorageRequest
 	}
 
+	if r.Spec.RetentionPolicy == "" {
+		r.Spec.RetentionPolicy = shared.DefaultRetentionPolicy
+	}
+
+	r.Spec.AutoExpand.Default()
+
 	if r.Spec.TLSPort == 0 {
 		r.Spec.TLSPort = DefaultTLSPort
 	}
@@ -8,6 +8,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-chainlink-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=chainlink.kotal.io,resources=nodes,versions=v1alpha1,name=validate-chainlink-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
@@ -21,6 +23,13 @@ func (r *Node) ValidateCreate() error {
 	nodelog.Info("validate create", "name", r.Name)
 
 	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, shared.ValidateImage(r.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&r.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&r.Spec.ExtraConfig, "chainlink", r.ManagedFlags(), false)...)
+	allErrors = append(allErrors, shared.ValidateIngress(r.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(r.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateExpose(r.Spec.Expose, r.Spec.P2PPort)...)
+	allErrors = append(allErrors, r.Spec.Metrics.ValidateServedByAPI()...)
 
 	if len(allErrors) == 0 {
 		return nil
@@ -38,6 +47,13 @@ func (r *Node) ValidateUpdate(old runtime.Object) error {
 	nodelog.Info("validate update", "name", r.Name)
 
 	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, shared.ValidateImage(r.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&r.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&r.Spec.ExtraConfig, "chainlink", r.ManagedFlags(), false)...)
+	allErrors = append(allErrors, shared.ValidateIngress(r.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(r.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateExpose(r.Spec.Expose, r.Spec.P2PPort)...)
+	allErrors = append(allErrors, r.Spec.Metrics.ValidateServedByAPI()...)
 
 	if oldNode.Spec.EthereumChainId != r.Spec.EthereumChainId {
 		err := field.Invalid(field.NewPath("spec").Child("ethereumChainId"), fmt.Sprintf("%d", r.Spec.EthereumChainId), "field is immutable")
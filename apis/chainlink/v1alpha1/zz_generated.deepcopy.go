@@ -6,6 +6,7 @@
 package v1alpha1
 
 import (
+	"github.com/kotalco/kotal/apis/shared"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -30,7 +31,7 @@ func (in *Node) DeepCopyInto(out *Node) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
@@ -97,7 +98,26 @@ func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	out.Metrics = in.Metrics
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(shared.Expose)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(shared.Ingress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(shared.NetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
+	in.ExtraConfig.DeepCopyInto(&out.ExtraConfig)
 	in.Resources.DeepCopyInto(&out.Resources)
+	out.Probes = in.Probes
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSpec.
@@ -113,6 +133,7 @@ func (in *NodeSpec) DeepCopy() *NodeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatus.
@@ -0,0 +1,106 @@
+package v1beta1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// APICredentials is api credentials
+type APICredentials struct {
+	// Email is user email
+	Email string `json:"email"`
+	// PasswordSecretName is the k8s secret name that holds password
+	PasswordSecretName string `json:"passwordSecretName"`
+}
+
+// NodeSpec defines the desired state of Node
+type NodeSpec struct {
+	// EthereumChainID is ethereum chain id
+	EthereumChainID uint `json:"ethereumChainId"`
+	// EthereumWSEndpoint is ethereum websocket endpoint
+	EthereumWSEndpoint string `json:"ethereumWsEndpoint"`
+	// EthereumHTTPEndpoints is ethereum http endpoints
+	// +listType=set
+	EthereumHTTPEndpoints []string `json:"ethereumHttpEndpoints,omitempty"`
+	// LinkContractAddress is link contract address
+	LinkContractAddress string `json:"linkContractAddress"`
+	// DatabaseURL is postgres database connection URL
+	DatabaseURL string `json:"databaseURL"`
+	// KeystorePasswordSecretName is k8s secret name that holds keystore password
+	KeystorePasswordSecretName string `json:"keystorePasswordSecretName"`
+	// APICredentials is api credentials
+	APICredentials APICredentials `json:"apiCredentials"`
+	// CORSDomains is the domains from which to accept cross origin requests
+	// +listType=set
+	CORSDomains []string `json:"corsDomains,omitempty"`
+	// CertSecretName is k8s secret name that holds tls.key and tls.cert
+	CertSecretName string `json:"certSecretName,omitempty"`
+	// TLSPort is port used for HTTPS connections
+	TLSPort uint `json:"tlsPort,omitempty"`
+	// P2PPort is port used for p2p communcations
+	P2PPort uint `json:"p2pPort,omitempty"`
+	// APIPort is port used for node API and GUI
+	APIPort uint `json:"apiPort,omitempty"`
+	// SecureCookies enables secure cookies for authentication
+	SecureCookies bool `json:"secureCookies,omitempty"`
+	// Logging is logging verboisty level
+	// +kubebuilder:validation:Enum=debug;info;warn;error;panic
+	Logging shared.VerbosityLevel `json:"logging,omitempty"`
+	// Metrics is node prometheus metrics exporter
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+	// Expose is node service exposure outside the cluster
+	Expose *shared.Expose `json:"expose,omitempty"`
+	// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+	Ingress *shared.Ingress `json:"ingress,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
+	// Resources is node compute and storage resources
+	shared.Resources `json:"resources,omitempty"`
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
+}
+
+// NodeStatus defines the observed state of Node
+type NodeStatus struct {
+	shared.Status `json:",inline"`
+	Client        string `json:"client,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// Node is the Schema for the nodes API
+// +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
+// +kubebuilder:printcolumn:name="EthereumChainId",type=number,JSONPath=".spec.ethereumChainId"
+// +kubebuilder:printcolumn:name="LinkContractAddress",type=string,JSONPath=".spec.linkContractAddress",priority=10
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+type Node struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeSpec   `json:"spec,omitempty"`
+	Status NodeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeList contains a list of Node
+type NodeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Node `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Node{}, &NodeList{})
+}
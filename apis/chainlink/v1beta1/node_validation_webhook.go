@@ -0,0 +1,49 @@
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/chainlink/v1alpha1"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-chainlink-kotal-io-v1beta1-node,mutating=false,failurePolicy=fail,groups=chainlink.kotal.io,resources=nodes,versions=v1beta1,name=validate-chainlink-v1beta1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Node{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+// node is validated by the defaulted hub version, it's rejected if it can't be defaulted
+func (r *Node) ValidateCreate() error {
+	hub, err := r.defaultedHub()
+	if err != nil {
+		return err
+	}
+
+	return hub.ValidateCreate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+// node is validated by the defaulted hub version, it's rejected if it can't be defaulted
+func (r *Node) ValidateUpdate(old runtime.Object) error {
+	hub, err := r.defaultedHub()
+	if err != nil {
+		return err
+	}
+
+	oldHub := &v1alpha1.Node{}
+	if err := old.(*Node).ConvertTo(oldHub); err != nil {
+		return err
+	}
+
+	return hub.ValidateUpdate(oldHub)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *Node) ValidateDelete() error {
+	hub := &v1alpha1.Node{}
+	if err := r.ConvertTo(hub); err != nil {
+		return err
+	}
+
+	return hub.ValidateDelete()
+}
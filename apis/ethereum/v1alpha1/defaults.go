@@ -30,6 +30,10 @@ const (
 	DefaultWSPort uint = 8546
 	// DefaultGraphQLPort is the default graphQL port
 	DefaultGraphQLPort uint = 8547
+	// DefaultMetricsPort is the default prometheus metrics port
+	DefaultMetricsPort uint = 6060
+	// DefaultMetricsHost is the default host on which metrics server should listen
+	DefaultMetricsHost = "0.0.0.0"
 )
 
 // Genesis block defaults
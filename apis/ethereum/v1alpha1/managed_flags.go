@@ -0,0 +1,12 @@
+package v1alpha1
+
+// NodeManagedFlags is set by Ethereum clients package which owns the flags
+var NodeManagedFlags func(*Node) []string
+
+// ManagedFlags returns client flags set by Kotal that can't be used in extra args
+func (n *Node) ManagedFlags() []string {
+	if NodeManagedFlags == nil {
+		return nil
+	}
+	return NodeManagedFlags(n)
+}
@@ -1,6 +1,10 @@
 package v1alpha1
 
-import "sigs.k8s.io/controller-runtime/pkg/webhook"
+import (
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
+)
 
 // +kubebuilder:webhook:path=/mutate-ethereum-kotal-io-v1alpha1-node,mutating=true,failurePolicy=fail,groups=ethereum.kotal.io,resources=nodes,verbs=create;update,versions=v1alpha1,name=mutate-ethereum-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
 
@@ -24,6 +28,8 @@ func (n *Node) Default() {
 		n.Spec.P2PPort = DefaultP2PPort
 	}
 
+	n.Spec.Metrics.Default(DefaultMetricsPort, DefaultMetricsHost)
+
 	if n.Spec.SyncMode == "" {
 		// public network
 		if n.Spec.Genesis == nil {
@@ -72,6 +78,10 @@ func (n *Node) Default() {
 		n.Spec.Logging = DefaultLogging
 	}
 
+	n.Spec.RPCGateway.Default()
+
+	n.Spec.UpdateStrategy.Default()
+
 }
 
 // DefaultNodeResources defaults node cpu, memory and storage resources
@@ -134,4 +144,10 @@ func (n *Node) DefaultNodeResources() {
 		n.Spec.Resources.Storage = storage
 	}
 
+	if n.Spec.Resources.RetentionPolicy == "" {
+		n.Spec.Resources.RetentionPolicy = shared.DefaultRetentionPolicy
+	}
+
+	n.Spec.Resources.AutoExpand.Default()
+
 }
@@ -8,6 +8,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-ethereum-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=ethereum.kotal.io,resources=nodes,versions=v1alpha1,name=validate-ethereum-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
@@ -70,6 +72,19 @@ func (n *Node) validate() field.ErrorList {
 		nodeErrors = append(nodeErrors, err)
 	}
 
+	// validate graphql is disabled if rpc gateway is used, graphql queries can't be filtered by JSON-RPC method
+	if n.Spec.GraphQL && n.Spec.RPCGateway != nil {
+		err := field.Invalid(path.Child("graphql"), n.Spec.GraphQL, "must be disabled if rpc gateway is used")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate node port is the same as p2p port, clients advertise p2p port in enode url
+	if expose := n.Spec.Expose; expose != nil && expose.NodePort != 0 && expose.NodePort != n.Spec.P2PPort {
+		msg := fmt.Sprintf("must be the same as p2p port, %s client can't announce a different port", n.Spec.Client)
+		err := field.Invalid(path.Child("expose").Child("nodePort"), expose.NodePort, msg)
+		nodeErrors = append(nodeErrors, err)
+	}
+
 	// validate nethermind doesn't support hosts whitelisting
 	if len(n.Spec.Hosts) > 0 && n.Spec.Client == NethermindClient {
 		err := field.Invalid(path.Child("client"), n.Spec.Client, "client doesn't support hosts whitelisting")
@@ -127,6 +142,20 @@ func (n *Node) validate() field.ErrorList {
 	return nodeErrors
 }
 
+// uniqueKeys returns configured spec fields holding keys or accounts that can't be shared between replicas
+func (n *Node) uniqueKeys() (keys []string) {
+	if n.Spec.NodePrivateKeySecretName != "" {
+		keys = append(keys, "nodePrivateKeySecretName")
+	}
+	if n.Spec.Import != nil {
+		keys = append(keys, "import")
+	}
+	if n.Spec.Miner {
+		keys = append(keys, "miner")
+	}
+	return
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (n *Node) ValidateCreate() error {
 	var allErrors field.ErrorList
@@ -135,6 +164,15 @@ func (n *Node) ValidateCreate() error {
 
 	allErrors = append(allErrors, n.validate()...)
 	allErrors = append(allErrors, n.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, shared.ValidateImage(n.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&n.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&n.Spec.ExtraConfig, string(n.Spec.Client), n.ManagedFlags(), false)...)
+	allErrors = append(allErrors, shared.ValidateIngress(n.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(n.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateRPCGateway(n.Spec.RPCGateway, n.Spec.RPC, n.Spec.P2PPort, n.Spec.RPCPort, n.Spec.WSPort, n.Spec.GraphQLPort)...)
+	allErrors = append(allErrors, shared.ValidateExpose(n.Spec.Expose, n.Spec.P2PPort)...)
+	allErrors = append(allErrors, shared.ValidateReplicas(n.Spec.Replicas, n.uniqueKeys()...)...)
+	allErrors = append(allErrors, shared.ValidateUpdateStrategy(n.Spec.UpdateStrategy, n.Spec.Replicas)...)
 
 	// validate genesis block
 	if n.Spec.Genesis != nil {
@@ -172,6 +210,16 @@ func (n *Node) ValidateUpdate(old runtime.Object) error {
 
 	allErrors = append(allErrors, n.validate()...)
 	allErrors = append(allErrors, n.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, shared.ValidateImage(n.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&n.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&n.Spec.ExtraConfig, string(n.Spec.Client), n.ManagedFlags(), false)...)
+	allErrors = append(allErrors, shared.ValidateIngress(n.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(n.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateRPCGateway(n.Spec.RPCGateway, n.Spec.RPC, n.Spec.P2PPort, n.Spec.RPCPort, n.Spec.WSPort, n.Spec.GraphQLPort)...)
+	allErrors = append(allErrors, shared.ValidateExpose(n.Spec.Expose, n.Spec.P2PPort)...)
+	allErrors = append(allErrors, shared.ValidateReplicas(n.Spec.Replicas, n.uniqueKeys()...)...)
+	allErrors = append(allErrors, shared.ValidateUpdateStrategy(n.Spec.UpdateStrategy, n.Spec.Replicas)...)
+	allErrors = append(allErrors, shared.ValidateReplicasUpdate(n.Spec.Replicas, oldNode.Spec.Replicas)...)
 
 	if len(allErrors) == 0 {
 		return nil
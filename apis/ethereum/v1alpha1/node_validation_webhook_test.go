@@ -6,6 +6,7 @@ import (
 	"github.com/kotalco/kotal/apis/shared"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -509,6 +510,57 @@ var _ = Describe("Ethereum node validation", func() {
 				},
 			},
 		},
+		{
+			Title: "node #41",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node-1",
+				},
+				Spec: NodeSpec{
+					Client:  BesuClient,
+					Network: RinkebyNetwork,
+					RPC:     true,
+					GraphQL: true,
+					RPCGateway: &shared.RPCGateway{
+						Auth:       shared.APIKeyAuth,
+						SecretName: "rpc-keys",
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.graphql",
+					BadValue: true,
+					Detail:   "must be disabled if rpc gateway is used",
+				},
+			},
+		},
+		{
+			Title: "node #42",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node-1",
+				},
+				Spec: NodeSpec{
+					Client:  GethClient,
+					Network: RinkebyNetwork,
+					P2PPort: 30303,
+					Expose: &shared.Expose{
+						ServiceType: corev1.ServiceTypeNodePort,
+						NodePort:    31000,
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.expose.nodePort",
+					BadValue: uint(31000),
+					Detail:   "must be the same as p2p port, geth client can't announce a different port",
+				},
+			},
+		},
 	}
 
 	// TODO: move .resources validation to shared resources package
@@ -6,6 +6,7 @@
 package v1alpha1
 
 import (
+	"github.com/kotalco/kotal/apis/shared"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -191,7 +192,7 @@ func (in *Node) DeepCopyInto(out *Node) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
@@ -288,7 +289,36 @@ func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
 		*out = make([]API, len(*in))
 		copy(*out, *in)
 	}
+	out.Metrics = in.Metrics
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(shared.Expose)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(shared.Ingress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RPCGateway != nil {
+		in, out := &in.RPCGateway, &out.RPCGateway
+		*out = new(shared.RPCGateway)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(shared.UpdateStrategy)
+		**out = **in
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(shared.NetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
+	in.ExtraConfig.DeepCopyInto(&out.ExtraConfig)
 	in.Resources.DeepCopyInto(&out.Resources)
+	out.Probes = in.Probes
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSpec.
@@ -304,6 +334,7 @@ func (in *NodeSpec) DeepCopy() *NodeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatus.
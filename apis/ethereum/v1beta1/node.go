@@ -0,0 +1,285 @@
+package v1beta1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeStatus defines the observed state of Node
+type NodeStatus struct {
+	shared.Status `json:",inline"`
+	// Consensus is network consensus algorithm
+	Consensus string `json:"consensus,omitempty"`
+	// Network is the network this node is joining
+	Network string `json:"network,omitempty"`
+	// EnodeURL is the node URL
+	EnodeURL string `json:"enodeURL,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// Node is the Schema for the nodes API
+// +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".spec.client"
+// +kubebuilder:printcolumn:name="Consensus",type=string,JSONPath=".status.consensus"
+// +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".status.network"
+// +kubebuilder:printcolumn:name="enodeURL",type=string,JSONPath=".status.enodeURL",priority=10
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+type Node struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeSpec   `json:"spec,omitempty"`
+	Status NodeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeList contains a list of Node
+type NodeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Node `json:"items"`
+}
+
+// NodeSpec is the specification of the node
+type NodeSpec struct {
+	AvailabilityConfig `json:",inline"`
+
+	// Genesis is genesis block configuration
+	Genesis *Genesis `json:"genesis,omitempty"`
+
+	// Network specifies the network to join
+	Network string `json:"network,omitempty"`
+
+	// Client is ethereum client running on the node
+	Client EthereumClient `json:"client"`
+
+	// import is account to import
+	Import *ImportedAccount `json:"import,omitempty"`
+
+	// Bootnodes is set of ethereum node URLS for p2p discovery bootstrap
+	// +listType=set
+	Bootnodes []Enode `json:"bootnodes,omitempty"`
+
+	// NodePrivateKeySecretName is the secret name holding node private key
+	NodePrivateKeySecretName string `json:"nodePrivateKeySecretName,omitempty"`
+
+	// StaticNodes is a set of ethereum nodes to maintain connection to
+	// +listType=set
+	StaticNodes []Enode `json:"staticNodes,omitempty"`
+
+	// P2PPort is port used for peer to peer communication
+	P2PPort uint `json:"p2pPort,omitempty"`
+
+	// SyncMode is the node synchronization mode
+	SyncMode SynchronizationMode `json:"syncMode,omitempty"`
+
+	// Miner is whether node is mining/validating blocks or no
+	Miner bool `json:"miner,omitempty"`
+
+	// Logging is logging verboisty level
+	// +kubebuilder:validation:Enum=off;fatal;error;warn;info;debug;trace;all
+	Logging shared.VerbosityLevel `json:"logging,omitempty"`
+
+	// Coinbase is the account to which mining rewards are paid
+	Coinbase EthereumAddress `json:"coinbase,omitempty"`
+
+	// Hosts is a list of hostnames to to whitelist for RPC access
+	// +listType=set
+	Hosts []string `json:"hosts,omitempty"`
+
+	// CORSDomains is the domains from which to accept cross origin requests
+	// +listType=set
+	CORSDomains []string `json:"corsDomains,omitempty"`
+
+	// RPC is whether HTTP-RPC server is enabled or not
+	RPC bool `json:"rpc,omitempty"`
+
+	// RPCPort is HTTP-RPC server listening port
+	RPCPort uint `json:"rpcPort,omitempty"`
+
+	// RPCAPI is a list of rpc services to enable
+	// +listType=set
+	RPCAPI []API `json:"rpcAPI,omitempty"`
+
+	// WS is whether web socket server is enabled or not
+	WS bool `json:"ws,omitempty"`
+
+	// WSPort is the web socket server listening port
+	WSPort uint `json:"wsPort,omitempty"`
+
+	// WSAPI is a list of WS services to enable
+	// +listType=set
+	WSAPI []API `json:"wsAPI,omitempty"`
+
+	// GraphQL is whether GraphQL server is enabled or not
+	GraphQL bool `json:"graphql,omitempty"`
+
+	// GraphQLPort is the GraphQL server listening port
+	GraphQLPort uint `json:"graphqlPort,omitempty"`
+
+	// Metrics is node prometheus metrics exporter
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+
+	// Expose is node service exposure outside the cluster
+	Expose *shared.Expose `json:"expose,omitempty"`
+	// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+	Ingress *shared.Ingress `json:"ingress,omitempty"`
+	// RPCGateway is authenticating and rate-limiting JSON-RPC gateway sidecar in front of node RPC port
+	RPCGateway *shared.RPCGateway `json:"rpcGateway,omitempty"`
+	// Replicas is node pods count behind node service, each replica has its own data volume
+	// it must be 1 if node is configured with keys or accounts that can't be shared between replicas
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	Replicas uint `json:"replicas,omitempty"`
+	// UpdateStrategy is node pods update strategy, canary updates require more than one replica
+	UpdateStrategy *shared.UpdateStrategy `json:"updateStrategy,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
+	// Resources is node compute and storage resources
+	shared.Resources `json:"resources,omitempty"`
+
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
+}
+
+// Enode is ethereum node url
+type Enode string
+
+// SynchronizationMode is the node synchronization mode
+// +kubebuilder:validation:Enum=fast;full;light;snap
+type SynchronizationMode string
+
+const (
+	//SnapSynchronization is the snap synchronization mode
+	SnapSynchronization SynchronizationMode = "snap"
+
+	//FastSynchronization is the fast synchronization mode
+	FastSynchronization SynchronizationMode = "fast"
+
+	//LightSynchronization is the light synchronization mode
+	LightSynchronization SynchronizationMode = "light"
+
+	//FullSynchronization is full archival synchronization mode
+	FullSynchronization SynchronizationMode = "full"
+)
+
+// API is RPC API to be exposed by RPC or web socket server
+// +kubebuilder:validation:Enum=admin;clique;debug;eea;eth;ibft;miner;net;perm;plugins;priv;txpool;web3
+type API string
+
+const (
+	// AdminAPI is administration API
+	AdminAPI API = "admin"
+
+	// CliqueAPI is clique (Proof of Authority consensus) API
+	CliqueAPI API = "clique"
+
+	// DebugAPI is debugging API
+	DebugAPI API = "debug"
+
+	// EEAAPI is EEA (Enterprise Ethereum Alliance) API
+	EEAAPI API = "eea"
+
+	// ETHAPI is ethereum API
+	ETHAPI API = "eth"
+
+	// IBFTAPI is IBFT consensus API
+	IBFTAPI API = "ibft"
+
+	// MinerAPI is miner API
+	MinerAPI API = "miner"
+
+	// NetworkAPI is network API
+	NetworkAPI API = "net"
+
+	// PermissionAPI is permission API
+	PermissionAPI API = "perm"
+
+	// PluginsAPI is plugins API
+	PluginsAPI API = "plugins"
+
+	// PrivacyAPI is privacy API
+	PrivacyAPI API = "privacy"
+
+	// TransactionPoolAPI is transaction pool API
+	TransactionPoolAPI API = "txpool"
+
+	// Web3API is web3 API
+	Web3API API = "web3"
+)
+
+// EthereumClient is the ethereum client running on a given node
+// +kubebuilder:validation:Enum=besu;geth;nethermind
+type EthereumClient string
+
+func (e EthereumClient) SupportsVerbosityLevel(level shared.VerbosityLevel) bool {
+	switch e {
+	case BesuClient:
+		switch level {
+		case shared.NoLogs,
+			shared.FatalLogs,
+			shared.ErrorLogs,
+			shared.WarnLogs,
+			shared.InfoLogs,
+			shared.DebugLogs,
+			shared.TraceLogs,
+			shared.AllLogs:
+			return true
+		}
+	case GethClient:
+		switch level {
+		case shared.NoLogs,
+			shared.ErrorLogs,
+			shared.WarnLogs,
+			shared.InfoLogs,
+			shared.DebugLogs,
+			shared.AllLogs:
+			return true
+		}
+	case NethermindClient:
+		switch level {
+		case shared.ErrorLogs,
+			shared.WarnLogs,
+			shared.InfoLogs,
+			shared.DebugLogs,
+			shared.TraceLogs:
+			return true
+		}
+
+	}
+	return false
+}
+
+const (
+	// BesuClient is hyperledger besu ethereum client
+	BesuClient EthereumClient = "besu"
+	// GethClient is go ethereum client
+	GethClient EthereumClient = "geth"
+	// NethermindClient is Nethermind .NET client
+	NethermindClient EthereumClient = "nethermind"
+)
+
+// ImportedAccount is account derived from private key
+type ImportedAccount struct {
+	// PrivateKeySecretName is the secret name holding account private key
+	PrivateKeySecretName string `json:"privateKeySecretName"`
+	// PasswordSecretName is the secret holding password used to encrypt account private key
+	PasswordSecretName string `json:"passwordSecretName"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Node{}, &NodeList{})
+}
@@ -53,19 +53,43 @@ type BeaconNodeSpec struct {
 	// P2PPort is p2p and discovery port
 	P2PPort uint `json:"p2pPort,omitempty"`
 
+	// Metrics is node prometheus metrics exporter
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+
+	// Expose is node service exposure outside the cluster
+	Expose *shared.Expose `json:"expose,omitempty"`
+	// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+	Ingress *shared.Ingress `json:"ingress,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
 }
 
 // BeaconNodeStatus defines the observed state of BeaconNode
 type BeaconNodeStatus struct {
+	shared.Status `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 
 // BeaconNode is the Schema for the beaconnodes API
 // +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".spec.client"
 // +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
 type BeaconNode struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
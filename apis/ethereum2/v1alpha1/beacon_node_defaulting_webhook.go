@@ -1,6 +1,10 @@
 package v1alpha1
 
-import "sigs.k8s.io/controller-runtime/pkg/webhook"
+import (
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
+)
 
 // +kubebuilder:webhook:path=/mutate-ethereum2-kotal-io-v1alpha1-beaconnode,mutating=true,failurePolicy=fail,groups=ethereum2.kotal.io,resources=beaconnodes,verbs=create;update,versions=v1alpha1,name=mutate-ethereum2-v1alpha1-beaconnode.kb.io,sideEffects=None,admissionReviewVersions=v1
 
@@ -39,6 +43,8 @@ func (r *BeaconNode) Default() {
 		r.Spec.GRPCHost = DefaultGRPCHost
 	}
 
+	r.Spec.Metrics.Default(DefaultMetricsPort, DefaultMetricsHost)
+
 	if len(r.Spec.CORSDomains) == 0 {
 		r.Spec.CORSDomains = DefaultOrigins
 	}
@@ -71,4 +77,10 @@ func (r *BeaconNode) DefaultNodeResources() {
 	if r.Spec.Resources.Storage == "" {
 		r.Spec.Resources.Storage = DefaultStorage
 	}
+
+	if r.Spec.Resources.RetentionPolicy == "" {
+		r.Spec.Resources.RetentionPolicy = shared.DefaultRetentionPolicy
+	}
+
+	r.Spec.Resources.AutoExpand.Default()
 }
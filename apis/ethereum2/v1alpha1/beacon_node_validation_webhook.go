@@ -9,6 +9,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-ethereum2-kotal-io-v1alpha1-beaconnode,mutating=false,failurePolicy=fail,groups=ethereum2.kotal.io,resources=beaconnodes,versions=v1alpha1,name=validate-ethereum2-v1alpha1-beaconnode.kb.io,sideEffects=None,admissionReviewVersions=v1
@@ -61,6 +63,13 @@ func (r *BeaconNode) validate() field.ErrorList {
 		nodeErrors = append(nodeErrors, err)
 	}
 
+	// prysm and nimbus can't announce node port different from p2p port
+	if expose := r.Spec.Expose; expose != nil && expose.NodePort != 0 && expose.NodePort != r.Spec.P2PPort && (r.Spec.Client == PrysmClient || r.Spec.Client == NimbusClient) {
+		msg := fmt.Sprintf("must be the same as p2p port, %s client can't announce a different port", r.Spec.Client)
+		err := field.Invalid(path.Child("expose").Child("nodePort"), expose.NodePort, msg)
+		nodeErrors = append(nodeErrors, err)
+	}
+
 	// teku and nimbus doesn't support multiple Ethereum 1 endpoints
 	if len(r.Spec.Eth1Endpoints) > 1 && r.Spec.Client == NimbusClient {
 		err := field.Invalid(path.Child("eth1Endpoints"), strings.Join(r.Spec.Eth1Endpoints, ", "), fmt.Sprintf("multiple Ethereum 1 endpoints not supported by %s client", r.Spec.Client))
@@ -78,6 +87,12 @@ func (r *BeaconNode) ValidateCreate() error {
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, shared.ValidateImage(r.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&r.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&r.Spec.ExtraConfig, string(r.Spec.Client), r.ManagedFlags(), false)...)
+	allErrors = append(allErrors, shared.ValidateIngress(r.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(r.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateExpose(r.Spec.Expose, r.Spec.P2PPort)...)
 
 	if len(allErrors) == 0 {
 		return nil
@@ -97,6 +112,12 @@ func (r *BeaconNode) ValidateUpdate(old runtime.Object) error {
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, shared.ValidateImage(r.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&r.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&r.Spec.ExtraConfig, string(r.Spec.Client), r.ManagedFlags(), false)...)
+	allErrors = append(allErrors, shared.ValidateIngress(r.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(r.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateExpose(r.Spec.Expose, r.Spec.P2PPort)...)
 
 	if oldNode.Spec.Client != r.Spec.Client {
 		err := field.Invalid(path.Child("client"), r.Spec.Client, "field is immutable")
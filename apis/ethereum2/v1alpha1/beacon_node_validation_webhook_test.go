@@ -6,6 +6,7 @@ import (
 	"github.com/kotalco/kotal/apis/shared"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -181,6 +182,29 @@ var _ = Describe("Ethereum 2.0 beacon node validation", func() {
 				},
 			},
 		},
+		{
+			Title: "Node #10",
+			Node: &BeaconNode{
+				Spec: BeaconNodeSpec{
+					Network:       "mainnet",
+					Client:        NimbusClient,
+					Eth1Endpoints: []string{"http://localhost:8545"},
+					P2PPort:       9000,
+					Expose: &shared.Expose{
+						ServiceType: corev1.ServiceTypeNodePort,
+						NodePort:    31000,
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.expose.nodePort",
+					BadValue: uint(31000),
+					Detail:   "must be the same as p2p port, nimbus client can't announce a different port",
+				},
+			},
+		},
 	}
 
 	updateCases := []struct {
@@ -0,0 +1,23 @@
+package v1alpha1
+
+// BeaconNodeManagedFlags is set by Ethereum 2.0 clients package which owns the flags
+var BeaconNodeManagedFlags func(*BeaconNode) []string
+
+// ManagedFlags returns beacon node client flags set by Kotal that can't be used in extra args
+func (r *BeaconNode) ManagedFlags() []string {
+	if BeaconNodeManagedFlags == nil {
+		return nil
+	}
+	return BeaconNodeManagedFlags(r)
+}
+
+// ValidatorManagedFlags is set by Ethereum 2.0 clients package which owns the flags
+var ValidatorManagedFlags func(*Validator) []string
+
+// ManagedFlags returns validator client flags set by Kotal that can't be used in extra args
+func (r *Validator) ManagedFlags() []string {
+	if ValidatorManagedFlags == nil {
+		return nil
+	}
+	return ValidatorManagedFlags(r)
+}
@@ -0,0 +1,20 @@
+package v1alpha1
+
+import "github.com/kotalco/kotal/apis/shared"
+
+// SecretNames returns names of k8s secrets referenced by beacon node spec
+func (r *BeaconNode) SecretNames() []string {
+	names := []string{r.Spec.CertSecretName}
+	names = append(names, r.Spec.EnvSecretNames()...)
+	return shared.SecretNames(names...)
+}
+
+// SecretNames returns names of k8s secrets referenced by validator spec
+func (r *Validator) SecretNames() []string {
+	names := []string{r.Spec.CertSecretName, r.Spec.WalletPasswordSecret}
+	for _, keystore := range r.Spec.Keystores {
+		names = append(names, keystore.SecretName)
+	}
+	names = append(names, r.Spec.EnvSecretNames()...)
+	return shared.SecretNames(names...)
+}
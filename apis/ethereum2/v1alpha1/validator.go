@@ -27,8 +27,23 @@ type ValidatorSpec struct {
 	Keystores []Keystore `json:"keystores"`
 	// WalletPasswordSecret is wallet password secret
 	WalletPasswordSecret string `json:"walletPasswordSecret,omitempty"`
+	// Metrics is node prometheus metrics exporter
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
 }
 
 // Keystore is Ethereum 2.0 validator EIP-2335 BLS12-381 keystore https://eips.ethereum.org/EIPS/eip-2335
@@ -41,13 +56,17 @@ type Keystore struct {
 }
 
 // ValidatorStatus defines the observed state of Validator
-type ValidatorStatus struct{}
+type ValidatorStatus struct {
+	shared.Status `json:",inline"`
+}
 
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 
 // Validator is the Schema for the validators API
 // +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".spec.client"
 // +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
 type Validator struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -0,0 +1,5 @@
+package v1alpha1
+
+// Hub marks this version as the conversion hub
+// controllers reconcile v1alpha1 validators, other versions are converted to and from it
+func (*Validator) Hub() {}
@@ -2,6 +2,8 @@ package v1alpha1
 
 import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
 )
 
 // +kubebuilder:webhook:path=/mutate-ethereum2-kotal-io-v1alpha1-validator,mutating=true,failurePolicy=fail,groups=ethereum2.kotal.io,resources=validators,verbs=create;update,versions=v1alpha1,name=mutate-ethereum2-v1alpha1-validator.kb.io,sideEffects=None,admissionReviewVersions=v1
@@ -16,6 +18,8 @@ func (r *Validator) Default() {
 		r.Spec.Graffiti = DefaultGraffiti
 	}
 
+	r.Spec.Metrics.Default(DefaultMetricsPort, DefaultMetricsHost)
+
 	r.DefaultNodeResources()
 
 }
@@ -42,6 +46,12 @@ func (r *Validator) DefaultNodeResources() {
 		r.Spec.Resources.Storage = DefaultStorage
 	}
 
+	if r.Spec.Resources.RetentionPolicy == "" {
+		r.Spec.Resources.RetentionPolicy = shared.DefaultRetentionPolicy
+	}
+
+	r.Spec.Resources.AutoExpand.Default()
+
 	if r.Spec.Logging == "" {
 		r.Spec.Logging = DefaultLogging
 	}
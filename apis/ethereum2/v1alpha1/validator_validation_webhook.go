@@ -9,6 +9,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-ethereum2-kotal-io-v1alpha1-validator,mutating=false,failurePolicy=fail,groups=ethereum2.kotal.io,resources=validators,versions=v1alpha1,name=validate-ethereum2-v1alpha1-validator.kb.io,sideEffects=None,admissionReviewVersions=v1
@@ -64,6 +66,10 @@ func (r *Validator) ValidateCreate() error {
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, shared.ValidateImage(r.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&r.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&r.Spec.ExtraConfig, string(r.Spec.Client), r.ManagedFlags(), false)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(r.Spec.NetworkPolicy)...)
 
 	if len(allErrors) == 0 {
 		return nil
@@ -81,6 +87,10 @@ func (r *Validator) ValidateUpdate(old runtime.Object) error {
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldValidator.Spec.Resources)...)
+	allErrors = append(allErrors, shared.ValidateImage(r.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&r.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&r.Spec.ExtraConfig, string(r.Spec.Client), r.ManagedFlags(), false)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(r.Spec.NetworkPolicy)...)
 
 	if oldValidator.Spec.Client != r.Spec.Client {
 		err := field.Invalid(field.NewPath("spec").Child("client"), r.Spec.Client, "field is immutable")
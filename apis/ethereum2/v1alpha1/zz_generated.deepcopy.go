@@ -6,6 +6,7 @@
 package v1alpha1
 
 import (
+	"github.com/kotalco/kotal/apis/shared"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -15,7 +16,7 @@ func (in *BeaconNode) DeepCopyInto(out *BeaconNode) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeaconNode.
@@ -86,7 +87,26 @@ func (in *BeaconNodeSpec) DeepCopyInto(out *BeaconNodeSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	out.Metrics = in.Metrics
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(shared.Expose)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(shared.Ingress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(shared.NetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
+	in.ExtraConfig.DeepCopyInto(&out.ExtraConfig)
 	in.Resources.DeepCopyInto(&out.Resources)
+	out.Probes = in.Probes
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeaconNodeSpec.
@@ -102,6 +122,7 @@ func (in *BeaconNodeSpec) DeepCopy() *BeaconNodeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BeaconNodeStatus) DeepCopyInto(out *BeaconNodeStatus) {
 	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeaconNodeStatus.
@@ -135,7 +156,7 @@ func (in *Validator) DeepCopyInto(out *Validator) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Validator.
@@ -201,7 +222,16 @@ func (in *ValidatorSpec) DeepCopyInto(out *ValidatorSpec) {
 		*out = make([]Keystore, len(*in))
 		copy(*out, *in)
 	}
+	out.Metrics = in.Metrics
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(shared.NetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
+	in.ExtraConfig.DeepCopyInto(&out.ExtraConfig)
 	in.Resources.DeepCopyInto(&out.Resources)
+	out.Probes = in.Probes
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatorSpec.
@@ -217,6 +247,7 @@ func (in *ValidatorSpec) DeepCopy() *ValidatorSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValidatorStatus) DeepCopyInto(out *ValidatorStatus) {
 	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatorStatus.
@@ -0,0 +1,113 @@
+package v1beta1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BeaconNodeSpec defines the desired state of BeaconNode
+type BeaconNodeSpec struct {
+	// Network is the network to join
+	Network string `json:"network"`
+	// Client is the Ethereum 2.0 client to use
+	Client Ethereum2Client `json:"client"`
+	// Eth1Endpoints is Ethereum 1 endpoints
+	// +listType=set
+	Eth1Endpoints []string `json:"eth1Endpoints,omitempty"`
+
+	// REST enables Beacon REST API
+	REST bool `json:"rest,omitempty"`
+	// RESTHost is Beacon REST API server host
+	RESTHost string `json:"restHost,omitempty"`
+	// RESTPort is Beacon REST API server port
+	RESTPort uint `json:"restPort,omitempty"`
+
+	// RPC enables RPC server
+	RPC bool `json:"rpc,omitempty"`
+	// RPCHost is host on which RPC server should listen
+	RPCHost string `json:"rpcHost,omitempty"`
+	// RPCPort is RPC server port
+	RPCPort uint `json:"rpcPort,omitempty"`
+
+	// GRPC enables GRPC gateway server
+	GRPC bool `json:"grpc,omitempty"`
+	// GRPCHost is GRPC gateway server host
+	GRPCHost string `json:"grpcHost,omitempty"`
+	// GRPCPort is GRPC gateway server port
+	GRPCPort uint `json:"grpcPort,omitempty"`
+
+	// CertSecretName is k8s secret name that holds tls.key and tls.cert
+	CertSecretName string `json:"certSecretName,omitempty"`
+
+	// Logging is logging verboisty level
+	// +kubebuilder:validation:Enum=off;fatal;error;warn;info;debug;trace;all;notice;crit;panic;none
+	Logging shared.VerbosityLevel `json:"logging,omitempty"`
+
+	// Hosts is a list of hostnames to to whitelist for API access
+	// +listType=set
+	Hosts []string `json:"hosts,omitempty"`
+	// CORSDomains is the domains from which to accept cross origin requests
+	// +listType=set
+	CORSDomains []string `json:"corsDomains,omitempty"`
+
+	// P2PPort is p2p and discovery port
+	P2PPort uint `json:"p2pPort,omitempty"`
+
+	// Metrics is node prometheus metrics exporter
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+
+	// Expose is node service exposure outside the cluster
+	Expose *shared.Expose `json:"expose,omitempty"`
+	// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+	Ingress *shared.Ingress `json:"ingress,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
+	// Resources is node compute and storage resources
+	shared.Resources `json:"resources,omitempty"`
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
+}
+
+// BeaconNodeStatus defines the observed state of BeaconNode
+type BeaconNodeStatus struct {
+	shared.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// BeaconNode is the Schema for the beaconnodes API
+// +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".spec.client"
+// +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+type BeaconNode struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BeaconNodeSpec   `json:"spec,omitempty"`
+	Status BeaconNodeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BeaconNodeList contains a list of BeaconNodes
+type BeaconNodeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BeaconNode `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BeaconNode{}, &BeaconNodeList{})
+}
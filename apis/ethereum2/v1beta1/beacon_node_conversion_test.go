@@ -0,0 +1,36 @@
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+)
+
+var _ = Describe("Ethereum 2.0 beacon node conversion", func() {
+
+	It("should convert to the hub version and back", func() {
+		node := &BeaconNode{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-node",
+				Namespace: "default",
+			},
+			Spec: BeaconNodeSpec{
+				Network:       "mainnet",
+				Client:        TekuClient,
+				Eth1Endpoints: []string{"http://localhost:8545"},
+				REST:          true,
+			},
+		}
+
+		hub := &v1alpha1.BeaconNode{}
+		Expect(node.ConvertTo(hub)).To(Succeed())
+		Expect(hub.ObjectMeta).To(Equal(node.ObjectMeta))
+
+		converted := &BeaconNode{}
+		Expect(converted.ConvertFrom(hub)).To(Succeed())
+		Expect(converted).To(Equal(node))
+	})
+
+})
@@ -0,0 +1,43 @@
+package v1beta1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/mutate-ethereum2-kotal-io-v1beta1-beaconnode,mutating=true,failurePolicy=fail,groups=ethereum2.kotal.io,resources=beaconnodes,verbs=create;update,versions=v1beta1,name=mutate-ethereum2-v1beta1-beaconnode.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &BeaconNode{}
+
+// Default defaults beacon node the same way as the hub version
+// beacon node that can't be defaulted is rejected by the validation webhook
+func (r *BeaconNode) Default() {
+	hub, err := r.defaultedHub()
+	if err != nil {
+		nodelog.Error(err, "unable to default beacon node", "name", r.Name)
+		return
+	}
+
+	// conversion from the defaulted hub version is checked by defaultedHub
+	_ = r.ConvertFrom(hub)
+}
+
+// defaultedHub returns the hub version of beacon node defaulted
+// error is returned if beacon node can't be converted to the hub version, or from the defaulted hub version
+func (r *BeaconNode) defaultedHub() (*v1alpha1.BeaconNode, error) {
+	hub := &v1alpha1.BeaconNode{}
+	if err := r.ConvertTo(hub); err != nil {
+		return nil, fmt.Errorf("unable to convert to hub version: %w", err)
+	}
+
+	hub.Default()
+
+	if err := r.DeepCopy().ConvertFrom(hub); err != nil {
+		return nil, fmt.Errorf("unable to convert from defaulted hub version: %w", err)
+	}
+
+	return hub, nil
+}
@@ -0,0 +1,90 @@
+package v1beta1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ValidatorSpec defines the desired state of Validator
+type ValidatorSpec struct {
+	// Network is the network this validator is validating blocks for
+	Network string `json:"network"`
+	// Client is the Ethereum 2.0 client to use
+	Client Ethereum2Client `json:"client"`
+	// BeaconEndpoints is beacon node endpoints
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	BeaconEndpoints []string `json:"beaconEndpoints"`
+	// Graffiti is the text to include in proposed blocks
+	Graffiti string `json:"graffiti,omitempty"`
+	// Logging is logging verboisty level
+	// +kubebuilder:validation:Enum=off;fatal;error;warn;info;debug;trace;all;notice;crit;panic;none
+	Logging shared.VerbosityLevel `json:"logging,omitempty"`
+	// CertSecretName is k8s secret name that holds tls.crt
+	CertSecretName string `json:"certSecretName,omitempty"`
+	// Keystores is a list of Validator keystores
+	// +kubebuilder:validation:MinItems=1
+	Keystores []Keystore `json:"keystores"`
+	// WalletPasswordSecretName is k8s secret name holding wallet password
+	WalletPasswordSecretName string `json:"walletPasswordSecretName,omitempty"`
+	// Metrics is node prometheus metrics exporter
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
+	// Resources is node compute and storage resources
+	shared.Resources `json:"resources,omitempty"`
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
+}
+
+// Keystore is Ethereum 2.0 validator EIP-2335 BLS12-381 keystore https://eips.ethereum.org/EIPS/eip-2335
+type Keystore struct {
+	// PublicKey is the validator public key in hexadecimal
+	// +kubebuilder:validation:Pattern="^0[xX][0-9a-fA-F]{96}$"
+	PublicKey string `json:"publicKey,omitempty"`
+	// SecretName is the kubernetes secret holding [keystore] and [password]
+	SecretName string `json:"secretName"`
+}
+
+// ValidatorStatus defines the observed state of Validator
+type ValidatorStatus struct {
+	shared.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// Validator is the Schema for the validators API
+// +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".spec.client"
+// +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+type Validator struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ValidatorSpec   `json:"spec,omitempty"`
+	Status ValidatorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ValidatorList contains a list of Validator
+type ValidatorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Validator `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Validator{}, &ValidatorList{})
+}
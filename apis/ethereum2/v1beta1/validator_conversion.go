@@ -0,0 +1,48 @@
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+	"github.com/kotalco/kotal/apis/shared"
+)
+
+// ConvertTo converts this validator to the hub version (v1alpha1)
+func (src *Validator) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Validator)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	if err := shared.ConvertJSON(&src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+
+	if err := shared.ConvertJSON(&src.Status, &dst.Status); err != nil {
+		return err
+	}
+
+	// walletPasswordSecret has been renamed to walletPasswordSecretName
+	dst.Spec.WalletPasswordSecret = src.Spec.WalletPasswordSecretName
+
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this validator
+func (dst *Validator) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Validator)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	if err := shared.ConvertJSON(&src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+
+	if err := shared.ConvertJSON(&src.Status, &dst.Status); err != nil {
+		return err
+	}
+
+	// walletPasswordSecret has been renamed to walletPasswordSecretName
+	dst.Spec.WalletPasswordSecretName = src.Spec.WalletPasswordSecret
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+)
+
+var _ = Describe("Ethereum 2.0 validator conversion", func() {
+
+	It("should convert to the hub version and back", func() {
+		validator := &Validator{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-validator",
+				Namespace: "default",
+			},
+			Spec: ValidatorSpec{
+				Network:                  "mainnet",
+				Client:                   PrysmClient,
+				BeaconEndpoints:          []string{"http://localhost:4000"},
+				Keystores:                []Keystore{{SecretName: "my-validator"}},
+				WalletPasswordSecretName: "wallet-password",
+			},
+		}
+
+		hub := &v1alpha1.Validator{}
+		Expect(validator.ConvertTo(hub)).To(Succeed())
+		Expect(hub.ObjectMeta).To(Equal(validator.ObjectMeta))
+		Expect(hub.Spec.WalletPasswordSecret).To(Equal("wallet-password"))
+
+		converted := &Validator{}
+		Expect(converted.ConvertFrom(hub)).To(Succeed())
+		Expect(converted).To(Equal(validator))
+	})
+
+})
@@ -0,0 +1,43 @@
+package v1beta1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/mutate-ethereum2-kotal-io-v1beta1-validator,mutating=true,failurePolicy=fail,groups=ethereum2.kotal.io,resources=validators,verbs=create;update,versions=v1beta1,name=mutate-ethereum2-v1beta1-validator.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &Validator{}
+
+// Default defaults validator the same way as the hub version
+// validator that can't be defaulted is rejected by the validation webhook
+func (r *Validator) Default() {
+	hub, err := r.defaultedHub()
+	if err != nil {
+		validatorlog.Error(err, "unable to default validator", "name", r.Name)
+		return
+	}
+
+	// conversion from the defaulted hub version is checked by defaultedHub
+	_ = r.ConvertFrom(hub)
+}
+
+// defaultedHub returns the hub version of validator defaulted
+// error is returned if validator can't be converted to the hub version, or from the defaulted hub version
+func (r *Validator) defaultedHub() (*v1alpha1.Validator, error) {
+	hub := &v1alpha1.Validator{}
+	if err := r.ConvertTo(hub); err != nil {
+		return nil, fmt.Errorf("unable to convert to hub version: %w", err)
+	}
+
+	hub.Default()
+
+	if err := r.DeepCopy().ConvertFrom(hub); err != nil {
+		return nil, fmt.Errorf("unable to convert from defaulted hub version: %w", err)
+	}
+
+	return hub, nil
+}
@@ -0,0 +1,49 @@
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-ethereum2-kotal-io-v1beta1-validator,mutating=false,failurePolicy=fail,groups=ethereum2.kotal.io,resources=validators,versions=v1beta1,name=validate-ethereum2-v1beta1-validator.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Validator{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+// validator is validated by the defaulted hub version, it's rejected if it can't be defaulted
+func (r *Validator) ValidateCreate() error {
+	hub, err := r.defaultedHub()
+	if err != nil {
+		return err
+	}
+
+	return hub.ValidateCreate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+// validator is validated by the defaulted hub version, it's rejected if it can't be defaulted
+func (r *Validator) ValidateUpdate(old runtime.Object) error {
+	hub, err := r.defaultedHub()
+	if err != nil {
+		return err
+	}
+
+	oldHub := &v1alpha1.Validator{}
+	if err := old.(*Validator).ConvertTo(oldHub); err != nil {
+		return err
+	}
+
+	return hub.ValidateUpdate(oldHub)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *Validator) ValidateDelete() error {
+	hub := &v1alpha1.Validator{}
+	if err := r.ConvertTo(hub); err != nil {
+		return err
+	}
+
+	return hub.ValidateDelete()
+}
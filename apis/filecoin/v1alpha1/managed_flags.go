@@ -0,0 +1,7 @@
+package v1alpha1
+
+// ManagedFlags returns lotus flags set by Kotal that can't be used in extra args
+// lotus is configured using config.toml, config overrides should be used instead
+func (n *Node) ManagedFlags() []string {
+	return []string{}
+}
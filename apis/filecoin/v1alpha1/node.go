@@ -32,8 +32,29 @@ type NodeSpec struct {
 	// Logging is logging verboisty level
 	// +kubebuilder:validation:Enum=error;warn;info;debug
 	Logging shared.VerbosityLevel `json:"logging,omitempty"`
+	// Metrics is node prometheus metrics exporter
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+	// Bootstrap is node data bootstrapping from a remote snapshot archive
+	Bootstrap *shared.Bootstrap `json:"bootstrap,omitempty"`
+	// Expose is node service exposure outside the cluster
+	Expose *shared.Expose `json:"expose,omitempty"`
+	// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+	Ingress *shared.Ingress `json:"ingress,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
 }
 
 // FilecoinNetwork is Filecoin network
@@ -49,7 +70,8 @@ const (
 
 // NodeStatus defines the observed state of Node
 type NodeStatus struct {
-	Client string `json:"client"`
+	shared.Status `json:",inline"`
+	Client        string `json:"client"`
 }
 
 // +kubebuilder:object:root=true
@@ -58,6 +80,7 @@ type NodeStatus struct {
 // Node is the Schema for the nodes API
 // +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
 // +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
 type Node struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -2,6 +2,8 @@ package v1alpha1
 
 import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
 )
 
 // +kubebuilder:webhook:path=/mutate-filecoin-kotal-io-v1alpha1-node,mutating=true,failurePolicy=fail,groups=filecoin.kotal.io,resources=nodes,verbs=create;update,versions=v1alpha1,name=mutate-filecoin-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
@@ -81,4 +83,10 @@ func (n *Node) Default() {
 		}
 	}
 
+	if n.Spec.RetentionPolicy == "" {
+		n.Spec.RetentionPolicy = shared.DefaultRetentionPolicy
+	}
+
+	n.Spec.AutoExpand.Default()
+
 }
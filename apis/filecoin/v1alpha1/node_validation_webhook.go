@@ -6,19 +6,44 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-filecoin-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=filecoin.kotal.io,resources=nodes,versions=v1alpha1,name=validate-filecoin-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
 
 var _ webhook.Validator = &Node{}
 
+// validate shared validation logic for create and update resources
+func (n *Node) validate() field.ErrorList {
+	var nodeErrors field.ErrorList
+
+	nodeErrors = append(nodeErrors, n.Spec.Metrics.ValidateServedByAPI()...)
+
+	// lotus serves metrics from API server
+	if n.Spec.Metrics.Enabled && !n.Spec.API {
+		err := field.Invalid(field.NewPath("spec").Child("metrics").Child("enabled"), n.Spec.Metrics.Enabled, "must be false if api is disabled")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	return nodeErrors
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (n *Node) ValidateCreate() error {
 	nodelog.Info("validate create", "name", n.Name)
 
 	var allErrors field.ErrorList
 
+	allErrors = append(allErrors, n.validate()...)
 	allErrors = append(allErrors, n.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, shared.ValidateImage(n.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&n.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&n.Spec.ExtraConfig, "lotus", n.ManagedFlags(), true)...)
+	allErrors = append(allErrors, shared.ValidateIngress(n.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(n.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateExpose(n.Spec.Expose, n.Spec.P2PPort)...)
+	allErrors = append(allErrors, shared.ValidateBootstrap(n.Spec.Bootstrap)...)
 
 	if len(allErrors) == 0 {
 		return nil
@@ -41,7 +66,15 @@ func (n *Node) ValidateUpdate(old runtime.Object) error {
 		allErrors = append(allErrors, err)
 	}
 
+	allErrors = append(allErrors, n.validate()...)
 	allErrors = append(allErrors, n.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, shared.ValidateImage(n.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&n.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&n.Spec.ExtraConfig, "lotus", n.ManagedFlags(), true)...)
+	allErrors = append(allErrors, shared.ValidateIngress(n.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(n.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateExpose(n.Spec.Expose, n.Spec.P2PPort)...)
+	allErrors = append(allErrors, shared.ValidateBootstrap(n.Spec.Bootstrap)...)
 
 	if len(allErrors) == 0 {
 		return nil
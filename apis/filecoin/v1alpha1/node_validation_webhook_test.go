@@ -39,6 +39,37 @@ var _ = Describe("Filecoin node validation", func() {
 				},
 			},
 		},
+		{
+			Title: "metrics #1",
+			OldNode: &Node{
+				Spec: NodeSpec{
+					Network: MainNetwork,
+				},
+			},
+			NewNode: &Node{
+				Spec: NodeSpec{
+					Network: MainNetwork,
+					Metrics: shared.Metrics{
+						Enabled: true,
+						Port:    9999,
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.metrics.port",
+					BadValue: uint(9999),
+					Detail:   "metrics are served by the API server",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.metrics.enabled",
+					BadValue: true,
+					Detail:   "must be false if api is disabled",
+				},
+			},
+		},
 	}
 
 	Context("While updating node", func() {
@@ -0,0 +1,8 @@
+package v1alpha1
+
+import "github.com/kotalco/kotal/apis/shared"
+
+// SecretNames returns names of k8s secrets referenced by node spec
+func (r *Node) SecretNames() []string {
+	return shared.SecretNames(r.Spec.EnvSecretNames()...)
+}
@@ -0,0 +1,104 @@
+package v1beta1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeSpec defines the desired state of Node
+type NodeSpec struct {
+	// API enables API server
+	API bool `json:"api,omitempty"`
+	// APIPort is API server listening port
+	APIPort uint `json:"apiPort,omitempty"`
+	// APIHost is API server host
+	APIHost string `json:"apiHost,omitempty"`
+	// APIRequestTimeout is API request timeout in seconds
+	APIRequestTimeout uint `json:"apiRequestTimeout,omitempty"`
+	// DisableMetadataLog disables metadata log
+	DisableMetadataLog bool `json:"disableMetadataLog,omitempty"`
+	// P2PPort is p2p port
+	P2PPort uint `json:"p2pPort,omitempty"`
+	// P2PHost is p2p host
+	P2PHost string `json:"p2pHost,omitempty"`
+	// Network is the Filecoin network the node will join and sync
+	Network FilecoinNetwork `json:"network"`
+	// IPFSPeerEndpoint is ipfs peer endpoint
+	IPFSPeerEndpoint string `json:"ipfsPeerEndpoint,omitempty"`
+	// IPFSOnlineMode sets ipfs online mode
+	IPFSOnlineMode bool `json:"ipfsOnlineMode,omitempty"`
+	// IPFSForRetrieval uses ipfs for retrieval
+	IPFSForRetrieval bool `json:"ipfsForRetrieval,omitempty"`
+	// Logging is logging verboisty level
+	// +kubebuilder:validation:Enum=error;warn;info;debug
+	Logging shared.VerbosityLevel `json:"logging,omitempty"`
+	// Metrics is node prometheus metrics exporter
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+	// Bootstrap is node data bootstrapping from a remote snapshot archive
+	Bootstrap *shared.Bootstrap `json:"bootstrap,omitempty"`
+	// Expose is node service exposure outside the cluster
+	Expose *shared.Expose `json:"expose,omitempty"`
+	// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+	Ingress *shared.Ingress `json:"ingress,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
+	// Resources is node compute and storage resources
+	shared.Resources `json:"resources,omitempty"`
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
+}
+
+// FilecoinNetwork is Filecoin network
+// +kubebuilder:validation:Enum=mainnet;calibration
+type FilecoinNetwork string
+
+const (
+	// MainNetwork is the Filecoin main network
+	MainNetwork FilecoinNetwork = "mainnet"
+	// CalibrationNetwork is the Filecoin main network
+	CalibrationNetwork FilecoinNetwork = "calibration"
+)
+
+// NodeStatus defines the observed state of Node
+type NodeStatus struct {
+	shared.Status `json:",inline"`
+	Client        string `json:"client"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// Node is the Schema for the nodes API
+// +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
+// +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+type Node struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeSpec   `json:"spec,omitempty"`
+	Status NodeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeList contains a list of Node
+type NodeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Node `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Node{}, &NodeList{})
+}
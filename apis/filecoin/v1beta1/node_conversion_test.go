@@ -0,0 +1,34 @@
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kotalco/kotal/apis/filecoin/v1alpha1"
+)
+
+var _ = Describe("Filecoin node conversion", func() {
+
+	It("should convert to the hub version and back", func() {
+		node := &Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-node",
+				Namespace: "default",
+			},
+			Spec: NodeSpec{
+				Network: MainNetwork,
+				API:     true,
+			},
+		}
+
+		hub := &v1alpha1.Node{}
+		Expect(node.ConvertTo(hub)).To(Succeed())
+		Expect(hub.ObjectMeta).To(Equal(node.ObjectMeta))
+
+		converted := &Node{}
+		Expect(converted.ConvertFrom(hub)).To(Succeed())
+		Expect(converted).To(Equal(node))
+	})
+
+})
@@ -26,8 +26,27 @@ type ClusterPeerSpec struct {
 	// Logging is logging verboisty level
 	// +kubebuilder:validation:Enum=error;warn;info;debug
 	Logging shared.VerbosityLevel `json:"logging,omitempty"`
+	// Metrics is node prometheus metrics exporter
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+	// Expose is node service exposure outside the cluster
+	Expose *shared.Expose `json:"expose,omitempty"`
+	// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+	Ingress *shared.Ingress `json:"ingress,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
 }
 
 // ConsensusAlgorithm is IPFS cluster consensus algorithm
@@ -43,8 +62,9 @@ const (
 
 // ClusterPeerStatus defines the observed state of ClusterPeer
 type ClusterPeerStatus struct {
-	Client    string `json:"client"`
-	Consensus string `json:"consensus"`
+	shared.Status `json:",inline"`
+	Client        string `json:"client"`
+	Consensus     string `json:"consensus"`
 }
 
 // +kubebuilder:object:root=true
@@ -53,6 +73,7 @@ type ClusterPeerStatus struct {
 // ClusterPeer is the Schema for the clusterpeers API
 // +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
 // +kubebuilder:printcolumn:name="Consensus",type=string,JSONPath=".spec.consensus"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
 type ClusterPeer struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
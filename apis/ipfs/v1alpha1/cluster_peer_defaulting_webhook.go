@@ -1,6 +1,10 @@
 package v1alpha1
 
-import "sigs.k8s.io/controller-runtime/pkg/webhook"
+import (
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
+)
 
 // +kubebuilder:webhook:path=/mutate-ipfs-kotal-io-v1alpha1-clusterpeer,mutating=true,failurePolicy=fail,groups=ipfs.kotal.io,resources=clusterpeers,verbs=create;update,versions=v1alpha1,name=mutate-ipfs-v1alpha1-clusterpeer.kb.io,sideEffects=None,admissionReviewVersions=v1
 
@@ -27,6 +31,12 @@ func (r *ClusterPeer) DefaultResources() {
 	if r.Spec.Resources.Storage == "" {
 		r.Spec.Resources.Storage = DefaultNodeStorageRequest
 	}
+
+	if r.Spec.Resources.RetentionPolicy == "" {
+		r.Spec.Resources.RetentionPolicy = shared.DefaultRetentionPolicy
+	}
+
+	r.Spec.Resources.AutoExpand.Default()
 }
 
 // Default implements webhook.Defaulter so a webhook will be registered for the type
@@ -45,5 +55,7 @@ func (r *ClusterPeer) Default() {
 		r.Spec.TrustedPeers = []string{"*"}
 	}
 
+	r.Spec.Metrics.Default(DefaultIPFSClusterMetricsPort, DefaultHost)
+
 	r.DefaultResources()
 }
@@ -6,6 +6,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-ipfs-kotal-io-v1alpha1-clusterpeer,mutating=false,failurePolicy=fail,groups=ipfs.kotal.io,resources=clusterpeers,versions=v1alpha1,name=validate-ipfs-v1alpha1-clusterpeer.kb.io,sideEffects=None,admissionReviewVersions=v1
@@ -40,6 +42,12 @@ func (r *ClusterPeer) ValidateCreate() error {
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, shared.ValidateImage(r.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&r.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&r.Spec.ExtraConfig, "ipfs-cluster-service", r.ManagedFlags(), false)...)
+	allErrors = append(allErrors, validateExpose(r.Spec.Expose)...)
+	allErrors = append(allErrors, shared.ValidateIngress(r.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(r.Spec.NetworkPolicy)...)
 
 	if len(allErrors) == 0 {
 		return nil
@@ -72,6 +80,12 @@ func (r *ClusterPeer) ValidateUpdate(old runtime.Object) error {
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldClusterPeer.Spec.Resources)...)
+	allErrors = append(allErrors, shared.ValidateImage(r.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&r.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&r.Spec.ExtraConfig, "ipfs-cluster-service", r.ManagedFlags(), false)...)
+	allErrors = append(allErrors, validateExpose(r.Spec.Expose)...)
+	allErrors = append(allErrors, shared.ValidateIngress(r.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(r.Spec.NetworkPolicy)...)
 
 	if len(allErrors) == 0 {
 		return nil
@@ -35,4 +35,6 @@ const (
 const (
 	// DefaultIPFSClusterConsensus is the default ipfs cluster consensus algorithm
 	DefaultIPFSClusterConsensus = CRDT
+	// DefaultIPFSClusterMetricsPort is the default ipfs cluster prometheus metrics port
+	DefaultIPFSClusterMetricsPort uint = 8888
 )
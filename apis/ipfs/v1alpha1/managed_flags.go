@@ -0,0 +1,23 @@
+package v1alpha1
+
+// PeerManagedFlags is set by IPFS clients package which owns the flags
+var PeerManagedFlags func(*Peer) []string
+
+// ManagedFlags returns go-ipfs flags set by Kotal that can't be used in extra args
+func (p *Peer) ManagedFlags() []string {
+	if PeerManagedFlags == nil {
+		return nil
+	}
+	return PeerManagedFlags(p)
+}
+
+// ClusterPeerManagedFlags is set by IPFS clients package which owns the flags
+var ClusterPeerManagedFlags func(*ClusterPeer) []string
+
+// ManagedFlags returns ipfs cluster service flags set by Kotal that can't be used in extra args
+func (r *ClusterPeer) ManagedFlags() []string {
+	if ClusterPeerManagedFlags == nil {
+		return nil
+	}
+	return ClusterPeerManagedFlags(r)
+}
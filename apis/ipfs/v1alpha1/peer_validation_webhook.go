@@ -3,11 +3,14 @@ package v1alpha1
 import (
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-ipfs-kotal-io-v1alpha1-peer,mutating=false,failurePolicy=fail,groups=ipfs.kotal.io,resources=peers,versions=v1alpha1,name=validate-ipfs-v1alpha1-peer.kb.io,sideEffects=None,admissionReviewVersions=v1
@@ -21,6 +24,13 @@ func (p *Peer) ValidateCreate() error {
 	peerlog.Info("validate create", "name", p.Name)
 
 	allErrors = append(allErrors, p.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, shared.ValidateImage(p.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&p.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&p.Spec.ExtraConfig, "go-ipfs", p.ManagedFlags(), false)...)
+	allErrors = append(allErrors, validateExpose(p.Spec.Expose)...)
+	allErrors = append(allErrors, shared.ValidateIngress(p.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(p.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, p.Spec.Metrics.ValidateServedByAPI()...)
 
 	if len(allErrors) == 0 {
 		return nil
@@ -29,6 +39,16 @@ func (p *Peer) ValidateCreate() error {
 	return apierrors.NewInvalid(schema.GroupKind{}, p.Name, allErrors)
 }
 
+// validateExpose validates peer service exposure
+// swarm ports are outside node port range, so node port is required for NodePort service type
+func validateExpose(expose *shared.Expose) field.ErrorList {
+	if expose != nil && expose.ServiceType == corev1.ServiceTypeNodePort && expose.NodePort == 0 {
+		err := field.Required(field.NewPath("spec").Child("expose").Child("nodePort"), "node port is required for NodePort service type")
+		return field.ErrorList{err}
+	}
+	return shared.ValidateExpose(expose, 0)
+}
+
 // initProfilesChanged returns true if initial profiles changed
 func initProfilesChanged(old, peer *Peer) bool {
 	for i, profile := range old.Spec.InitProfiles {
@@ -61,6 +81,13 @@ func (p *Peer) ValidateUpdate(old runtime.Object) error {
 	}
 
 	allErrors = append(allErrors, p.Spec.Resources.ValidateUpdate(&oldPeer.Spec.Resources)...)
+	allErrors = append(allErrors, shared.ValidateImage(p.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&p.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&p.Spec.ExtraConfig, "go-ipfs", p.ManagedFlags(), false)...)
+	allErrors = append(allErrors, validateExpose(p.Spec.Expose)...)
+	allErrors = append(allErrors, shared.ValidateIngress(p.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(p.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, p.Spec.Metrics.ValidateServedByAPI()...)
 
 	if len(allErrors) == 0 {
 		return nil
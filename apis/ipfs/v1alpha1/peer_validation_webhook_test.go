@@ -6,6 +6,7 @@ import (
 	"github.com/kotalco/kotal/apis/shared"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -54,6 +55,24 @@ var _ = Describe("IPFS peer validation", func() {
 				},
 			},
 		},
+		{
+			Title: "Peer #4",
+			Peer: &Peer{
+				Spec: PeerSpec{
+					Expose: &shared.Expose{
+						ServiceType: corev1.ServiceTypeNodePort,
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeRequired,
+					Field:    "spec.expose.nodePort",
+					BadValue: "",
+					Detail:   "node port is required for NodePort service type",
+				},
+			},
+		},
 	}
 
 	updateCases := []struct {
@@ -0,0 +1,17 @@
+package v1alpha1
+
+import "github.com/kotalco/kotal/apis/shared"
+
+// SecretNames returns names of k8s secrets referenced by peer spec
+func (r *Peer) SecretNames() []string {
+	names := []string{r.Spec.SwarmKeySecretName}
+	names = append(names, r.Spec.EnvSecretNames()...)
+	return shared.SecretNames(names...)
+}
+
+// SecretNames returns names of k8s secrets referenced by cluster peer spec
+func (r *ClusterPeer) SecretNames() []string {
+	names := []string{r.Spec.PrivateKeySecretName, r.Spec.ClusterSecretName}
+	names = append(names, r.Spec.EnvSecretNames()...)
+	return shared.SecretNames(names...)
+}
@@ -0,0 +1,97 @@
+package v1beta1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterPeerSpec defines the desired state of ClusterPeer
+type ClusterPeerSpec struct {
+	// ID is the the cluster peer id
+	ID string `json:"id,omitempty"`
+	// PrivateKeySecretName is k8s secret holding private key
+	PrivateKeySecretName string `json:"privateKeySecretName,omitempty"`
+	// TrustedPeers is CRDT trusted cluster peers who can manage the pinset
+	// +listType=set
+	TrustedPeers []string `json:"trustedPeers,omitempty"`
+	// BootstrapPeers are ipfs cluster peers to connect to
+	// +listType=set
+	BootstrapPeers []string `json:"bootstrapPeers,omitempty"`
+	// Consensus is ipfs cluster consensus algorithm
+	Consensus ConsensusAlgorithm `json:"consensus,omitempty"`
+	// ClusterSecretName is k8s secret holding cluster secret
+	ClusterSecretName string `json:"clusterSecretName"`
+	// PeerEndpoint is ipfs peer http API endpoint
+	PeerEndpoint string `json:"peerEndpoint"`
+	// Logging is logging verboisty level
+	// +kubebuilder:validation:Enum=error;warn;info;debug
+	Logging shared.VerbosityLevel `json:"logging,omitempty"`
+	// Metrics is node prometheus metrics exporter
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+	// Expose is node service exposure outside the cluster
+	Expose *shared.Expose `json:"expose,omitempty"`
+	// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+	Ingress *shared.Ingress `json:"ingress,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
+	// Resources is node compute and storage resources
+	shared.Resources `json:"resources,omitempty"`
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
+}
+
+// ConsensusAlgorithm is IPFS cluster consensus algorithm
+// +kubebuilder:validation:Enum=crdt;raft
+type ConsensusAlgorithm string
+
+const (
+	// CRDT consensus algorithm
+	CRDT ConsensusAlgorithm = "crdt"
+	// Raft consensus algorithm
+	Raft ConsensusAlgorithm = "raft"
+)
+
+// ClusterPeerStatus defines the observed state of ClusterPeer
+type ClusterPeerStatus struct {
+	shared.Status `json:",inline"`
+	Client        string `json:"client"`
+	Consensus     string `json:"consensus"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// ClusterPeer is the Schema for the clusterpeers API
+// +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
+// +kubebuilder:printcolumn:name="Consensus",type=string,JSONPath=".spec.consensus"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+type ClusterPeer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterPeerSpec   `json:"spec,omitempty"`
+	Status ClusterPeerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterPeerList contains a list of ClusterPeer
+type ClusterPeerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPeer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterPeer{}, &ClusterPeerList{})
+}
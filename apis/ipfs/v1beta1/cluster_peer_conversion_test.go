@@ -0,0 +1,35 @@
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+)
+
+var _ = Describe("IPFS cluster peer conversion", func() {
+
+	It("should convert to the hub version and back", func() {
+		peer := &ClusterPeer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-peer",
+				Namespace: "default",
+			},
+			Spec: ClusterPeerSpec{
+				ClusterSecretName: "cluster-secret",
+				PeerEndpoint:      "/dns4/ipfs-peer/tcp/5001",
+				TrustedPeers:      []string{"12D3KooWBcEtY8GH4mNkri9kM3haeWhEXtQV7mi81ErWrqLYGuiq"},
+			},
+		}
+
+		hub := &v1alpha1.ClusterPeer{}
+		Expect(peer.ConvertTo(hub)).To(Succeed())
+		Expect(hub.ObjectMeta).To(Equal(peer.ObjectMeta))
+
+		converted := &ClusterPeer{}
+		Expect(converted.ConvertFrom(hub)).To(Succeed())
+		Expect(converted).To(Equal(peer))
+	})
+
+})
@@ -0,0 +1,43 @@
+package v1beta1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/mutate-ipfs-kotal-io-v1beta1-clusterpeer,mutating=true,failurePolicy=fail,groups=ipfs.kotal.io,resources=clusterpeers,verbs=create;update,versions=v1beta1,name=mutate-ipfs-v1beta1-clusterpeer.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &ClusterPeer{}
+
+// Default defaults cluster peer the same way as the hub version
+// cluster peer that can't be defaulted is rejected by the validation webhook
+func (r *ClusterPeer) Default() {
+	hub, err := r.defaultedHub()
+	if err != nil {
+		clusterpeerlog.Error(err, "unable to default cluster peer", "name", r.Name)
+		return
+	}
+
+	// conversion from the defaulted hub version is checked by defaultedHub
+	_ = r.ConvertFrom(hub)
+}
+
+// defaultedHub returns the hub version of cluster peer defaulted
+// error is returned if cluster peer can't be converted to the hub version, or from the defaulted hub version
+func (r *ClusterPeer) defaultedHub() (*v1alpha1.ClusterPeer, error) {
+	hub := &v1alpha1.ClusterPeer{}
+	if err := r.ConvertTo(hub); err != nil {
+		return nil, fmt.Errorf("unable to convert to hub version: %w", err)
+	}
+
+	hub.Default()
+
+	if err := r.DeepCopy().ConvertFrom(hub); err != nil {
+		return nil, fmt.Errorf("unable to convert from defaulted hub version: %w", err)
+	}
+
+	return hub, nil
+}
@@ -0,0 +1,49 @@
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-ipfs-kotal-io-v1beta1-clusterpeer,mutating=false,failurePolicy=fail,groups=ipfs.kotal.io,resources=clusterpeers,versions=v1beta1,name=validate-ipfs-v1beta1-clusterpeer.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ClusterPeer{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+// cluster peer is validated by the defaulted hub version, it's rejected if it can't be defaulted
+func (r *ClusterPeer) ValidateCreate() error {
+	hub, err := r.defaultedHub()
+	if err != nil {
+		return err
+	}
+
+	return hub.ValidateCreate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+// cluster peer is validated by the defaulted hub version, it's rejected if it can't be defaulted
+func (r *ClusterPeer) ValidateUpdate(old runtime.Object) error {
+	hub, err := r.defaultedHub()
+	if err != nil {
+		return err
+	}
+
+	oldHub := &v1alpha1.ClusterPeer{}
+	if err := old.(*ClusterPeer).ConvertTo(oldHub); err != nil {
+		return err
+	}
+
+	return hub.ValidateUpdate(oldHub)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *ClusterPeer) ValidateDelete() error {
+	hub := &v1alpha1.ClusterPeer{}
+	if err := r.ConvertTo(hub); err != nil {
+		return err
+	}
+
+	return hub.ValidateDelete()
+}
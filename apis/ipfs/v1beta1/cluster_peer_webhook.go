@@ -0,0 +1,16 @@
+package v1beta1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// log is for logging in this package.
+var clusterpeerlog = logf.Log.WithName("clusterpeer-resource")
+
+// SetupWebhookWithManager sets up the webook with a given controller manager
+func (r *ClusterPeer) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
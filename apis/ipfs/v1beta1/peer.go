@@ -0,0 +1,126 @@
+package v1beta1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PeerSpec defines the desired state of Peer
+type PeerSpec struct {
+	// InitProfiles is the intial profiles to apply during
+	// +listType=set
+	InitProfiles []Profile `json:"initProfiles,omitempty"`
+	// Profiles is the configuration profiles to apply after peer initialization
+	// +listType=set
+	Profiles []Profile `json:"profiles,omitempty"`
+	// APIPort is api server port
+	APIPort uint `json:"apiPort,omitempty"`
+	// APIHost is api server host
+	APIHost string `json:"apiHost,omitempty"`
+	// GatewayPort is local gateway port
+	GatewayPort uint `json:"gatewayPort,omitempty"`
+	// GatewayHost is local gateway host
+	GatewayHost string `json:"gatewayHost,omitempty"`
+	// Routing is the content routing mechanism
+	Routing RoutingMechanism `json:"routing,omitempty"`
+	// SwarmKeySecretName is the k8s secret holding swarm key
+	SwarmKeySecretName string `json:"swarmKeySecretName,omitempty"`
+	// Logging is logging verboisty level
+	// +kubebuilder:validation:Enum=error;warn;info;debug;notice
+	Logging shared.VerbosityLevel `json:"logging,omitempty"`
+	// Metrics is node prometheus metrics exporter
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+	// Expose is node service exposure outside the cluster
+	Expose *shared.Expose `json:"expose,omitempty"`
+	// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+	Ingress *shared.Ingress `json:"ingress,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
+	// Resources is node compute and storage resources
+	shared.Resources `json:"resources,omitempty"`
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
+}
+
+// Profile is ipfs configuration
+// +kubebuilder:validation:Enum=server;randomports;default-datastore;local-discovery;test;default-networking;flatfs;badgerds;lowpower
+type Profile string
+
+const (
+	// ServerProfile is the server profile
+	ServerProfile Profile = "server"
+	// RandomPortsProfile is the random ports profile
+	RandomPortsProfile Profile = "randomports"
+	// DefaultDatastoreProfile is the default data store profile
+	DefaultDatastoreProfile Profile = "default-datastore"
+	// LocalDiscoveryProfile is the local discovery profile
+	LocalDiscoveryProfile Profile = "local-discovery"
+	// TestProfile is the test profile
+	TestProfile Profile = "test"
+	// DefaultNetworkingProfile is the default networking profile
+	DefaultNetworkingProfile Profile = "default-networking"
+	// FlatFSProfile is the flat file system profile
+	FlatFSProfile Profile = "flatfs"
+	// BadgerDSProfile is badger data store profile
+	BadgerDSProfile Profile = "badgerds"
+	// LowPowerProfile is the low power profile
+	LowPowerProfile Profile = "lowpower"
+)
+
+// RoutingMechanism is the content routing mechanism
+// +kubebuilder:validation:Enum=none;dht;dhtclient;dhtserver
+type RoutingMechanism string
+
+const (
+	// NoneRouting is no routing mechanism
+	NoneRouting RoutingMechanism = "none"
+	// DHTRouting is automatic dht routing mechanism
+	DHTRouting RoutingMechanism = "dht"
+	// DHTClientRouting is the dht client routing mechanism
+	DHTClientRouting RoutingMechanism = "dhtclient"
+	// DHTServerRouting is the dht server routing mechanism
+	DHTServerRouting RoutingMechanism = "dhtserver"
+)
+
+// PeerStatus defines the observed state of Peer
+type PeerStatus struct {
+	shared.Status `json:",inline"`
+	Client        string `json:"client,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// Peer is the Schema for the peers API
+// +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+type Peer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PeerSpec   `json:"spec,omitempty"`
+	Status PeerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PeerList contains a list of Peer
+type PeerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Peer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Peer{}, &PeerList{})
+}
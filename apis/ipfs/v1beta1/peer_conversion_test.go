@@ -0,0 +1,34 @@
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+)
+
+var _ = Describe("IPFS peer conversion", func() {
+
+	It("should convert to the hub version and back", func() {
+		peer := &Peer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-peer",
+				Namespace: "default",
+			},
+			Spec: PeerSpec{
+				APIPort:            5001,
+				SwarmKeySecretName: "swarm-key",
+			},
+		}
+
+		hub := &v1alpha1.Peer{}
+		Expect(peer.ConvertTo(hub)).To(Succeed())
+		Expect(hub.ObjectMeta).To(Equal(peer.ObjectMeta))
+
+		converted := &Peer{}
+		Expect(converted.ConvertFrom(hub)).To(Succeed())
+		Expect(converted).To(Equal(peer))
+	})
+
+})
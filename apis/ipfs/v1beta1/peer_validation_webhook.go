@@ -0,0 +1,49 @@
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-ipfs-kotal-io-v1beta1-peer,mutating=false,failurePolicy=fail,groups=ipfs.kotal.io,resources=peers,versions=v1beta1,name=validate-ipfs-v1beta1-peer.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Peer{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+// peer is validated by the defaulted hub version, it's rejected if it can't be defaulted
+func (r *Peer) ValidateCreate() error {
+	hub, err := r.defaultedHub()
+	if err != nil {
+		return err
+	}
+
+	return hub.ValidateCreate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+// peer is validated by the defaulted hub version, it's rejected if it can't be defaulted
+func (r *Peer) ValidateUpdate(old runtime.Object) error {
+	hub, err := r.defaultedHub()
+	if err != nil {
+		return err
+	}
+
+	oldHub := &v1alpha1.Peer{}
+	if err := old.(*Peer).ConvertTo(oldHub); err != nil {
+		return err
+	}
+
+	return hub.ValidateUpdate(oldHub)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *Peer) ValidateDelete() error {
+	hub := &v1alpha1.Peer{}
+	if err := r.ConvertTo(hub); err != nil {
+		return err
+	}
+
+	return hub.ValidateDelete()
+}
@@ -0,0 +1,12 @@
+package v1alpha1
+
+// NodeManagedFlags is set by NEAR core client package which owns the flags
+var NodeManagedFlags func(*Node) []string
+
+// ManagedFlags returns NEAR core flags set by Kotal that can't be used in extra args
+func (n *Node) ManagedFlags() []string {
+	if NodeManagedFlags == nil {
+		return nil
+	}
+	return NodeManagedFlags(n)
+}
@@ -2,6 +2,8 @@ package v1alpha1
 
 import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
 )
 
 // +kubebuilder:webhook:path=/mutate-near-kotal-io-v1alpha1-node,mutating=true,failurePolicy=fail,groups=near.kotal.io,resources=nodes,verbs=create;update,versions=v1alpha1,name=mutate-near-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
@@ -62,4 +64,14 @@ func (n *Node) Default() {
 		n.Spec.Storage = storage
 	}
 
+	if n.Spec.RetentionPolicy == "" {
+		n.Spec.RetentionPolicy = shared.DefaultRetentionPolicy
+	}
+
+	n.Spec.AutoExpand.Default()
+
+	n.Spec.RPCGateway.Default()
+
+	n.Spec.UpdateStrategy.Default()
+
 }
@@ -6,12 +6,25 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-near-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=near.kotal.io,resources=nodes,versions=v1alpha1,name=validate-near-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
 
 var _ webhook.Validator = &Node{}
 
+// uniqueKeys returns configured spec fields holding keys that can't be shared between replicas
+func (n *Node) uniqueKeys() (keys []string) {
+	if n.Spec.NodePrivateKeySecretName != "" {
+		keys = append(keys, "nodePrivateKeySecretName")
+	}
+	if n.Spec.ValidatorSecretName != "" {
+		keys = append(keys, "validatorSecretName")
+	}
+	return
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (n *Node) ValidateCreate() error {
 	var allErrors field.ErrorList
@@ -19,6 +32,16 @@ func (n *Node) ValidateCreate() error {
 	nodelog.Info("validate create", "name", n.Name)
 
 	allErrors = append(allErrors, n.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, shared.ValidateImage(n.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&n.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&n.Spec.ExtraConfig, "nearcore", n.ManagedFlags(), false)...)
+	allErrors = append(allErrors, shared.ValidateIngress(n.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(n.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateRPCGateway(n.Spec.RPCGateway, n.Spec.RPC, n.Spec.P2PPort, n.Spec.RPCPort, n.Spec.PrometheusPort)...)
+	allErrors = append(allErrors, shared.ValidateExpose(n.Spec.Expose, n.Spec.P2PPort)...)
+	allErrors = append(allErrors, shared.ValidateReplicas(n.Spec.Replicas, n.uniqueKeys()...)...)
+	allErrors = append(allErrors, shared.ValidateUpdateStrategy(n.Spec.UpdateStrategy, n.Spec.Replicas)...)
+	allErrors = append(allErrors, shared.ValidateBootstrap(n.Spec.Bootstrap)...)
 
 	if len(allErrors) == 0 {
 		return nil
@@ -35,6 +58,17 @@ func (n *Node) ValidateUpdate(old runtime.Object) error {
 	nodelog.Info("validate update", "name", n.Name)
 
 	allErrors = append(allErrors, n.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, shared.ValidateImage(n.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&n.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&n.Spec.ExtraConfig, "nearcore", n.ManagedFlags(), false)...)
+	allErrors = append(allErrors, shared.ValidateIngress(n.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(n.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateRPCGateway(n.Spec.RPCGateway, n.Spec.RPC, n.Spec.P2PPort, n.Spec.RPCPort, n.Spec.PrometheusPort)...)
+	allErrors = append(allErrors, shared.ValidateExpose(n.Spec.Expose, n.Spec.P2PPort)...)
+	allErrors = append(allErrors, shared.ValidateReplicas(n.Spec.Replicas, n.uniqueKeys()...)...)
+	allErrors = append(allErrors, shared.ValidateUpdateStrategy(n.Spec.UpdateStrategy, n.Spec.Replicas)...)
+	allErrors = append(allErrors, shared.ValidateReplicasUpdate(n.Spec.Replicas, oldNode.Spec.Replicas)...)
+	allErrors = append(allErrors, shared.ValidateBootstrap(n.Spec.Bootstrap)...)
 
 	if n.Spec.Network != oldNode.Spec.Network {
 		err := field.Invalid(field.NewPath("spec").Child("network"), n.Spec.Network, "field is immutable")
@@ -16,7 +16,28 @@ var _ = Describe("NEAR node validation", func() {
 		Title  string
 		Node   *Node
 		Errors field.ErrorList
-	}{}
+	}{
+		{
+			Title: "invalid image",
+			Node: &Node{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network: "mainnet",
+					Image:   "kotalco/nearcore:",
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.image",
+					BadValue: "kotalco/nearcore:",
+					Detail:   "must be a valid image reference",
+				},
+			},
+		},
+	}
 
 	updateCases := []struct {
 		Title   string
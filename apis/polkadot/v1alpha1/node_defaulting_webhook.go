@@ -2,6 +2,8 @@ package v1alpha1
 
 import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
 )
 
 // +kubebuilder:webhook:path=/mutate-polkadot-kotal-io-v1alpha1-node,mutating=true,failurePolicy=fail,groups=polkadot.kotal.io,resources=nodes,verbs=create;update,versions=v1alpha1,name=mutate-polkadot-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
@@ -28,6 +30,12 @@ func (r *Node) DefaultNodeResources() {
 	if r.Spec.Resources.Storage == "" {
 		r.Spec.Resources.Storage = DefaultNodeStorageRequest
 	}
+
+	if r.Spec.Resources.RetentionPolicy == "" {
+		r.Spec.Resources.RetentionPolicy = shared.DefaultRetentionPolicy
+	}
+
+	r.Spec.Resources.AutoExpand.Default()
 }
 
 // Default implements webhook.Defaulter so a webhook will be registered for the type
@@ -77,4 +85,8 @@ func (r *Node) Default() {
 		r.Spec.PrometheusPort = DefaultPrometheusPort
 	}
 
+	r.Spec.RPCGateway.Default()
+
+	r.Spec.UpdateStrategy.Default()
+
 }
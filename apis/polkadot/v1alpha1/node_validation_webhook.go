@@ -6,6 +6,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-polkadot-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=polkadot.kotal.io,resources=nodes,versions=v1alpha1,name=validate-polkadot-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
@@ -38,6 +40,17 @@ func (r *Node) validate() field.ErrorList {
 	return nodeErrors
 }
 
+// uniqueKeys returns configured spec fields holding keys that can't be shared between replicas
+func (r *Node) uniqueKeys() (keys []string) {
+	if r.Spec.NodePrivateKeySecretName != "" {
+		keys = append(keys, "nodePrivateKeySecretName")
+	}
+	if r.Spec.Validator {
+		keys = append(keys, "validator")
+	}
+	return
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *Node) ValidateCreate() error {
 	var allErrors field.ErrorList
@@ -46,6 +59,16 @@ func (r *Node) ValidateCreate() error {
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, shared.ValidateImage(r.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&r.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&r.Spec.ExtraConfig, "polkadot", r.ManagedFlags(), false)...)
+	allErrors = append(allErrors, shared.ValidateIngress(r.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(r.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateRPCGateway(r.Spec.RPCGateway, r.Spec.RPC, r.Spec.P2PPort, r.Spec.RPCPort, r.Spec.WSPort, r.Spec.PrometheusPort)...)
+	allErrors = append(allErrors, shared.ValidateExpose(r.Spec.Expose, r.Spec.P2PPort)...)
+	allErrors = append(allErrors, shared.ValidateReplicas(r.Spec.Replicas, r.uniqueKeys()...)...)
+	allErrors = append(allErrors, shared.ValidateUpdateStrategy(r.Spec.UpdateStrategy, r.Spec.Replicas)...)
+	allErrors = append(allErrors, shared.ValidateBootstrap(r.Spec.Bootstrap)...)
 
 	if len(allErrors) == 0 {
 		return nil
@@ -63,6 +86,17 @@ func (r *Node) ValidateUpdate(old runtime.Object) error {
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, shared.ValidateImage(r.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&r.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&r.Spec.ExtraConfig, "polkadot", r.ManagedFlags(), false)...)
+	allErrors = append(allErrors, shared.ValidateIngress(r.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(r.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateRPCGateway(r.Spec.RPCGateway, r.Spec.RPC, r.Spec.P2PPort, r.Spec.RPCPort, r.Spec.WSPort, r.Spec.PrometheusPort)...)
+	allErrors = append(allErrors, shared.ValidateExpose(r.Spec.Expose, r.Spec.P2PPort)...)
+	allErrors = append(allErrors, shared.ValidateReplicas(r.Spec.Replicas, r.uniqueKeys()...)...)
+	allErrors = append(allErrors, shared.ValidateUpdateStrategy(r.Spec.UpdateStrategy, r.Spec.Replicas)...)
+	allErrors = append(allErrors, shared.ValidateReplicasUpdate(r.Spec.Replicas, oldNode.Spec.Replicas)...)
+	allErrors = append(allErrors, shared.ValidateBootstrap(r.Spec.Bootstrap)...)
 
 	if r.Spec.Network != oldNode.Spec.Network {
 		err := field.Invalid(field.NewPath("spec").Child("network"), r.Spec.Network, "field is immutable")
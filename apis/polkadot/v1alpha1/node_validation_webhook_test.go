@@ -81,6 +81,27 @@ var _ = Describe("Polkadot node validation", func() {
 				},
 			},
 		},
+		{
+			Title: "replicated validator node",
+			Node: &Node{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network:   "kusama",
+					Validator: true,
+					Replicas:  2,
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.replicas",
+					BadValue: uint(2),
+					Detail:   "must be 1 if validator is set, it can't be shared between replicas",
+				},
+			},
+		},
 	}
 
 	updateCases := []struct {
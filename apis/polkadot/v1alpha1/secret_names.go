@@ -0,0 +1,13 @@
+package v1alpha1
+
+import "github.com/kotalco/kotal/apis/shared"
+
+// SecretNames returns names of k8s secrets referenced by node spec
+func (r *Node) SecretNames() []string {
+	names := []string{r.Spec.NodePrivateKeySecretName}
+	if r.Spec.RPCGateway != nil {
+		names = append(names, r.Spec.RPCGateway.SecretName)
+	}
+	names = append(names, r.Spec.EnvSecretNames()...)
+	return shared.SecretNames(names...)
+}
@@ -0,0 +1,122 @@
+package v1beta1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SynchronizationMode is the blockchain synchronization mode
+// +kubebuilder:validation:Enum=fast;full
+type SynchronizationMode string
+
+const (
+	//FastSynchronization is the fast synchronization mode
+	FastSynchronization SynchronizationMode = "fast"
+
+	//FullSynchronization is the full archival synchronization mode
+	FullSynchronization SynchronizationMode = "full"
+)
+
+// NodeSpec defines the desired state of Node
+type NodeSpec struct {
+	// Network is the polkadot network/chain to join
+	Network string `json:"network"`
+	// P2PPort is p2p protocol tcp port
+	P2PPort uint `json:"p2pPort,omitempty"`
+	// NodePrivateKeySecretName is the secret name holding node Ed25519 private key
+	NodePrivateKeySecretName string `json:"nodePrivateKeySecretName,omitempty"`
+	// Validator enables validator mode
+	Validator bool `json:"validator,omitempty"`
+	// SyncMode is the blockchain synchronization mode
+	SyncMode SynchronizationMode `json:"syncMode,omitempty"`
+	// Pruning keeps recent or all blocks
+	Pruning *bool `json:"pruning,omitempty"`
+	// RetainedBlocks is the number of blocks to keep state for
+	RetainedBlocks uint `json:"retainedBlocks,omitempty"`
+	// Logging is logging verboisty level
+	// +kubebuilder:validation:Enum=error;warn;info;debug;trace
+	Logging shared.VerbosityLevel `json:"logging,omitempty"`
+	// Telemetry enables connecting to telemetry server
+	Telemetry bool `json:"telemetry,omitempty"`
+	// TelemetryURL is telemetry service URL
+	TelemetryURL string `json:"telemetryURL,omitempty"`
+	// Prometheus exposes a prometheus exporter endpoint.
+	Prometheus bool `json:"prometheus,omitempty"`
+	// PrometheusPort is prometheus exporter port
+	PrometheusPort uint `json:"prometheusPort,omitempty"`
+	// RPC enables JSON-RPC server
+	RPC bool `json:"rpc,omitempty"`
+	// RPCPort is JSON-RPC server port
+	RPCPort uint `json:"rpcPort,omitempty"`
+	// WS enables Websocket server
+	WS bool `json:"ws,omitempty"`
+	// WSPort is Websocket server port
+	WSPort uint `json:"wsPort,omitempty"`
+	// CORSDomains is browser origins allowed to access the JSON-RPC HTTP and WS servers
+	// +listType=set
+	CORSDomains []string `json:"corsDomains,omitempty"`
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+	// Bootstrap is node data bootstrapping from a remote snapshot archive
+	Bootstrap *shared.Bootstrap `json:"bootstrap,omitempty"`
+	// Expose is node service exposure outside the cluster
+	Expose *shared.Expose `json:"expose,omitempty"`
+	// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+	Ingress *shared.Ingress `json:"ingress,omitempty"`
+	// RPCGateway is authenticating and rate-limiting JSON-RPC gateway sidecar in front of node RPC port
+	RPCGateway *shared.RPCGateway `json:"rpcGateway,omitempty"`
+	// Replicas is node pods count behind node service, each replica has its own data volume
+	// it must be 1 if node is configured with keys or accounts that can't be shared between replicas
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	Replicas uint `json:"replicas,omitempty"`
+	// UpdateStrategy is node pods update strategy, canary updates require more than one replica
+	UpdateStrategy *shared.UpdateStrategy `json:"updateStrategy,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
+	// Resources is node compute and storage resources
+	shared.Resources `json:"resources,omitempty"`
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
+}
+
+// NodeStatus defines the observed state of Node
+type NodeStatus struct {
+	shared.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// Node is the Schema for the nodes API
+// +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
+// +kubebuilder:printcolumn:name="Validator",type=boolean,JSONPath=".spec.validator"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+type Node struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeSpec   `json:"spec,omitempty"`
+	Status NodeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeList contains a list of Node
+type NodeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Node `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Node{}, &NodeList{})
+}
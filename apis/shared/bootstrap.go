@@ -0,0 +1,35 @@
+package shared
+
+import (
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Bootstrap is node data bootstrapping from a remote snapshot archive
+// +k8s:deepcopy-gen=true
+type Bootstrap struct {
+	// SnapshotURL is http(s) URL of tar archive (optionally gzip, bzip2 or xz compressed) holding node data
+	// archive is extracted into node data directory only if it's empty
+	SnapshotURL string `json:"snapshotURL"`
+	// Checksum is hex-encoded SHA-256 checksum of the snapshot archive
+	// +kubebuilder:validation:Pattern="^[0-9a-fA-F]{64}$"
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// ValidateBootstrap validates node bootstrap snapshot if provided
+func ValidateBootstrap(bootstrap *Bootstrap) (errors field.ErrorList) {
+	if bootstrap == nil {
+		return
+	}
+
+	path := field.NewPath("spec").Child("bootstrap")
+
+	u, err := url.Parse(bootstrap.SnapshotURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		err := field.Invalid(path.Child("snapshotURL"), bootstrap.SnapshotURL, "must be a valid http or https URL")
+		errors = append(errors, err)
+	}
+
+	return
+}
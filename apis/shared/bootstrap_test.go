@@ -0,0 +1,45 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Bootstrap validation", func() {
+	It("Should accept missing bootstrap", func() {
+		Expect(ValidateBootstrap(nil)).To(BeEmpty())
+	})
+
+	valid := []string{
+		"https://near-protocol-public.s3.ca-central-1.amazonaws.com/backups/mainnet/rpc/data.tar",
+		"http://snapshots.example.com:8080/polkadot/db.tar.gz",
+	}
+
+	for _, snapshotURL := range valid {
+		snapshotURL := snapshotURL
+		It("Should accept snapshot URL "+snapshotURL, func() {
+			Expect(ValidateBootstrap(&Bootstrap{SnapshotURL: snapshotURL})).To(BeEmpty())
+		})
+	}
+
+	invalid := []string{
+		"",
+		"snapshots.example.com/db.tar",
+		"ftp://snapshots.example.com/db.tar",
+		"s3://bucket/db.tar",
+		"https:///db.tar",
+	}
+
+	for _, snapshotURL := range invalid {
+		snapshotURL := snapshotURL
+		It("Should reject snapshot URL "+snapshotURL, func() {
+			Expect(ValidateBootstrap(&Bootstrap{SnapshotURL: snapshotURL})).To(ContainElement(&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.bootstrap.snapshotURL",
+				BadValue: snapshotURL,
+				Detail:   "must be a valid http or https URL",
+			}))
+		})
+	}
+})
@@ -0,0 +1,13 @@
+package shared
+
+import "encoding/json"
+
+// ConvertJSON converts src into dst of another API version using their JSON representation
+// fields renamed between versions are dropped and have to be converted by the caller
+func ConvertJSON(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
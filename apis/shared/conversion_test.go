@@ -0,0 +1,27 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSON conversion", func() {
+
+	It("should convert fields with the same JSON name", func() {
+		type v1 struct {
+			RpcPort              uint   `json:"rpcPort"`
+			WalletPasswordSecret string `json:"walletPasswordSecret"`
+		}
+		type v2 struct {
+			RPCPort                  uint   `json:"rpcPort"`
+			WalletPasswordSecretName string `json:"walletPasswordSecretName"`
+		}
+
+		dst := &v2{}
+		Expect(ConvertJSON(&v1{RpcPort: 8332, WalletPasswordSecret: "wallet-password"}, dst)).To(Succeed())
+		Expect(dst.RPCPort).To(Equal(uint(8332)))
+		// renamed JSON fields are converted by the caller
+		Expect(dst.WalletPasswordSecretName).To(BeEmpty())
+	})
+
+})
@@ -0,0 +1,30 @@
+package shared
+
+// DisruptionPolicy is node pods voluntary disruption policy during cluster maintenance like node drains
+// +kubebuilder:validation:Enum=Auto;Protect;Allow
+type DisruptionPolicy string
+
+const (
+	// AutoDisruptionPolicy protects pods of nodes producing or signing blocks from voluntary disruptions
+	AutoDisruptionPolicy DisruptionPolicy = "Auto"
+	// ProtectDisruptionPolicy protects node pods from voluntary disruptions
+	ProtectDisruptionPolicy DisruptionPolicy = "Protect"
+	// AllowDisruptionPolicy allows voluntary disruptions of node pods
+	AllowDisruptionPolicy DisruptionPolicy = "Allow"
+)
+
+// DefaultDisruptionPolicy is the default node pods voluntary disruption policy
+const DefaultDisruptionPolicy = AutoDisruptionPolicy
+
+// Protects checks if node pods are protected from voluntary disruptions
+// critical is whether node produces or signs blocks
+func (p DisruptionPolicy) Protects(critical bool) bool {
+	switch p {
+	case ProtectDisruptionPolicy:
+		return true
+	case AllowDisruptionPolicy:
+		return false
+	default:
+		return critical
+	}
+}
@@ -0,0 +1,22 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Disruption policy", func() {
+
+	It("Should protect critical nodes by default", func() {
+		Expect(DisruptionPolicy("").Protects(true)).To(BeTrue())
+		Expect(DisruptionPolicy("").Protects(false)).To(BeFalse())
+		Expect(AutoDisruptionPolicy.Protects(true)).To(BeTrue())
+		Expect(AutoDisruptionPolicy.Protects(false)).To(BeFalse())
+	})
+
+	It("Should override node criticality", func() {
+		Expect(ProtectDisruptionPolicy.Protects(false)).To(BeTrue())
+		Expect(AllowDisruptionPolicy.Protects(true)).To(BeFalse())
+	})
+
+})
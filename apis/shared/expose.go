@@ -0,0 +1,86 @@
+package shared
+
+import (
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// MinNodePort is the lowest port in k8s default node port range
+	MinNodePort uint = 30000
+	// MaxNodePort is the highest port in k8s default node port range
+	MaxNodePort uint = 32767
+)
+
+// Expose is node service exposure outside the cluster
+// +k8s:deepcopy-gen=true
+type Expose struct {
+	// ServiceType is node service type
+	// node ports of NodePort services are pinned to node port, or node p2p port(s) if node port isn't set
+	// +kubebuilder:validation:Enum=NodePort;LoadBalancer
+	ServiceType corev1.ServiceType `json:"serviceType"`
+	// Annotations is extra annotations added to node service
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// ExternalIP is static external IP address announced to peers
+	// it's requested as load balancer IP for LoadBalancer services
+	ExternalIP string `json:"externalIP,omitempty"`
+	// NodePort is the node port p2p port(s) are exposed at for NodePort service type
+	// it's announced to peers instead of p2p port
+	// +kubebuilder:validation:Minimum=30000
+	// +kubebuilder:validation:Maximum=32767
+	NodePort uint `json:"nodePort,omitempty"`
+}
+
+// ValidateExpose validates node service exposure if provided
+func ValidateExpose(expose *Expose, p2pPort uint) (errors field.ErrorList) {
+	if expose == nil {
+		return
+	}
+
+	path := field.NewPath("spec").Child("expose")
+
+	if expose.NodePort != 0 {
+		if expose.ServiceType != corev1.ServiceTypeNodePort {
+			err := field.Invalid(path.Child("nodePort"), expose.NodePort, "can't be set unless service type is NodePort")
+			errors = append(errors, err)
+		} else if expose.NodePort < MinNodePort || expose.NodePort > MaxNodePort {
+			msg := fmt.Sprintf("must be in node port range %d-%d", MinNodePort, MaxNodePort)
+			err := field.Invalid(path.Child("nodePort"), expose.NodePort, msg)
+			errors = append(errors, err)
+		}
+	} else if expose.ServiceType == corev1.ServiceTypeNodePort && (p2pPort < MinNodePort || p2pPort > MaxNodePort) {
+		msg := fmt.Sprintf("must be in node port range %d-%d for NodePort service type without node port", MinNodePort, MaxNodePort)
+		err := field.Invalid(field.NewPath("spec").Child("p2pPort"), p2pPort, msg)
+		errors = append(errors, err)
+	}
+
+	if expose.ExternalIP != "" && net.ParseIP(expose.ExternalIP) == nil {
+		err := field.Invalid(path.Child("externalIP"), expose.ExternalIP, "must be a valid IP address")
+		errors = append(errors, err)
+	}
+
+	errors = append(errors, apivalidation.ValidateAnnotations(expose.Annotations, path.Child("annotations"))...)
+
+	return
+}
+
+// GetExternalIP returns static external IP announced to peers, or empty string if not set
+func (e *Expose) GetExternalIP() string {
+	if e == nil {
+		return ""
+	}
+	return e.ExternalIP
+}
+
+// AnnouncedPort returns p2p port announced to peers
+// it's the node port of NodePort services if set, otherwise p2p port
+func (e *Expose) AnnouncedPort(p2pPort uint) uint {
+	if e == nil || e.ServiceType != corev1.ServiceTypeNodePort || e.NodePort == 0 {
+		return p2pPort
+	}
+	return e.NodePort
+}
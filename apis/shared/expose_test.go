@@ -0,0 +1,98 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Expose validation", func() {
+	It("Should accept missing expose", func() {
+		Expect(ValidateExpose(nil, 30303)).To(BeEmpty())
+	})
+
+	It("Should accept load balancer with static external IP", func() {
+		expose := &Expose{
+			ServiceType: corev1.ServiceTypeLoadBalancer,
+			ExternalIP:  "203.0.113.10",
+			Annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type": "nlb",
+			},
+		}
+		Expect(ValidateExpose(expose, 9000)).To(BeEmpty())
+	})
+
+	It("Should reject invalid external IP", func() {
+		expose := &Expose{
+			ServiceType: corev1.ServiceTypeLoadBalancer,
+			ExternalIP:  "203.0.113",
+		}
+		Expect(ValidateExpose(expose, 30303)).To(ContainElement(&field.Error{
+			Type:     field.ErrorTypeInvalid,
+			Field:    "spec.expose.externalIP",
+			BadValue: "203.0.113",
+			Detail:   "must be a valid IP address",
+		}))
+	})
+
+	It("Should reject p2p port outside node port range", func() {
+		expose := &Expose{
+			ServiceType: corev1.ServiceTypeNodePort,
+		}
+		Expect(ValidateExpose(expose, 9000)).To(ContainElement(&field.Error{
+			Type:     field.ErrorTypeInvalid,
+			Field:    "spec.p2pPort",
+			BadValue: uint(9000),
+			Detail:   "must be in node port range 30000-32767 for NodePort service type without node port",
+		}))
+	})
+
+	It("Should accept p2p port outside node port range with node port", func() {
+		expose := &Expose{
+			ServiceType: corev1.ServiceTypeNodePort,
+			NodePort:    30303,
+		}
+		Expect(ValidateExpose(expose, 9000)).To(BeEmpty())
+		Expect(expose.AnnouncedPort(9000)).To(Equal(uint(30303)))
+	})
+
+	It("Should reject node port outside node port range", func() {
+		expose := &Expose{
+			ServiceType: corev1.ServiceTypeNodePort,
+			NodePort:    9000,
+		}
+		Expect(ValidateExpose(expose, 30303)).To(ContainElement(&field.Error{
+			Type:     field.ErrorTypeInvalid,
+			Field:    "spec.expose.nodePort",
+			BadValue: uint(9000),
+			Detail:   "must be in node port range 30000-32767",
+		}))
+	})
+
+	It("Should reject node port for load balancer", func() {
+		expose := &Expose{
+			ServiceType: corev1.ServiceTypeLoadBalancer,
+			NodePort:    30303,
+		}
+		Expect(ValidateExpose(expose, 9000)).To(ContainElement(&field.Error{
+			Type:     field.ErrorTypeInvalid,
+			Field:    "spec.expose.nodePort",
+			BadValue: uint(30303),
+			Detail:   "can't be set unless service type is NodePort",
+		}))
+		Expect(expose.AnnouncedPort(9000)).To(Equal(uint(9000)))
+	})
+
+	It("Should reject invalid service annotations", func() {
+		expose := &Expose{
+			ServiceType: corev1.ServiceTypeLoadBalancer,
+			Annotations: map[string]string{
+				"not valid": "nlb",
+			},
+		}
+		errors := ValidateExpose(expose, 30303)
+		Expect(errors).NotTo(BeEmpty())
+		Expect(errors[0].Field).To(Equal("spec.expose.annotations"))
+	})
+})
@@ -0,0 +1,83 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ExtraConfig is node client arguments, environment variables and config options not managed by Kotal
+// +k8s:deepcopy-gen=true
+type ExtraConfig struct {
+	// ExtraArgs is extra arguments appended to node client arguments, flags managed by Kotal can't be used
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+	// ExtraEnv is extra environment variables set in node container
+	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+	// ConfigOverrides is config options merged into node config file generated by Kotal
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ConfigOverrides *runtime.RawExtension `json:"configOverrides,omitempty"`
+}
+
+// FlagName returns argument flag name without leading dashes and value
+// empty string is returned if argument is not a flag
+func FlagName(arg string) string {
+	if !strings.HasPrefix(arg, "-") {
+		return ""
+	}
+	return strings.SplitN(strings.TrimLeft(arg, "-"), "=", 2)[0]
+}
+
+// ValidateExtraConfig validates extra args don't use flags managed by Kotal
+// and config overrides are only used by clients with config file generated by Kotal
+func ValidateExtraConfig(extra *ExtraConfig, client string, managed []string, configurable bool) (errors field.ErrorList) {
+	managedFlags := map[string]bool{}
+	for _, flag := range managed {
+		managedFlags[FlagName(flag)] = true
+	}
+
+	for i, arg := range extra.ExtraArgs {
+		if name := FlagName(arg); managedFlags[name] {
+			msg := fmt.Sprintf("flag %s is managed by Kotal for %s client", name, client)
+			errors = append(errors, field.Invalid(field.NewPath("spec").Child("extraArgs").Index(i), arg, msg))
+		}
+	}
+
+	for i, env := range extra.ExtraEnv {
+		if env.Name == "" {
+			errors = append(errors, field.Required(field.NewPath("spec").Child("extraEnv").Index(i).Child("name"), ""))
+		}
+	}
+
+	if extra.ConfigOverrides == nil {
+		return
+	}
+
+	path := field.NewPath("spec").Child("configOverrides")
+
+	if !configurable {
+		msg := fmt.Sprintf("config overrides are not supported by %s client, use extraArgs instead", client)
+		errors = append(errors, field.Forbidden(path, msg))
+		return
+	}
+
+	overrides := map[string]interface{}{}
+	if err := json.Unmarshal(extra.ConfigOverrides.Raw, &overrides); err != nil {
+		errors = append(errors, field.Invalid(path, string(extra.ConfigOverrides.Raw), "must be an object"))
+	}
+
+	return
+}
+
+// EnvSecretNames returns names of secrets referenced by extra environment variables
+func (e *ExtraConfig) EnvSecretNames() (names []string) {
+	for _, env := range e.ExtraEnv {
+		if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+			names = append(names, env.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+	return
+}
@@ -0,0 +1,114 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Extra config validation", func() {
+	managed := []string{"--datadir", "--http.port", "-rpcport"}
+
+	It("Should return flag name", func() {
+		Expect(FlagName("--http.port=8545")).To(Equal("http.port"))
+		Expect(FlagName("-rpcport")).To(Equal("rpcport"))
+		Expect(FlagName("--snapshot")).To(Equal("snapshot"))
+		Expect(FlagName("false")).To(Equal(""))
+	})
+
+	It("Should accept extra args that aren't managed by Kotal", func() {
+		extra := &ExtraConfig{
+			ExtraArgs: []string{"--cache=4096", "--txlookuplimit", "0", "-dbcache=1000"},
+			ExtraEnv:  []corev1.EnvVar{{Name: "GOGC", Value: "50"}},
+		}
+		Expect(ValidateExtraConfig(extra, "geth", managed, false)).To(BeEmpty())
+	})
+
+	It("Should reject extra args managed by Kotal", func() {
+		extra := &ExtraConfig{
+			ExtraArgs: []string{"--cache=4096", "--datadir=/data", "--rpcport", "8332"},
+		}
+		Expect(ValidateExtraConfig(extra, "geth", managed, false)).To(ContainElements(
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.extraArgs[1]",
+				BadValue: "--datadir=/data",
+				Detail:   "flag datadir is managed by Kotal for geth client",
+			},
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.extraArgs[2]",
+				BadValue: "--rpcport",
+				Detail:   "flag rpcport is managed by Kotal for geth client",
+			},
+		))
+	})
+
+	It("Should reject extra env without name", func() {
+		extra := &ExtraConfig{
+			ExtraEnv: []corev1.EnvVar{{Value: "50"}},
+		}
+		Expect(ValidateExtraConfig(extra, "geth", managed, false)).To(ContainElement(&field.Error{
+			Type:     field.ErrorTypeRequired,
+			Field:    "spec.extraEnv[0].name",
+			BadValue: "",
+			Detail:   "",
+		}))
+	})
+
+	It("Should accept config overrides for clients with generated config", func() {
+		extra := &ExtraConfig{
+			ConfigOverrides: &runtime.RawExtension{Raw: []byte(`{"Chainstore":{"EnableSplitstore":true}}`)},
+		}
+		Expect(ValidateExtraConfig(extra, "lotus", nil, true)).To(BeEmpty())
+	})
+
+	It("Should reject config overrides that aren't objects", func() {
+		extra := &ExtraConfig{
+			ConfigOverrides: &runtime.RawExtension{Raw: []byte(`["miner"]`)},
+		}
+		Expect(ValidateExtraConfig(extra, "lotus", nil, true)).To(ContainElement(&field.Error{
+			Type:     field.ErrorTypeInvalid,
+			Field:    "spec.configOverrides",
+			BadValue: `["miner"]`,
+			Detail:   "must be an object",
+		}))
+	})
+
+	It("Should reject config overrides for clients without generated config", func() {
+		extra := &ExtraConfig{
+			ConfigOverrides: &runtime.RawExtension{Raw: []byte(`{"cache":4096}`)},
+		}
+		Expect(ValidateExtraConfig(extra, "geth", managed, false)).To(ContainElement(&field.Error{
+			Type:     field.ErrorTypeForbidden,
+			Field:    "spec.configOverrides",
+			BadValue: "",
+			Detail:   "config overrides are not supported by geth client, use extraArgs instead",
+		}))
+	})
+
+	It("Should return secrets referenced by extra env", func() {
+		extra := &ExtraConfig{
+			ExtraEnv: []corev1.EnvVar{
+				{Name: "GOGC", Value: "50"},
+				{
+					Name: "API_TOKEN",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "api-token"},
+							Key:                  "token",
+						},
+					},
+				},
+			},
+		}
+		Expect(extra.EnvSecretNames()).To(Equal([]string{"api-token"}))
+	})
+
+	It("Should return sorted unique secret names", func() {
+		Expect(SecretNames("tls", "", "password", "tls")).To(Equal([]string{"password", "tls"}))
+		Expect(SecretNames()).To(BeEmpty())
+	})
+})
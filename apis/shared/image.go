@@ -0,0 +1,34 @@
+package shared
+
+import (
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// imageReferenceRegexp matches container image references
+// [registry[:port]/]repository[:tag][@digest]
+var imageReferenceRegexp = regexp.MustCompile(`^` +
+	// registry host with optional port
+	`(?:(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])(?:\.(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]))*(?::[0-9]+)?/)?` +
+	// repository path components
+	`[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*)*` +
+	// tag
+	`(?::[\w][\w.-]{0,127})?` +
+	// digest
+	`(?:@[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,})?` +
+	`$`)
+
+// ValidateImage validates node container image reference if provided
+func ValidateImage(image string) (errors field.ErrorList) {
+	if image == "" {
+		return
+	}
+
+	if len(image) > 255 || !imageReferenceRegexp.MatchString(image) {
+		err := field.Invalid(field.NewPath("spec").Child("image"), image, "must be a valid image reference")
+		errors = append(errors, err)
+	}
+
+	return
+}
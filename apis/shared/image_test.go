@@ -0,0 +1,46 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Image validation", func() {
+	valid := []string{
+		"",
+		"busybox",
+		"ethereum/client-go:v1.10.16",
+		"kotalco/teku:v22.3.2",
+		"ghcr.io/paritytech/polkadot:v0.9.18",
+		"localhost:5000/geth",
+		"registry.example.com:443/team/geth:latest@sha256:ea8fbf4a0f7fa1b4de4b9f2b1f1b9d3a1f8c7b0f2b6a91c4e4a4f6c1b2d3e4f5",
+	}
+
+	for _, image := range valid {
+		image := image
+		It("Should accept image "+image, func() {
+			Expect(ValidateImage(image)).To(BeEmpty())
+		})
+	}
+
+	invalid := []string{
+		"ethereum/Client-go",
+		"ethereum/client-go:",
+		"ethereum/client-go:v1.10 16",
+		"ethereum//client-go",
+		"ethereum/client-go@sha256:abc",
+	}
+
+	for _, image := range invalid {
+		image := image
+		It("Should reject image "+image, func() {
+			Expect(ValidateImage(image)).To(ContainElement(&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.image",
+				BadValue: image,
+				Detail:   "must be a valid image reference",
+			}))
+		})
+	}
+})
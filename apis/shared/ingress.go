@@ -0,0 +1,99 @@
+package shared
+
+import (
+	"strings"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// IngressKind is the kind of generated route to node API endpoints
+// +kubebuilder:validation:Enum=Ingress;HTTPRoute
+type IngressKind string
+
+const (
+	// IngressKindIngress is networking.k8s.io/v1 Ingress
+	IngressKindIngress IngressKind = "Ingress"
+	// IngressKindHTTPRoute is gateway API HTTPRoute
+	IngressKindHTTPRoute IngressKind = "HTTPRoute"
+)
+
+// IssuerKind is cert-manager issuer kind
+// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+type IssuerKind string
+
+const (
+	// IssuerKindIssuer is namespaced cert-manager issuer
+	IssuerKindIssuer IssuerKind = "Issuer"
+	// IssuerKindClusterIssuer is cluster-wide cert-manager issuer
+	IssuerKindClusterIssuer IssuerKind = "ClusterIssuer"
+)
+
+// IngressHost routes hostname to node API endpoint
+// +k8s:deepcopy-gen=true
+type IngressHost struct {
+	// Hostname is fully qualified domain name routed to node API endpoint
+	Hostname string `json:"hostname"`
+	// Endpoint is node API endpoint (service port name) like rpc or ws
+	// defaults to node first enabled API endpoint
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+// +k8s:deepcopy-gen=true
+type Ingress struct {
+	// Kind is generated route kind
+	// +kubebuilder:default=Ingress
+	Kind IngressKind `json:"kind,omitempty"`
+	// Hosts is hostnames routed to node API endpoints
+	// +kubebuilder:validation:MinItems=1
+	Hosts []IngressHost `json:"hosts"`
+	// ClassName is ingress class name
+	ClassName string `json:"className,omitempty"`
+	// Gateway is HTTP route parent gateway in the form [namespace/]name
+	Gateway string `json:"gateway,omitempty"`
+	// Issuer is cert-manager issuer name used to issue ingress TLS certificate
+	Issuer string `json:"issuer,omitempty"`
+	// IssuerKind is cert-manager issuer kind
+	// +kubebuilder:default=ClusterIssuer
+	IssuerKind IssuerKind `json:"issuerKind,omitempty"`
+	// Annotations is extra annotations added to generated route
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ValidateIngress validates node ingress if provided
+func ValidateIngress(ingress *Ingress) (errors field.ErrorList) {
+	if ingress == nil {
+		return
+	}
+
+	path := field.NewPath("spec").Child("ingress")
+
+	for i, host := range ingress.Hosts {
+		for _, msg := range validation.IsDNS1123Subdomain(host.Hostname) {
+			err := field.Invalid(path.Child("hosts").Index(i).Child("hostname"), host.Hostname, msg)
+			errors = append(errors, err)
+		}
+	}
+
+	if ingress.Kind == IngressKindHTTPRoute {
+		if ingress.Gateway == "" {
+			errors = append(errors, field.Required(path.Child("gateway"), "must be provided for HTTPRoute kind"))
+		} else if len(strings.Split(ingress.Gateway, "/")) > 2 {
+			errors = append(errors, field.Invalid(path.Child("gateway"), ingress.Gateway, "must be in the form [namespace/]name"))
+		}
+		if ingress.Issuer != "" {
+			errors = append(errors, field.Forbidden(path.Child("issuer"), "is only supported for Ingress kind, issue gateway listener certificate instead"))
+		}
+		if ingress.ClassName != "" {
+			errors = append(errors, field.Forbidden(path.Child("className"), "is only supported for Ingress kind"))
+		}
+	} else if ingress.Gateway != "" {
+		errors = append(errors, field.Forbidden(path.Child("gateway"), "is only supported for HTTPRoute kind"))
+	}
+
+	errors = append(errors, apivalidation.ValidateAnnotations(ingress.Annotations, path.Child("annotations"))...)
+
+	return
+}
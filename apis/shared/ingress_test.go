@@ -0,0 +1,86 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Ingress validation", func() {
+	It("Should accept missing ingress", func() {
+		Expect(ValidateIngress(nil)).To(BeEmpty())
+	})
+
+	It("Should accept ingress with cert-manager issuer", func() {
+		ingress := &Ingress{
+			Kind: IngressKindIngress,
+			Hosts: []IngressHost{
+				{Hostname: "rpc.example.com", Endpoint: "rpc"},
+				{Hostname: "ws.example.com", Endpoint: "ws"},
+			},
+			ClassName:  "nginx",
+			Issuer:     "letsencrypt",
+			IssuerKind: IssuerKindClusterIssuer,
+		}
+		Expect(ValidateIngress(ingress)).To(BeEmpty())
+	})
+
+	It("Should accept HTTP route with parent gateway", func() {
+		ingress := &Ingress{
+			Kind:    IngressKindHTTPRoute,
+			Hosts:   []IngressHost{{Hostname: "rpc.example.com"}},
+			Gateway: "gateways/public",
+		}
+		Expect(ValidateIngress(ingress)).To(BeEmpty())
+	})
+
+	It("Should reject invalid hostname", func() {
+		ingress := &Ingress{
+			Hosts: []IngressHost{{Hostname: "RPC_example.com"}},
+		}
+		errors := ValidateIngress(ingress)
+		Expect(errors).NotTo(BeEmpty())
+		Expect(errors[0].Field).To(Equal("spec.ingress.hosts[0].hostname"))
+	})
+
+	It("Should reject HTTP route without parent gateway", func() {
+		ingress := &Ingress{
+			Kind:  IngressKindHTTPRoute,
+			Hosts: []IngressHost{{Hostname: "rpc.example.com"}},
+		}
+		Expect(ValidateIngress(ingress)).To(ContainElement(&field.Error{
+			Type:     field.ErrorTypeRequired,
+			Field:    "spec.ingress.gateway",
+			BadValue: "",
+			Detail:   "must be provided for HTTPRoute kind",
+		}))
+	})
+
+	It("Should reject cert-manager issuer for HTTP route", func() {
+		ingress := &Ingress{
+			Kind:    IngressKindHTTPRoute,
+			Hosts:   []IngressHost{{Hostname: "rpc.example.com"}},
+			Gateway: "public",
+			Issuer:  "letsencrypt",
+		}
+		Expect(ValidateIngress(ingress)).To(ContainElement(&field.Error{
+			Type:     field.ErrorTypeForbidden,
+			Field:    "spec.ingress.issuer",
+			BadValue: "",
+			Detail:   "is only supported for Ingress kind, issue gateway listener certificate instead",
+		}))
+	})
+
+	It("Should reject parent gateway for ingress", func() {
+		ingress := &Ingress{
+			Hosts:   []IngressHost{{Hostname: "rpc.example.com"}},
+			Gateway: "public",
+		}
+		Expect(ValidateIngress(ingress)).To(ContainElement(&field.Error{
+			Type:     field.ErrorTypeForbidden,
+			Field:    "spec.ingress.gateway",
+			BadValue: "",
+			Detail:   "is only supported for HTTPRoute kind",
+		}))
+	})
+})
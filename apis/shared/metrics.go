@@ -0,0 +1,42 @@
+package shared
+
+import "k8s.io/apimachinery/pkg/util/validation/field"
+
+// Metrics is node prometheus metrics exporter
+// +k8s:deepcopy-gen=true
+type Metrics struct {
+	// Enabled enables prometheus metrics exporter
+	Enabled bool `json:"enabled,omitempty"`
+	// Port is metrics exporter listening port
+	Port uint `json:"port,omitempty"`
+	// Host is metrics exporter listening host
+	Host string `json:"host,omitempty"`
+}
+
+// Default sets metrics exporter default port and host
+func (m *Metrics) Default(port uint, host string) {
+	if m.Port == 0 {
+		m.Port = port
+	}
+
+	if m.Host == "" {
+		m.Host = host
+	}
+}
+
+// ValidateServedByAPI validates metrics of clients serving metrics from their API server
+// such clients have no dedicated metrics port or host
+func (m *Metrics) ValidateServedByAPI() (errors field.ErrorList) {
+	path := field.NewPath("spec").Child("metrics")
+	msg := "metrics are served by the API server"
+
+	if m.Port != 0 {
+		errors = append(errors, field.Invalid(path.Child("port"), m.Port, msg))
+	}
+
+	if m.Host != "" {
+		errors = append(errors, field.Invalid(path.Child("host"), m.Host, msg))
+	}
+
+	return
+}
@@ -0,0 +1,60 @@
+package shared
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+// +k8s:deepcopy-gen=true
+type NetworkPolicy struct {
+	// From is pods allowed to access node API ports like JSON-RPC, WebSocket, GraphQL and REST
+	// kotal nodes consuming node API are allowed automatically, ingress controller pods must be allowed explicitly
+	// +listType=atomic
+	From []NetworkPolicyPeer `json:"from,omitempty"`
+}
+
+// NetworkPolicyPeer selects pods allowed to access node API ports
+// +k8s:deepcopy-gen=true
+type NetworkPolicyPeer struct {
+	// PodSelector selects pods allowed to access node API ports, all pods in selected namespaces are allowed if not set
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+	// NamespaceSelector selects namespaces of pods allowed to access node API ports, node namespace is used if not set
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// ValidateNetworkPolicy validates node network policy allowed peers
+func ValidateNetworkPolicy(policy *NetworkPolicy) (errors field.ErrorList) {
+	if policy == nil {
+		return
+	}
+
+	path := field.NewPath("spec").Child("networkPolicy").Child("from")
+
+	for i, peer := range policy.From {
+		if peer.PodSelector == nil && peer.NamespaceSelector == nil {
+			errors = append(errors, field.Required(path.Index(i), "must set podSelector or namespaceSelector"))
+			continue
+		}
+
+		errors = append(errors, validateLabelSelector(path.Index(i).Child("podSelector"), peer.PodSelector)...)
+		errors = append(errors, validateLabelSelector(path.Index(i).Child("namespaceSelector"), peer.NamespaceSelector)...)
+	}
+
+	return
+}
+
+// validateLabelSelector validates label selector if provided
+func validateLabelSelector(path *field.Path, selector *metav1.LabelSelector) (errors field.ErrorList) {
+	if selector == nil {
+		return
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+		errors = append(errors, field.Invalid(path, selector, fmt.Sprintf("invalid label selector: %s", err)))
+	}
+
+	return
+}
@@ -0,0 +1,53 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Network policy validation", func() {
+
+	It("Should accept peers selecting pods or namespaces", func() {
+		policy := &NetworkPolicy{
+			From: []NetworkPolicyPeer{
+				{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "dapp"}}},
+				{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"name": "ingress-nginx"}}},
+			},
+		}
+		Expect(ValidateNetworkPolicy(policy)).To(BeEmpty())
+	})
+
+	It("Should reject peers without selectors", func() {
+		policy := &NetworkPolicy{
+			From: []NetworkPolicyPeer{{}},
+		}
+		Expect(ValidateNetworkPolicy(policy)).To(ConsistOf(
+			&field.Error{
+				Type:     field.ErrorTypeRequired,
+				Field:    "spec.networkPolicy.from[0]",
+				BadValue: "",
+				Detail:   "must set podSelector or namespaceSelector",
+			},
+		))
+	})
+
+	It("Should reject invalid label selectors", func() {
+		policy := &NetworkPolicy{
+			From: []NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "app", Operator: "Matches"},
+						},
+					},
+				},
+			},
+		}
+		errors := ValidateNetworkPolicy(policy)
+		Expect(errors).To(HaveLen(1))
+		Expect(errors[0].Field).To(Equal("spec.networkPolicy.from[0].podSelector"))
+	})
+
+})
@@ -0,0 +1,69 @@
+package shared
+
+const (
+	// DefaultReadinessPeriodSeconds is the default readiness probe period
+	DefaultReadinessPeriodSeconds = 10
+	// DefaultReadinessFailureThreshold is the default readiness probe failure threshold
+	DefaultReadinessFailureThreshold = 3
+	// DefaultLivenessPeriodSeconds is the default liveness probe period
+	DefaultLivenessPeriodSeconds = 30
+	// DefaultLivenessFailureThreshold is the default liveness probe failure threshold
+	DefaultLivenessFailureThreshold = 5
+	// DefaultStartupPeriodSeconds is the default startup probe period
+	DefaultStartupPeriodSeconds = 10
+	// DefaultStartupFailureThreshold is the default startup probe failure threshold
+	// node has 10 minutes to start before it's restarted
+	DefaultStartupFailureThreshold = 60
+	// DefaultProbeTimeoutSeconds is the default probe timeout
+	DefaultProbeTimeoutSeconds = 5
+)
+
+// Probe is container health check thresholds
+// +k8s:deepcopy-gen=true
+type Probe struct {
+	// InitialDelaySeconds is number of seconds after the container has started before the probe is initiated
+	// +kubebuilder:validation:Minimum=0
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+	// PeriodSeconds is how often in seconds to perform the probe
+	// +kubebuilder:validation:Minimum=1
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+	// TimeoutSeconds is number of seconds after which the probe times out
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// FailureThreshold is minimum consecutive failures for the probe to be considered failed
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// Probes is node container health checks thresholds
+// +k8s:deepcopy-gen=true
+type Probes struct {
+	// Readiness is the probe used to decide if node is ready to serve requests
+	Readiness Probe `json:"readiness,omitempty"`
+	// Liveness is the probe used to decide if node should be restarted
+	Liveness Probe `json:"liveness,omitempty"`
+	// Startup is the probe used to give node time to start before liveness probe kicks in
+	Startup Probe `json:"startup,omitempty"`
+}
+
+// defaultProbe sets probe default thresholds
+func defaultProbe(probe *Probe, period, failureThreshold int32) {
+	if probe.PeriodSeconds == 0 {
+		probe.PeriodSeconds = period
+	}
+
+	if probe.TimeoutSeconds == 0 {
+		probe.TimeoutSeconds = DefaultProbeTimeoutSeconds
+	}
+
+	if probe.FailureThreshold == 0 {
+		probe.FailureThreshold = failureThreshold
+	}
+}
+
+// Default sets probes default thresholds
+func (p *Probes) Default() {
+	defaultProbe(&p.Readiness, DefaultReadinessPeriodSeconds, DefaultReadinessFailureThreshold)
+	defaultProbe(&p.Liveness, DefaultLivenessPeriodSeconds, DefaultLivenessFailureThreshold)
+	defaultProbe(&p.Startup, DefaultStartupPeriodSeconds, DefaultStartupFailureThreshold)
+}
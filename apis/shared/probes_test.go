@@ -0,0 +1,46 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Probes defaulting", func() {
+	It("Should default probes thresholds", func() {
+		probes := Probes{}
+		probes.Default()
+
+		Expect(probes.Readiness).To(Equal(Probe{
+			PeriodSeconds:    DefaultReadinessPeriodSeconds,
+			TimeoutSeconds:   DefaultProbeTimeoutSeconds,
+			FailureThreshold: DefaultReadinessFailureThreshold,
+		}))
+		Expect(probes.Liveness).To(Equal(Probe{
+			PeriodSeconds:    DefaultLivenessPeriodSeconds,
+			TimeoutSeconds:   DefaultProbeTimeoutSeconds,
+			FailureThreshold: DefaultLivenessFailureThreshold,
+		}))
+		Expect(probes.Startup).To(Equal(Probe{
+			PeriodSeconds:    DefaultStartupPeriodSeconds,
+			TimeoutSeconds:   DefaultProbeTimeoutSeconds,
+			FailureThreshold: DefaultStartupFailureThreshold,
+		}))
+	})
+
+	It("Should not override user provided thresholds", func() {
+		probes := Probes{
+			Startup: Probe{
+				InitialDelaySeconds: 30,
+				FailureThreshold:    360,
+			},
+		}
+		probes.Default()
+
+		Expect(probes.Startup).To(Equal(Probe{
+			InitialDelaySeconds: 30,
+			PeriodSeconds:       DefaultStartupPeriodSeconds,
+			TimeoutSeconds:      DefaultProbeTimeoutSeconds,
+			FailureThreshold:    360,
+		}))
+	})
+})
@@ -0,0 +1,35 @@
+package shared
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateReplicas validates nodes configured with keys or accounts that must be unique aren't replicated
+// unique is names of configured spec fields holding keys or accounts that can't be shared between replicas
+func ValidateReplicas(replicas uint, unique ...string) (errors field.ErrorList) {
+	if replicas <= 1 {
+		return
+	}
+
+	for _, name := range unique {
+		msg := fmt.Sprintf("must be 1 if %s is set, it can't be shared between replicas", name)
+		errors = append(errors, field.Invalid(field.NewPath("spec").Child("replicas"), replicas, msg))
+	}
+
+	return
+}
+
+// ValidateReplicasUpdate validates node isn't switched between a single replica and replicas
+// single replica data volume isn't mounted by replicas, they would sync from scratch and so would the single replica
+func ValidateReplicasUpdate(replicas, oldReplicas uint) (errors field.ErrorList) {
+	if (replicas > 1) == (oldReplicas > 1) {
+		return
+	}
+
+	msg := fmt.Sprintf("can't be changed from %d to %d, node data volumes differ between a single replica and replicas", oldReplicas, replicas)
+	errors = append(errors, field.Invalid(field.NewPath("spec").Child("replicas"), replicas, msg))
+
+	return
+}
@@ -0,0 +1,53 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Replicas validation", func() {
+
+	It("Should accept single replica nodes with unique keys", func() {
+		Expect(ValidateReplicas(1, "nodePrivateKeySecretName")).To(BeEmpty())
+	})
+
+	It("Should accept replicated nodes without unique keys", func() {
+		Expect(ValidateReplicas(3)).To(BeEmpty())
+	})
+
+	It("Should reject replicated nodes with unique keys", func() {
+		Expect(ValidateReplicas(3, "nodePrivateKeySecretName", "validator")).To(ConsistOf(
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.replicas",
+				BadValue: uint(3),
+				Detail:   "must be 1 if nodePrivateKeySecretName is set, it can't be shared between replicas",
+			},
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.replicas",
+				BadValue: uint(3),
+				Detail:   "must be 1 if validator is set, it can't be shared between replicas",
+			},
+		))
+	})
+
+	It("Should accept scaling replicated nodes", func() {
+		Expect(ValidateReplicasUpdate(3, 2)).To(BeEmpty())
+		Expect(ValidateReplicasUpdate(1, 0)).To(BeEmpty())
+	})
+
+	It("Should reject switching between a single replica and replicas", func() {
+		Expect(ValidateReplicasUpdate(2, 1)).To(ConsistOf(
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.replicas",
+				BadValue: uint(2),
+				Detail:   "can't be changed from 1 to 2, node data volumes differ between a single replica and replicas",
+			},
+		))
+		Expect(ValidateReplicasUpdate(1, 3)).To(HaveLen(1))
+	})
+
+})
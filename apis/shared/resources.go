@@ -7,6 +7,51 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+// RetentionPolicy is node storage retention policy on node deletion
+// +kubebuilder:validation:Enum=Retain;Delete;Snapshot
+type RetentionPolicy string
+
+const (
+	// RetainPolicy keeps node persistent volume claim after node deletion
+	RetainPolicy RetentionPolicy = "Retain"
+	// DeletePolicy deletes node persistent volume claim with the node
+	DeletePolicy RetentionPolicy = "Delete"
+	// SnapshotPolicy takes a volume snapshot of node persistent volume claim before deleting it
+	SnapshotPolicy RetentionPolicy = "Snapshot"
+)
+
+// DefaultRetentionPolicy is the default node storage retention policy
+const DefaultRetentionPolicy = DeletePolicy
+
+// DefaultAutoExpandThreshold is the default volume usage percentage that triggers storage expansion
+const DefaultAutoExpandThreshold uint = 80
+
+// AutoExpand is automatic node storage expansion driven by data volume usage
+// +k8s:deepcopy-gen=true
+type AutoExpand struct {
+	// Threshold is data volume usage percentage that triggers storage expansion
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	Threshold uint `json:"threshold,omitempty"`
+	// Increment is disk space added to storage on each expansion
+	// +kubebuilder:validation:Pattern="^[1-9][0-9]*[KMGTPE]i$"
+	Increment string `json:"increment"`
+	// Maximum is disk space storage can't be expanded beyond
+	// +kubebuilder:validation:Pattern="^[1-9][0-9]*[KMGTPE]i$"
+	Maximum string `json:"maximum"`
+}
+
+// Default sets auto expand default threshold if auto expand is provided
+func (a *AutoExpand) Default() {
+	if a == nil {
+		return
+	}
+
+	if a.Threshold == 0 {
+		a.Threshold = DefaultAutoExpandThreshold
+	}
+}
+
 // Resources is node compute and storage resources
 // +k8s:deepcopy-gen=true
 type Resources struct {
@@ -27,6 +72,10 @@ type Resources struct {
 	Storage string `json:"storage,omitempty"`
 	// StorageClass is the volume storage class
 	StorageClass *string `json:"storageClass,omitempty"`
+	// RetentionPolicy is node storage retention policy on node deletion
+	RetentionPolicy RetentionPolicy `json:"retentionPolicy,omitempty"`
+	// AutoExpand expands storage automatically when data volume usage exceeds threshold
+	AutoExpand *AutoExpand `json:"autoExpand,omitempty"`
 }
 
 // validate is the shared validation logic
@@ -57,6 +106,17 @@ func (r *Resources) validate() (errors field.ErrorList) {
 		errors = append(errors, err)
 	}
 
+	// validate auto expand maximum can't be less than storage
+	if r.AutoExpand != nil && r.Storage != "" {
+		storageQuantity := resource.MustParse(r.Storage)
+		maximumQuantity := resource.MustParse(r.AutoExpand.Maximum)
+		if maximumQuantity.Cmp(storageQuantity) == -1 {
+			msg := fmt.Sprintf("must be greater than or equal to storage %s", r.Storage)
+			err := field.Invalid(field.NewPath("spec").Child("resources").Child("autoExpand").Child("maximum"), r.AutoExpand.Maximum, msg)
+			errors = append(errors, err)
+		}
+	}
+
 	return
 }
 
@@ -48,6 +48,28 @@ var _ = Describe("Resource validation", func() {
 				},
 			},
 		},
+		{
+			Title: "invalid auto expand maximum value",
+			Resources: &Resources{
+				CPU:         "1",
+				CPULimit:    "2",
+				Memory:      "1Gi",
+				MemoryLimit: "2Gi",
+				Storage:     "500Gi",
+				AutoExpand: &AutoExpand{
+					Increment: "100Gi",
+					Maximum:   "200Gi",
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.resources.autoExpand.maximum",
+					BadValue: "200Gi",
+					Detail:   "must be greater than or equal to storage 500Gi",
+				},
+			},
+		},
 	}
 
 	storageClass := "standard"
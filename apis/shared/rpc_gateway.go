@@ -0,0 +1,110 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// RPCGatewayAuth is RPC gateway client authentication method
+// +kubebuilder:validation:Enum=APIKey;JWT
+type RPCGatewayAuth string
+
+const (
+	// APIKeyAuth authenticates clients by API key sent in X-API-Key header
+	APIKeyAuth RPCGatewayAuth = "APIKey"
+	// JWTAuth authenticates clients by HS256 JWT sent as Authorization bearer token
+	JWTAuth RPCGatewayAuth = "JWT"
+)
+
+const (
+	// DefaultRPCGatewayPort is the default RPC gateway port
+	DefaultRPCGatewayPort uint = 8080
+	// DefaultRPCGatewayBurst is the default burst of requests allowed per client
+	DefaultRPCGatewayBurst uint = 10
+	// DefaultRPCGatewayImage is the default RPC gateway container image
+	// it's published by make release-rpc-gateway, or by pushing rpc-gateway/<version> git tag
+	DefaultRPCGatewayImage = "kotalco/rpc-gateway:v0.1.0"
+)
+
+// RPCGateway is authenticating and rate-limiting JSON-RPC gateway sidecar in front of node RPC port
+// +k8s:deepcopy-gen=true
+type RPCGateway struct {
+	// Port is gateway listening port, node service RPC and websocket ports are routed to it
+	Port uint `json:"port,omitempty"`
+	// Auth is client authentication method
+	Auth RPCGatewayAuth `json:"auth"`
+	// SecretName is k8s secret holding newline separated API keys in api-keys key
+	// or JWT HMAC secret in jwt-secret key
+	SecretName string `json:"secretName"`
+	// RateLimit is JSON-RPC calls per second allowed per API key or JWT subject, every call in a batch counts, zero disables rate limiting
+	RateLimit uint `json:"rateLimit,omitempty"`
+	// Burst is maximum JSON-RPC calls burst allowed per API key or JWT subject
+	Burst uint `json:"burst,omitempty"`
+	// AllowedMethods is JSON-RPC methods allowed, all methods are allowed if empty
+	// method can end with * wildcard like eth_*
+	// +listType=set
+	AllowedMethods []string `json:"allowedMethods,omitempty"`
+	// DeniedMethods is JSON-RPC methods denied, takes precedence over allowed methods
+	// method can end with * wildcard like admin_*
+	// +listType=set
+	DeniedMethods []string `json:"deniedMethods,omitempty"`
+	// Image is RPC gateway container image
+	Image string `json:"image,omitempty"`
+}
+
+// Default sets RPC gateway default port, burst and image if gateway is provided
+func (g *RPCGateway) Default() {
+	if g == nil {
+		return
+	}
+
+	if g.Port == 0 {
+		g.Port = DefaultRPCGatewayPort
+	}
+
+	if g.RateLimit != 0 && g.Burst == 0 {
+		g.Burst = DefaultRPCGatewayBurst
+	}
+
+	if g.Image == "" {
+		g.Image = DefaultRPCGatewayImage
+	}
+}
+
+// ValidateRPCGateway validates RPC gateway if provided
+// rpc is whether node RPC server is enabled, reserved is node ports that can't be used by the gateway
+func ValidateRPCGateway(gateway *RPCGateway, rpc bool, reserved ...uint) (errors field.ErrorList) {
+	if gateway == nil {
+		return
+	}
+
+	path := field.NewPath("spec").Child("rpcGateway")
+
+	if !rpc {
+		err := field.Invalid(field.NewPath("spec").Child("rpc"), rpc, "must be enabled to use rpc gateway")
+		errors = append(errors, err)
+	}
+
+	for _, port := range reserved {
+		if gateway.Port == port {
+			err := field.Invalid(path.Child("port"), gateway.Port, fmt.Sprintf("port %d is already used by the node", port))
+			errors = append(errors, err)
+		}
+	}
+
+	validateMethods := func(name string, methods []string) {
+		for i, method := range methods {
+			if method == "" || strings.Contains(strings.TrimSuffix(method, "*"), "*") {
+				msg := "must be a method name optionally ending with * wildcard"
+				errors = append(errors, field.Invalid(path.Child(name).Index(i), method, msg))
+			}
+		}
+	}
+
+	validateMethods("allowedMethods", gateway.AllowedMethods)
+	validateMethods("deniedMethods", gateway.DeniedMethods)
+
+	return
+}
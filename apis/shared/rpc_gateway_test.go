@@ -0,0 +1,93 @@
+package shared
+
+import (
+	"os"
+	"regexp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("RPC gateway", func() {
+	It("Should default port, burst and image", func() {
+		gateway := &RPCGateway{
+			Auth:       APIKeyAuth,
+			SecretName: "rpc-keys",
+			RateLimit:  5,
+		}
+		gateway.Default()
+		Expect(gateway.Port).To(Equal(DefaultRPCGatewayPort))
+		Expect(gateway.Burst).To(Equal(DefaultRPCGatewayBurst))
+		Expect(gateway.Image).To(Equal(DefaultRPCGatewayImage))
+	})
+
+	// default image must be the one published by make release-rpc-gateway
+	It("Should default to the released image", func() {
+		makefile, err := os.ReadFile("../../Makefile")
+		Expect(err).NotTo(HaveOccurred())
+		img := regexp.MustCompile(`(?m)^RPC_GATEWAY_IMG \?= (\S+)$`).FindSubmatch(makefile)
+		Expect(img).NotTo(BeNil())
+		Expect(string(img[1])).To(Equal(DefaultRPCGatewayImage))
+	})
+
+	It("Should accept missing gateway", func() {
+		var gateway *RPCGateway
+		gateway.Default()
+		Expect(ValidateRPCGateway(gateway, true, 8545)).To(BeEmpty())
+	})
+
+	It("Should accept method wildcards", func() {
+		gateway := &RPCGateway{
+			Port:           8080,
+			Auth:           JWTAuth,
+			SecretName:     "rpc-jwt",
+			AllowedMethods: []string{"eth_*", "net_version"},
+			DeniedMethods:  []string{"admin_*", "debug_*"},
+		}
+		Expect(ValidateRPCGateway(gateway, true, 8545)).To(BeEmpty())
+	})
+
+	It("Should reject port used by the node", func() {
+		gateway := &RPCGateway{
+			Port:       8545,
+			Auth:       APIKeyAuth,
+			SecretName: "rpc-keys",
+		}
+		Expect(ValidateRPCGateway(gateway, true, 8545)).To(ContainElement(&field.Error{
+			Type:     field.ErrorTypeInvalid,
+			Field:    "spec.rpcGateway.port",
+			BadValue: uint(8545),
+			Detail:   "port 8545 is already used by the node",
+		}))
+	})
+
+	It("Should reject wildcard in the middle of method", func() {
+		gateway := &RPCGateway{
+			Port:          8080,
+			Auth:          APIKeyAuth,
+			SecretName:    "rpc-keys",
+			DeniedMethods: []string{"admin_*Peer"},
+		}
+		Expect(ValidateRPCGateway(gateway, true)).To(ContainElement(&field.Error{
+			Type:     field.ErrorTypeInvalid,
+			Field:    "spec.rpcGateway.deniedMethods[0]",
+			BadValue: "admin_*Peer",
+			Detail:   "must be a method name optionally ending with * wildcard",
+		}))
+	})
+
+	It("Should reject gateway if node rpc is disabled", func() {
+		gateway := &RPCGateway{
+			Port:       8080,
+			Auth:       APIKeyAuth,
+			SecretName: "rpc-keys",
+		}
+		Expect(ValidateRPCGateway(gateway, false, 8545)).To(ContainElement(&field.Error{
+			Type:     field.ErrorTypeInvalid,
+			Field:    "spec.rpc",
+			BadValue: false,
+			Detail:   "must be enabled to use rpc gateway",
+		}))
+	})
+})
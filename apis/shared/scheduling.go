@@ -0,0 +1,38 @@
+package shared
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Scheduling is node pod scheduling constraints and metadata overrides
+// +k8s:deepcopy-gen=true
+type Scheduling struct {
+	// NodeSelector is k8s node labels the node pod must be scheduled on
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations is node pod tolerations of k8s node taints
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Affinity is node pod affinity and anti-affinity scheduling constraints
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// TopologySpreadConstraints is how node pods are spread across topology domains
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// PriorityClassName is node pod priority class name
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// PodLabels is extra labels added to node pod
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+	// PodAnnotations is extra annotations added to node pod
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+}
+
+// ValidateScheduling validates node pod scheduling constraints and metadata overrides
+func ValidateScheduling(scheduling *Scheduling) (errors field.ErrorList) {
+	path := field.NewPath("spec")
+
+	errors = append(errors, metav1validation.ValidateLabels(scheduling.NodeSelector, path.Child("nodeSelector"))...)
+	errors = append(errors, metav1validation.ValidateLabels(scheduling.PodLabels, path.Child("podLabels"))...)
+	errors = append(errors, apivalidation.ValidateAnnotations(scheduling.PodAnnotations, path.Child("podAnnotations"))...)
+
+	return
+}
@@ -0,0 +1,60 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Scheduling validation", func() {
+	It("Should accept valid scheduling", func() {
+		scheduling := &Scheduling{
+			NodeSelector: map[string]string{
+				"kubernetes.io/arch": "amd64",
+			},
+			PodLabels: map[string]string{
+				"team": "infra",
+			},
+			PodAnnotations: map[string]string{
+				"cluster-autoscaler.kubernetes.io/safe-to-evict": "false",
+			},
+		}
+		Expect(ValidateScheduling(scheduling)).To(BeEmpty())
+	})
+
+	It("Should reject invalid node selector", func() {
+		scheduling := &Scheduling{
+			NodeSelector: map[string]string{
+				"kubernetes.io/arch": "not valid",
+			},
+		}
+		errors := ValidateScheduling(scheduling)
+		Expect(errors).NotTo(BeEmpty())
+		Expect(errors[0].Field).To(Equal("spec.nodeSelector"))
+		Expect(errors[0].Type).To(Equal(field.ErrorTypeInvalid))
+	})
+
+	It("Should reject invalid pod labels", func() {
+		scheduling := &Scheduling{
+			PodLabels: map[string]string{
+				"not valid": "infra",
+			},
+		}
+		errors := ValidateScheduling(scheduling)
+		Expect(errors).NotTo(BeEmpty())
+		Expect(errors[0].Field).To(Equal("spec.podLabels"))
+		Expect(errors[0].Type).To(Equal(field.ErrorTypeInvalid))
+	})
+
+	It("Should reject invalid pod annotations", func() {
+		scheduling := &Scheduling{
+			PodAnnotations: map[string]string{
+				"not valid": "false",
+			},
+		}
+		errors := ValidateScheduling(scheduling)
+		Expect(errors).NotTo(BeEmpty())
+		Expect(errors[0].Field).To(Equal("spec.podAnnotations"))
+		Expect(errors[0].Type).To(Equal(field.ErrorTypeInvalid))
+	})
+})
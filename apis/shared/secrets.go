@@ -0,0 +1,21 @@
+package shared
+
+import "sort"
+
+// SecretNames returns sorted unique secret names, empty names of optional secrets are dropped
+func SecretNames(names ...string) []string {
+	unique := map[string]bool{}
+	for _, name := range names {
+		if name != "" {
+			unique[name] = true
+		}
+	}
+
+	secrets := make([]string, 0, len(unique))
+	for name := range unique {
+		secrets = append(secrets, name)
+	}
+	sort.Strings(secrets)
+
+	return secrets
+}
@@ -0,0 +1,50 @@
+package shared
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionType is the type of status condition
+type ConditionType string
+
+const (
+	// ReadyCondition is true when all node pods are running and ready
+	ReadyCondition ConditionType = "Ready"
+	// ProgressingCondition is true while node statefulset is rolling out a new revision
+	ProgressingCondition ConditionType = "Progressing"
+	// DegradedCondition is true when node containers are crash-looping or failing
+	DegradedCondition ConditionType = "Degraded"
+	// SyncingCondition is true when node containers are running but not ready yet
+	SyncingCondition ConditionType = "Syncing"
+)
+
+// Phase is the lifecycle phase of the node
+// +kubebuilder:validation:Enum=Pending;Syncing;Ready;Degraded
+type Phase string
+
+const (
+	// PendingPhase is when node pods are not scheduled or not running yet
+	PendingPhase Phase = "Pending"
+	// SyncingPhase is when node pods are running but not ready yet
+	SyncingPhase Phase = "Syncing"
+	// ReadyPhase is when all node pods are running and ready
+	ReadyPhase Phase = "Ready"
+	// DegradedPhase is when node containers are crash-looping or failing
+	DegradedPhase Phase = "Degraded"
+)
+
+// Status is the shared observed state of all nodes
+// +k8s:deepcopy-gen=true
+type Status struct {
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Phase is the node lifecycle phase
+	Phase Phase `json:"phase,omitempty"`
+	// Conditions are the latest observations of node state
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ReadyReplicas is names of ready node pods
+	// +listType=set
+	ReadyReplicas []string `json:"readyReplicas,omitempty"`
+}
@@ -0,0 +1,86 @@
+package shared
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// UpdateStrategyType is node pods update strategy type
+// +kubebuilder:validation:Enum=RollingUpdate;Canary
+type UpdateStrategyType string
+
+const (
+	// RollingUpdateStrategy updates node pods one by one
+	RollingUpdateStrategy UpdateStrategyType = "RollingUpdate"
+	// CanaryUpdateStrategy updates a single node pod to the new client image first
+	// remaining pods are updated after the canary is ready and node isn't syncing for soak period, image is rolled back if the canary fails
+	CanaryUpdateStrategy UpdateStrategyType = "Canary"
+)
+
+// DefaultSoakPeriod is the default time canary pod must be ready before remaining pods are updated
+const DefaultSoakPeriod = "10m"
+
+// UpdateStrategy is node pods update strategy
+// +k8s:deepcopy-gen=true
+type UpdateStrategy struct {
+	// Type is node pods update strategy type
+	Type UpdateStrategyType `json:"type,omitempty"`
+	// SoakPeriod is how long canary pod must be ready without restarts while node isn't syncing before remaining pods are updated
+	SoakPeriod string `json:"soakPeriod,omitempty"`
+}
+
+// Default sets update strategy defaults if update strategy is provided
+func (u *UpdateStrategy) Default() {
+	if u == nil {
+		return
+	}
+
+	if u.Type == "" {
+		u.Type = RollingUpdateStrategy
+	}
+
+	if u.SoakPeriod == "" {
+		u.SoakPeriod = DefaultSoakPeriod
+	}
+}
+
+// IsCanary returns true if update strategy is canary
+func (u *UpdateStrategy) IsCanary() bool {
+	return u != nil && u.Type == CanaryUpdateStrategy
+}
+
+// SoakDuration returns soak period duration, default soak period is used if it's not set or invalid
+func (u *UpdateStrategy) SoakDuration() time.Duration {
+	if u != nil {
+		if duration, err := time.ParseDuration(u.SoakPeriod); err == nil {
+			return duration
+		}
+	}
+
+	duration, _ := time.ParseDuration(DefaultSoakPeriod)
+
+	return duration
+}
+
+// ValidateUpdateStrategy validates node pods update strategy
+// canary updates require more than one replica so the rest of the replicas keep serving the stable client
+func ValidateUpdateStrategy(strategy *UpdateStrategy, replicas uint) (errors field.ErrorList) {
+	if strategy == nil {
+		return
+	}
+
+	path := field.NewPath("spec").Child("updateStrategy")
+
+	if strategy.IsCanary() && replicas <= 1 {
+		errors = append(errors, field.Invalid(path.Child("type"), strategy.Type, "must be RollingUpdate if replicas is 1"))
+	}
+
+	if strategy.SoakPeriod != "" {
+		if duration, err := time.ParseDuration(strategy.SoakPeriod); err != nil || duration <= 0 {
+			errors = append(errors, field.Invalid(path.Child("soakPeriod"), strategy.SoakPeriod, "must be a positive duration such as 10m"))
+		}
+	}
+
+	return
+}
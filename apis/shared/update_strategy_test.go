@@ -0,0 +1,45 @@
+package shared
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Update strategy", func() {
+
+	It("Should default update strategy", func() {
+		strategy := &UpdateStrategy{}
+		strategy.Default()
+		Expect(strategy.Type).To(Equal(RollingUpdateStrategy))
+		Expect(strategy.SoakPeriod).To(Equal(DefaultSoakPeriod))
+		Expect(strategy.SoakDuration()).To(Equal(10 * time.Minute))
+	})
+
+	It("Should accept canary updates of replicated nodes", func() {
+		strategy := &UpdateStrategy{Type: CanaryUpdateStrategy, SoakPeriod: "1h"}
+		Expect(ValidateUpdateStrategy(strategy, 3)).To(BeEmpty())
+		Expect(strategy.SoakDuration()).To(Equal(time.Hour))
+	})
+
+	It("Should reject canary updates of single replica nodes and invalid soak period", func() {
+		strategy := &UpdateStrategy{Type: CanaryUpdateStrategy, SoakPeriod: "1 hour"}
+		Expect(ValidateUpdateStrategy(strategy, 1)).To(ConsistOf(
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.updateStrategy.type",
+				BadValue: CanaryUpdateStrategy,
+				Detail:   "must be RollingUpdate if replicas is 1",
+			},
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.updateStrategy.soakPeriod",
+				BadValue: "1 hour",
+				Detail:   "must be a positive duration such as 10m",
+			},
+		))
+	})
+
+})
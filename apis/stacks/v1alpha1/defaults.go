@@ -7,6 +7,8 @@ const (
 	DefaultRPCPort uint = 20443
 	// DefaultP2PPort is the default p2p bind port
 	DefaultP2PPort uint = 20444
+	// DefaultMetricsPort is the default prometheus metrics port
+	DefaultMetricsPort uint = 9153
 )
 
 // Resources
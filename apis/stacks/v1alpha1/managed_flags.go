@@ -0,0 +1,12 @@
+package v1alpha1
+
+// NodeManagedFlags is set by stacks node client package which owns the flags
+var NodeManagedFlags func(*Node) []string
+
+// ManagedFlags returns stacks node flags set by Kotal that can't be used in extra args
+func (r *Node) ManagedFlags() []string {
+	if NodeManagedFlags == nil {
+		return nil
+	}
+	return NodeManagedFlags(r)
+}
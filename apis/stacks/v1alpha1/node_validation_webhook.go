@@ -6,6 +6,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/apis/shared"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-stacks-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=stacks.kotal.io,resources=nodes,versions=v1alpha1,name=validate-stacks-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
@@ -19,6 +21,12 @@ func (r *Node) ValidateCreate() error {
 	nodelog.Info("validate create", "name", r.Name)
 
 	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, shared.ValidateImage(r.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&r.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&r.Spec.ExtraConfig, "stacks", r.ManagedFlags(), true)...)
+	allErrors = append(allErrors, shared.ValidateIngress(r.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(r.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateExpose(r.Spec.Expose, r.Spec.P2PPort)...)
 
 	if r.Spec.Miner && r.Spec.SeedPrivateKeySecretName == "" {
 		err := field.Invalid(field.NewPath("spec").Child("seedPrivateKeySecretName"), r.Spec.SeedPrivateKeySecretName, "seedPrivateKeySecretName is required if node is miner")
@@ -50,6 +58,12 @@ func (r *Node) ValidateUpdate(old runtime.Object) error {
 	nodelog.Info("validate update", "name", r.Name)
 
 	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, shared.ValidateImage(r.Spec.Image)...)
+	allErrors = append(allErrors, shared.ValidateScheduling(&r.Spec.Scheduling)...)
+	allErrors = append(allErrors, shared.ValidateExtraConfig(&r.Spec.ExtraConfig, "stacks", r.ManagedFlags(), true)...)
+	allErrors = append(allErrors, shared.ValidateIngress(r.Spec.Ingress)...)
+	allErrors = append(allErrors, shared.ValidateNetworkPolicy(r.Spec.NetworkPolicy)...)
+	allErrors = append(allErrors, shared.ValidateExpose(r.Spec.Expose, r.Spec.P2PPort)...)
 
 	if r.Spec.Network != oldNode.Spec.Network {
 		err := field.Invalid(field.NewPath("spec").Child("network"), r.Spec.Network, "field is immutable")
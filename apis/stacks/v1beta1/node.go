@@ -0,0 +1,110 @@
+package v1beta1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StacksNetwork is Stacks network
+type StacksNetwork string
+
+const (
+	Mainnet StacksNetwork = "mainnet"
+	Testnet StacksNetwork = "testnet"
+)
+
+// BitcoinNode is Bitcoin node
+type BitcoinNode struct {
+	// Endpoint is bitcoin node JSON-RPC endpoint
+	Endpoint string `json:"endpoint"`
+	// P2PPort is bitcoin node p2p port
+	P2PPort uint `json:"p2pPort"`
+	// RPCPort is bitcoin node JSON-RPC port
+	RPCPort uint `json:"rpcPort"`
+	// RPCUsername is bitcoin node JSON-RPC username
+	RPCUsername string `json:"rpcUsername"`
+	// RPCPasswordSecretName is k8s secret name holding bitcoin node JSON-RPC password
+	RPCPasswordSecretName string `json:"rpcPasswordSecretName"`
+}
+
+// NodeSpec defines the desired state of Node
+type NodeSpec struct {
+	// Network is stacks network
+	// +kubebuilder:validation:Enum=mainnet;testnet
+	Network StacksNetwork `json:"network"`
+	// RPCPort is JSON-RPC server port
+	RPCPort uint `json:"rpcPort,omitempty"`
+	// RPCHost is JSON-RPC server host
+	RPCHost string `json:"rpcHost,omitempty"`
+	// P2PPort is p2p bind port
+	P2PPort uint `json:"p2pPort,omitempty"`
+	// P2PHost is p2p bind host
+	P2PHost string `json:"p2pHost,omitempty"`
+	// BitcoinNode is Bitcoin node
+	BitcoinNode BitcoinNode `json:"bitcoinNode"`
+	// Miner enables mining
+	Miner bool `json:"miner,omitempty"`
+	// SeedPrivateKeySecretName is k8s secret holding seed private key used for mining
+	SeedPrivateKeySecretName string `json:"seedPrivateKeySecretName,omitempty"`
+	// MineMicroblocks mines Stacks micro blocks
+	MineMicroblocks bool `json:"mineMicroblocks,omitempty"`
+	// NodePrivateKeySecretName is k8s secret holding node private key
+	NodePrivateKeySecretName string `json:"nodePrivateKeySecretName,omitempty"`
+	// Metrics is node prometheus metrics exporter
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Image is node container image, overrides the default client image
+	Image string `json:"image,omitempty"`
+	// Expose is node service exposure outside the cluster
+	Expose *shared.Expose `json:"expose,omitempty"`
+	// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+	Ingress *shared.Ingress `json:"ingress,omitempty"`
+	// NetworkPolicy restricts access to node API ports, peer to peer and metrics ports stay open
+	NetworkPolicy *shared.NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Disruption is node pods voluntary disruption policy, pods of nodes producing or signing blocks are protected by default
+	// +kubebuilder:default=Auto
+	Disruption shared.DisruptionPolicy `json:"disruption,omitempty"`
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	shared.Scheduling `json:",inline"`
+	// ExtraConfig is extra client arguments, environment variables and config overrides
+	shared.ExtraConfig `json:",inline"`
+	// Resources is node compute and storage resources
+	shared.Resources `json:"resources,omitempty"`
+	// Probes is node container health checks thresholds
+	Probes shared.Probes `json:"probes,omitempty"`
+}
+
+// NodeStatus defines the observed state of Node
+type NodeStatus struct {
+	shared.Status `json:",inline"`
+	Client        string `json:"client,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// Node is the Schema for the nodes API
+// +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
+// +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
+// +kubebuilder:printcolumn:name="Miner",type=boolean,JSONPath=".spec.miner"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+type Node struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeSpec   `json:"spec,omitempty"`
+	Status NodeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeList contains a list of Node
+type NodeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Node `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Node{}, &NodeList{})
+}
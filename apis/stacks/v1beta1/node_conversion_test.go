@@ -0,0 +1,42 @@
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kotalco/kotal/apis/stacks/v1alpha1"
+)
+
+var _ = Describe("Stacks node conversion", func() {
+
+	It("should convert to the hub version and back", func() {
+		node := &Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-node",
+				Namespace: "default",
+			},
+			Spec: NodeSpec{
+				Network: Mainnet,
+				BitcoinNode: BitcoinNode{
+					Endpoint:              "bitcoin-node",
+					P2PPort:               8333,
+					RPCPort:               8332,
+					RPCUsername:           "kotal",
+					RPCPasswordSecretName: "bitcoin-password",
+				},
+			},
+		}
+
+		hub := &v1alpha1.Node{}
+		Expect(node.ConvertTo(hub)).To(Succeed())
+		Expect(hub.ObjectMeta).To(Equal(node.ObjectMeta))
+		Expect(hub.Spec.BitcoinNode.RpcPort).To(Equal(uint(8332)))
+		Expect(hub.Spec.BitcoinNode.RpcUsername).To(Equal("kotal"))
+
+		converted := &Node{}
+		Expect(converted.ConvertFrom(hub)).To(Succeed())
+		Expect(converted).To(Equal(node))
+	})
+
+})
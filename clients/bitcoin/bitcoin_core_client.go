@@ -10,6 +10,7 @@ import (
 	"os"
 
 	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -37,6 +38,10 @@ const (
 
 // Image returns Bitcoin core client image
 func (c *BitcoinCoreClient) Image() string {
+	if img := c.node.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvBitcoinCoreImage) == "" {
 		return DefaultBitcoinCoreImage
 	}
@@ -72,10 +77,14 @@ func (c *BitcoinCoreClient) Args() (args []string) {
 	args = append(args, fmt.Sprintf("%s=%s", BitcoinArgChain, networks[string(node.Spec.Network)]))
 	args = append(args, fmt.Sprintf("%s=%s:%d", BitcoinArgBind, node.Spec.P2PHost, node.Spec.P2PPort))
 
+	if externalIP := node.Spec.Expose.GetExternalIP(); externalIP != "" {
+		args = append(args, fmt.Sprintf("%s=%s:%d", BitcoinArgExternalIP, externalIP, node.Spec.Expose.AnnouncedPort(node.Spec.P2PPort)))
+	}
+
 	if c.node.Spec.RPC {
 		args = append(args, fmt.Sprintf("%s=1", BitcoinArgServer))
 		args = append(args, fmt.Sprintf("%s=%d", BitcoinArgRPCPort, node.Spec.RPCPort))
-		args = append(args, fmt.Sprintf("%s=%s", BitcoinArgRPCBind, node.Spec.RPCHost))
+		args = append(args, fmt.Sprintf("%s=%s", BitcoinArgRPCBind, clients.RPCHost(node.Spec.RPCGateway, node.Spec.RPCHost)))
 		args = append(args, fmt.Sprintf("%s=0.0.0.0/0", BitcoinArgRPCAllowIp))
 
 		for _, rpcUser := range node.Spec.RPCUsers {
@@ -97,7 +106,7 @@ func (c *BitcoinCoreClient) Args() (args []string) {
 	if node.Spec.TransactionIndex {
 		txIndex = 1
 	}
-	args = append(args, fmt.Sprintf("-txindex=%d", txIndex))
+	args = append(args, fmt.Sprintf("%s=%d", BitcoinArgTxIndex, txIndex))
 
 	if !node.Spec.Wallet {
 		args = append(args, BitcoinArgDisableWallet)
@@ -126,3 +135,17 @@ func HmacSha256(password string) (salt, hash string) {
 
 	return
 }
+
+// HealthCheck checks JSON-RPC port if enabled, otherwise p2p port
+// JSON-RPC server listens on localhost only if node has RPC gateway, p2p port is checked instead
+func (c *BitcoinCoreClient) HealthCheck() *corev1.ProbeHandler {
+	if c.node.Spec.RPC && c.node.Spec.RPCGateway == nil {
+		return clients.TCPHealthCheck(c.node.Spec.RPCPort)
+	}
+	return clients.TCPHealthCheck(c.node.Spec.P2PPort)
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (c *BitcoinCoreClient) MetricsPath() string {
+	return "/metrics"
+}
@@ -4,8 +4,11 @@ import (
 	"os"
 
 	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	"github.com/kotalco/kotal/clients"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,6 +28,10 @@ var _ = Describe("Bitcoin core client", func() {
 			RPCHost:          "127.0.0.1",
 			Wallet:           false,
 			TransactionIndex: true,
+			Expose: &sharedAPI.Expose{
+				ServiceType: corev1.ServiceTypeLoadBalancer,
+				ExternalIP:  "203.0.113.10",
+			},
 		},
 	}
 
@@ -54,12 +61,17 @@ var _ = Describe("Bitcoin core client", func() {
 		Expect(client.HomeDir()).To(Equal(BitcoinCoreHomeDir))
 	})
 
+	It("Should get correct health check", func() {
+		Expect(client.HealthCheck()).To(Equal(clients.TCPHealthCheck(7777)))
+	})
+
 	It("Should generate correct client arguments", func() {
 		Expect(client.Args()).To(ContainElements([]string{
 			"-chain=main",
 			"-datadir=/home/bitcoin/kotal-data",
 			"-server=1",
 			"-bind=127.0.0.1:8888",
+			"-externalip=203.0.113.10:8888",
 			"-rpcport=7777",
 			"-rpcbind=127.0.0.1",
 			"-rpcallowip=0.0.0.0/0",
@@ -0,0 +1,88 @@
+package bitcoin
+
+import (
+	"fmt"
+	"os"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Bitcoin core doesn't serve prometheus metrics, exporter sidecar is used instead
+// https://github.com/jvstein/bitcoin-prometheus-exporter
+const (
+	// EnvBitcoinExporterImage is the environment variable used for Bitcoin prometheus exporter image
+	EnvBitcoinExporterImage = "BITCOIN_EXPORTER_IMAGE"
+	// DefaultBitcoinExporterImage is the default Bitcoin prometheus exporter image
+	DefaultBitcoinExporterImage = "jvstein/bitcoin-prometheus-exporter:v0.7.0"
+)
+
+// Exporter environment variables
+const (
+	// EnvExporterRPCHost is environment variable used to set Bitcoin JSON-RPC host
+	EnvExporterRPCHost = "BITCOIN_RPC_HOST"
+	// EnvExporterRPCPort is environment variable used to set Bitcoin JSON-RPC port
+	EnvExporterRPCPort = "BITCOIN_RPC_PORT"
+	// EnvExporterRPCUser is environment variable used to set Bitcoin JSON-RPC user
+	EnvExporterRPCUser = "BITCOIN_RPC_USER"
+	// EnvExporterRPCPassword is environment variable used to set Bitcoin JSON-RPC password
+	EnvExporterRPCPassword = "BITCOIN_RPC_PASSWORD"
+	// EnvExporterMetricsAddr is environment variable used to set metrics server address
+	EnvExporterMetricsAddr = "METRICS_ADDR"
+	// EnvExporterMetricsPort is environment variable used to set metrics server port
+	EnvExporterMetricsPort = "METRICS_PORT"
+)
+
+// ExporterImage returns Bitcoin prometheus exporter image
+func ExporterImage() string {
+	if os.Getenv(EnvBitcoinExporterImage) == "" {
+		return DefaultBitcoinExporterImage
+	}
+	return os.Getenv(EnvBitcoinExporterImage)
+}
+
+// ExporterEnv returns Bitcoin prometheus exporter environment variables
+// exporter authenticates using the first JSON-RPC user
+func ExporterEnv(node *bitcoinv1alpha1.Node) (env []corev1.EnvVar) {
+	if len(node.Spec.RPCUsers) == 0 {
+		return
+	}
+
+	user := node.Spec.RPCUsers[0]
+
+	env = []corev1.EnvVar{
+		{
+			Name:  EnvExporterRPCHost,
+			Value: "127.0.0.1",
+		},
+		{
+			Name:  EnvExporterRPCPort,
+			Value: fmt.Sprintf("%d", node.Spec.RPCPort),
+		},
+		{
+			Name:  EnvExporterRPCUser,
+			Value: user.Username,
+		},
+		{
+			Name: EnvExporterRPCPassword,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: user.PasswordSecretName,
+					},
+					Key: "password",
+				},
+			},
+		},
+		{
+			Name:  EnvExporterMetricsAddr,
+			Value: node.Spec.Metrics.Host,
+		},
+		{
+			Name:  EnvExporterMetricsPort,
+			Value: fmt.Sprintf("%d", node.Spec.Metrics.Port),
+		},
+	}
+
+	return
+}
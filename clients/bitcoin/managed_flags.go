@@ -0,0 +1,24 @@
+package bitcoin
+
+import bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+
+func init() {
+	bitcoinv1alpha1.NodeManagedFlags = nodeManagedFlags
+}
+
+// nodeManagedFlags returns Bitcoin core flags set by Kotal that can't be used in extra args
+func nodeManagedFlags(node *bitcoinv1alpha1.Node) []string {
+	return []string{
+		BitcoinArgChain,
+		BitcoinArgBind,
+		BitcoinArgExternalIP,
+		BitcoinArgServer,
+		BitcoinArgRPCPort,
+		BitcoinArgDataDir,
+		BitcoinArgRPCBind,
+		BitcoinArgRPCAllowIp,
+		BitcoinArgRPCAuth,
+		BitcoinArgDisableWallet,
+		BitcoinArgTxIndex,
+	}
+}
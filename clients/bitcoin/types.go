@@ -10,6 +10,8 @@ const (
 	BitcoinArgChain = "-chain"
 	// BitcoinArgBind is argument used to bind and listen to the given address
 	BitcoinArgBind = "-bind"
+	// BitcoinArgExternalIP is argument used to set own public address advertised to peers
+	BitcoinArgExternalIP = "-externalip"
 	// BitcoinArgServer is argument used to enable CLI and JSON-RPC server
 	BitcoinArgServer = "-server"
 	// BitcoinArgRPCPort is argument used to set JSON-RPC port
@@ -24,4 +26,6 @@ const (
 	BitcoinArgRPCAuth = "-rpcauth"
 	// BitcoinArgDisableWallet is argument used to disable wallet and RPC calls
 	BitcoinArgDisableWallet = "-disablewallet"
+	// BitcoinArgTxIndex is argument used to maintain a full transaction index
+	BitcoinArgTxIndex = "-txindex"
 )
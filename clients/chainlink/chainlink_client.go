@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	chainlinkv1alpha1 "github.com/kotalco/kotal/apis/chainlink/v1alpha1"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -29,6 +30,10 @@ const (
 
 // Image returns chainlink image
 func (c *ChainlinkClient) Image() string {
+	if img := c.node.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvChainlinkImage) == "" {
 		return DefaultChainlinkImage
 	}
@@ -86,7 +91,6 @@ func (c *ChainlinkClient) Env() []corev1.EnvVar {
 			Name:  EnvSecureCookies,
 			Value: fmt.Sprintf("%t", c.node.Spec.SecureCookies),
 		},
-		// TODO: update with P2P_ANNOUNCE_PORT
 		{
 			Name:  EnvP2PListenPort,
 			Value: fmt.Sprintf("%d", node.Spec.P2PPort),
@@ -97,6 +101,19 @@ func (c *ChainlinkClient) Env() []corev1.EnvVar {
 		},
 	}
 
+	if externalIP := node.Spec.Expose.GetExternalIP(); externalIP != "" {
+		env = append(env,
+			corev1.EnvVar{
+				Name:  EnvP2PAnnounceIP,
+				Value: externalIP,
+			},
+			corev1.EnvVar{
+				Name:  EnvP2PAnnouncePort,
+				Value: fmt.Sprintf("%d", node.Spec.Expose.AnnouncedPort(node.Spec.P2PPort)),
+			},
+		)
+	}
+
 	if c.node.Spec.CertSecretName != "" {
 		env = append(env,
 			corev1.EnvVar{
@@ -139,3 +156,13 @@ func (c *ChainlinkClient) Env() []corev1.EnvVar {
 func (c *ChainlinkClient) HomeDir() string {
 	return ChainlinkHomeDir
 }
+
+// HealthCheck returns chainlink health endpoint
+func (c *ChainlinkClient) HealthCheck() *corev1.ProbeHandler {
+	return clients.HTTPHealthCheck("/health", c.node.Spec.APIPort)
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (c *ChainlinkClient) MetricsPath() string {
+	return "/metrics"
+}
@@ -6,6 +6,7 @@ import (
 
 	chainlinkv1alpha1 "github.com/kotalco/kotal/apis/chainlink/v1alpha1"
 	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -31,6 +32,10 @@ var _ = Describe("Chainlink Client", func() {
 			Logging:             sharedAPI.PanicLogs,
 			CORSDomains:         []string{"*"},
 			SecureCookies:       true,
+			Expose: &sharedAPI.Expose{
+				ServiceType: corev1.ServiceTypeLoadBalancer,
+				ExternalIP:  "203.0.113.10",
+			},
 		},
 	}
 
@@ -89,6 +94,14 @@ var _ = Describe("Chainlink Client", func() {
 				Name:  EnvP2PListenPort,
 				Value: "4444",
 			},
+			corev1.EnvVar{
+				Name:  EnvP2PAnnounceIP,
+				Value: "203.0.113.10",
+			},
+			corev1.EnvVar{
+				Name:  EnvP2PAnnouncePort,
+				Value: "4444",
+			},
 			corev1.EnvVar{
 				Name:  EnvPort,
 				Value: "7777",
@@ -120,6 +133,10 @@ var _ = Describe("Chainlink Client", func() {
 		Expect(client.HomeDir()).To(Equal(ChainlinkHomeDir))
 	})
 
+	It("Should get correct health check", func() {
+		Expect(client.HealthCheck()).To(Equal(clients.HTTPHealthCheck("/health", 7777)))
+	})
+
 	It("Should get correct args", func() {
 		Expect(client.Args()).To(ContainElements(
 			"local",
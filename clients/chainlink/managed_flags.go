@@ -0,0 +1,15 @@
+package chainlink
+
+import chainlinkv1alpha1 "github.com/kotalco/kotal/apis/chainlink/v1alpha1"
+
+func init() {
+	chainlinkv1alpha1.NodeManagedFlags = nodeManagedFlags
+}
+
+// nodeManagedFlags returns chainlink flags set by Kotal that can't be used in extra args
+func nodeManagedFlags(node *chainlinkv1alpha1.Node) []string {
+	return []string{
+		ChainlinkPassword,
+		ChainlinkAPI,
+	}
+}
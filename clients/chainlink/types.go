@@ -32,6 +32,10 @@ const (
 	EnvSecureCookies = "SECURE_COOKIES"
 	// EnvP2PListenPort is the environment variable for allowing cross origin requests from domains
 	EnvP2PListenPort = "P2P_LISTEN_PORT"
+	// EnvP2PAnnounceIP is the environment variable for p2p ip advertised to peers
+	EnvP2PAnnounceIP = "P2P_ANNOUNCE_IP"
+	// EnvP2PAnnouncePort is the environment variable for p2p port advertised to peers
+	EnvP2PAnnouncePort = "P2P_ANNOUNCE_PORT"
 )
 
 // arguments
@@ -8,7 +8,9 @@ import (
 
 	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
 	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // BesuClient is Hyperledger Besu client
@@ -46,7 +48,13 @@ func (b *BesuClient) Args() (args []string) {
 		args = append(args, arg...)
 	}
 
-	appendArg(BesuNatMethod, "KUBERNETES")
+	// announce static external ip instead of detecting it
+	if externalIP := node.Spec.Expose.GetExternalIP(); externalIP != "" {
+		appendArg(BesuNatMethod, "NONE")
+		appendArg(BesuP2PHost, externalIP)
+	} else {
+		appendArg(BesuNatMethod, "KUBERNETES")
+	}
 	appendArg(BesuDataPath, shared.PathData(b.HomeDir()))
 	appendArg(BesuP2PPort, fmt.Sprintf("%d", node.Spec.P2PPort))
 	appendArg(BesuSyncMode, string(node.Spec.SyncMode))
@@ -93,24 +101,30 @@ func (b *BesuClient) Args() (args []string) {
 
 	if node.Spec.RPC {
 		appendArg(BesuRPCHTTPEnabled)
-		appendArg(BesuRPCHTTPHost, DefaultHost)
+		appendArg(BesuRPCHTTPHost, clients.RPCHost(node.Spec.RPCGateway, DefaultHost))
 		appendArg(BesuRPCHTTPPort, fmt.Sprintf("%d", node.Spec.RPCPort))
 		appendArg(BesuRPCHTTPAPI, normalizedAPIs(node.Spec.RPCAPI))
 	}
 
 	if node.Spec.WS {
 		appendArg(BesuRPCWSEnabled)
-		appendArg(BesuRPCWSHost, DefaultHost)
+		appendArg(BesuRPCWSHost, clients.RPCHost(node.Spec.RPCGateway, DefaultHost))
 		appendArg(BesuRPCWSPort, fmt.Sprintf("%d", node.Spec.WSPort))
 		appendArg(BesuRPCWSAPI, normalizedAPIs(node.Spec.WSAPI))
 	}
 
 	if node.Spec.GraphQL {
 		appendArg(BesuGraphQLHTTPEnabled)
-		appendArg(BesuGraphQLHTTPHost, DefaultHost)
+		appendArg(BesuGraphQLHTTPHost, clients.RPCHost(node.Spec.RPCGateway, DefaultHost))
 		appendArg(BesuGraphQLHTTPPort, fmt.Sprintf("%d", node.Spec.GraphQLPort))
 	}
 
+	if node.Spec.Metrics.Enabled {
+		appendArg(BesuMetricsEnabled)
+		appendArg(BesuMetricsHost, node.Spec.Metrics.Host)
+		appendArg(BesuMetricsPort, fmt.Sprintf("%d", node.Spec.Metrics.Port))
+	}
+
 	if len(node.Spec.Hosts) != 0 {
 		commaSeperatedHosts := strings.Join(node.Spec.Hosts, ",")
 		appendArg(BesuHostAllowlist, commaSeperatedHosts)
@@ -265,8 +279,36 @@ func (b *BesuClient) EncodeStaticNodes() string {
 
 // Image returns besu docker image
 func (b *BesuClient) Image() string {
+	if img := b.node.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvBesuImage) == "" {
 		return DefaultBesuImage
 	}
 	return os.Getenv(EnvBesuImage)
 }
+
+// Command is Besu client entrypoint
+func (b *BesuClient) Command() []string {
+	return nil
+}
+
+// Env returns environment variables for the client
+func (b *BesuClient) Env() []corev1.EnvVar {
+	return nil
+}
+
+// HealthCheck returns Besu liveness endpoint if JSON-RPC is enabled, otherwise p2p port check
+// JSON-RPC server listens on localhost only if node has RPC gateway, p2p port is checked instead
+func (b *BesuClient) HealthCheck() *corev1.ProbeHandler {
+	if b.node.Spec.RPC && b.node.Spec.RPCGateway == nil {
+		return clients.HTTPHealthCheck("/liveness", b.node.Spec.RPCPort)
+	}
+	return clients.TCPHealthCheck(b.node.Spec.P2PPort)
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (b *BesuClient) MetricsPath() string {
+	return "/metrics"
+}
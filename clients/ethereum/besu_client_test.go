@@ -6,10 +6,12 @@ import (
 
 	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
 	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -136,6 +138,42 @@ var _ = Describe("Besu Client", func() {
 			))
 		})
 
+		It("should return liveness endpoint health check", func() {
+			client, _ := NewClient(node)
+			Expect(client.HealthCheck()).To(Equal(clients.HTTPHealthCheck("/liveness", 8888)))
+		})
+
+	})
+
+	Context("exposed by load balancer with static external ip", func() {
+		node := &ethereumv1alpha1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "besu-exposed-node",
+			},
+			Spec: ethereumv1alpha1.NodeSpec{
+				Network: ethereumv1alpha1.MainNetwork,
+				Client:  ethereumv1alpha1.BesuClient,
+				Expose: &sharedAPI.Expose{
+					ServiceType: corev1.ServiceTypeLoadBalancer,
+					ExternalIP:  "203.0.113.10",
+				},
+			},
+		}
+		node.Default()
+
+		It("should announce external ip to peers", func() {
+
+			client, err := NewClient(node)
+
+			Expect(err).To(BeNil())
+			Expect(client.Args()).To(ContainElements(
+				BesuNatMethod,
+				"NONE",
+				BesuP2PHost,
+				"203.0.113.10",
+			))
+		})
+
 	})
 
 	Context("miner in private PoW network", func() {
@@ -8,7 +8,9 @@ import (
 
 	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
 	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // GethClient is Go-Ethereum client
@@ -61,6 +63,10 @@ func (g *GethClient) Args() (args []string) {
 	appendArg(GethSyncMode, string(node.Spec.SyncMode))
 	appendArg(GethLogging, g.LoggingArgFromVerbosity(node.Spec.Logging))
 
+	if externalIP := node.Spec.Expose.GetExternalIP(); externalIP != "" {
+		appendArg(GethNat, fmt.Sprintf("extip:%s", externalIP))
+	}
+
 	// config.toml holding static nodes
 	if len(node.Spec.StaticNodes) != 0 {
 		appendArg(GethConfig, fmt.Sprintf("%s/config.toml", shared.PathConfig(g.HomeDir())))
@@ -94,7 +100,7 @@ func (g *GethClient) Args() (args []string) {
 
 	if node.Spec.RPC {
 		appendArg(GethRPCHTTPEnabled)
-		appendArg(GethRPCHTTPHost, DefaultHost)
+		appendArg(GethRPCHTTPHost, clients.RPCHost(node.Spec.RPCGateway, DefaultHost))
 		appendArg(GethRPCHTTPPort, fmt.Sprintf("%d", node.Spec.RPCPort))
 		// JSON-RPC API
 		apis := []string{}
@@ -107,7 +113,7 @@ func (g *GethClient) Args() (args []string) {
 
 	if node.Spec.WS {
 		appendArg(GethRPCWSEnabled)
-		appendArg(GethRPCWSHost, DefaultHost)
+		appendArg(GethRPCWSHost, clients.RPCHost(node.Spec.RPCGateway, DefaultHost))
 		appendArg(GethRPCWSPort, fmt.Sprintf("%d", node.Spec.WSPort))
 		// WebSocket API
 		apis := []string{}
@@ -124,6 +130,12 @@ func (g *GethClient) Args() (args []string) {
 		// .GraphQLPort will be used in the service that point to the pod
 	}
 
+	if node.Spec.Metrics.Enabled {
+		appendArg(GethMetrics)
+		appendArg(GethMetricsHost, node.Spec.Metrics.Host)
+		appendArg(GethMetricsPort, fmt.Sprintf("%d", node.Spec.Metrics.Port))
+	}
+
 	if len(node.Spec.Hosts) != 0 {
 		commaSeperatedHosts := strings.Join(node.Spec.Hosts, ",")
 		if node.Spec.RPC {
@@ -259,8 +271,45 @@ func (g *GethClient) Genesis() (content string, err error) {
 
 // Image returns geth docker image
 func (g *GethClient) Image() string {
+	if img := g.node.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvGethImage) == "" {
 		return DefaultGethImage
 	}
 	return os.Getenv(EnvGethImage)
 }
+
+// Command is Geth client entrypoint
+func (g *GethClient) Command() []string {
+	return nil
+}
+
+// Env returns environment variables for the client
+func (g *GethClient) Env() []corev1.EnvVar {
+	return nil
+}
+
+// HealthCheck checks JSON-RPC port if enabled, otherwise p2p port
+// JSON-RPC server listens on localhost only if node has RPC gateway, p2p port is checked instead
+func (g *GethClient) HealthCheck() *corev1.ProbeHandler {
+	if g.node.Spec.RPC && g.node.Spec.RPCGateway == nil {
+		return clients.TCPHealthCheck(g.node.Spec.RPCPort)
+	}
+	return clients.TCPHealthCheck(g.node.Spec.P2PPort)
+}
+
+// ReadinessCheck sends HTTP request to JSON-RPC server if enabled, otherwise health check is used
+// geth JSON-RPC server responds with 200 OK to GET requests without body, which is meant for health checks
+func (g *GethClient) ReadinessCheck() *corev1.ProbeHandler {
+	if g.node.Spec.RPC && g.node.Spec.RPCGateway == nil {
+		return clients.HTTPHealthCheck("/", g.node.Spec.RPCPort)
+	}
+	return nil
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (g *GethClient) MetricsPath() string {
+	return "/debug/metrics/prometheus"
+}
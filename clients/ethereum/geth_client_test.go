@@ -6,9 +6,11 @@ import (
 
 	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
 	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -46,6 +48,14 @@ var _ = Describe("Geth Client", func() {
 
 			Expect(client.EncodeStaticNodes()).To(Equal(fmt.Sprintf("[Node.P2P]\nStaticNodes = [\"%s\"]", string(enode))))
 		})
+
+		It("should reject flags managed by Kotal in extra args", func() {
+			extra := node.DeepCopy()
+			extra.Spec.Network = ethereumv1alpha1.RinkebyNetwork
+			extra.Spec.ExtraArgs = []string{"--cache=4096", "--http.port=8546"}
+			extra.Default()
+			Expect(extra.ValidateCreate()).To(MatchError(ContainSubstring("flag http.port is managed by Kotal for geth client")))
+		})
 	})
 
 	Context("Joining mainnet", func() {
@@ -79,6 +89,9 @@ var _ = Describe("Geth Client", func() {
 				},
 				GraphQL:     true,
 				GraphQLPort: 9999,
+				Metrics: sharedAPI.Metrics{
+					Enabled: true,
+				},
 			},
 		}
 		node.Default()
@@ -130,7 +143,87 @@ var _ = Describe("Geth Client", func() {
 				"allowed.domain.com",
 				GethWSOrigins,
 				"allowed.domain.com",
+				GethMetrics,
+				GethMetricsHost,
+				ethereumv1alpha1.DefaultMetricsHost,
+				GethMetricsPort,
+				fmt.Sprintf("%d", ethereumv1alpha1.DefaultMetricsPort),
+			))
+		})
+
+		It("should serve metrics on prometheus path", func() {
+			client, _ := NewClient(node)
+			Expect(client.MetricsPath()).To(Equal("/debug/metrics/prometheus"))
+		})
+
+		It("should check JSON-RPC server readiness", func() {
+			client, _ := NewClient(node)
+			Expect(client.HealthCheck()).To(Equal(clients.TCPHealthCheck(8888)))
+			Expect(client.(clients.ReadinessChecker).ReadinessCheck()).To(Equal(clients.HTTPHealthCheck("/", 8888)))
+		})
+	})
+
+	Context("exposed by load balancer with static external ip", func() {
+		node := &ethereumv1alpha1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "geth-exposed-node",
+			},
+			Spec: ethereumv1alpha1.NodeSpec{
+				Network: ethereumv1alpha1.MainNetwork,
+				Client:  ethereumv1alpha1.GethClient,
+				Expose: &sharedAPI.Expose{
+					ServiceType: corev1.ServiceTypeLoadBalancer,
+					ExternalIP:  "203.0.113.10",
+				},
+			},
+		}
+		node.Default()
+
+		It("should announce external ip to peers", func() {
+
+			client, err := NewClient(node)
+
+			Expect(err).To(BeNil())
+			Expect(client.Args()).To(ContainElements(
+				GethNat,
+				"extip:203.0.113.10",
+			))
+		})
+
+	})
+
+	Context("behind rpc gateway", func() {
+		node := &ethereumv1alpha1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "geth-gateway-node",
+			},
+			Spec: ethereumv1alpha1.NodeSpec{
+				Network: ethereumv1alpha1.MainNetwork,
+				Client:  ethereumv1alpha1.GethClient,
+				RPC:     true,
+				WS:      true,
+				RPCGateway: &sharedAPI.RPCGateway{
+					Auth:       sharedAPI.APIKeyAuth,
+					SecretName: "rpc-keys",
+				},
+			},
+		}
+		node.Default()
+
+		It("should listen on localhost only", func() {
+			client, _ := NewClient(node)
+			Expect(client.Args()).To(ContainElements(
+				GethRPCHTTPHost,
+				"127.0.0.1",
+				GethRPCWSHost,
 			))
+			Expect(client.Args()).NotTo(ContainElement(DefaultHost))
+		})
+
+		It("should check p2p port health", func() {
+			client, _ := NewClient(node)
+			Expect(client.HealthCheck().TCPSocket.Port.IntValue()).To(Equal(int(node.Spec.P2PPort)))
+			Expect(client.(clients.ReadinessChecker).ReadinessCheck()).To(BeNil())
 		})
 	})
 
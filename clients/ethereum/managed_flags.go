@@ -0,0 +1,125 @@
+package ethereum
+
+import (
+	"fmt"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+)
+
+func init() {
+	ethereumv1alpha1.NodeManagedFlags = nodeManagedFlags
+}
+
+// nodeManagedFlags returns client flags set by Kotal that can't be used in extra args
+func nodeManagedFlags(node *ethereumv1alpha1.Node) []string {
+	var flags []string
+
+	switch node.Spec.Client {
+	case ethereumv1alpha1.BesuClient:
+		flags = []string{
+			BesuLogging,
+			BesuNetworkID,
+			BesuNatMethod,
+			BesuNodePrivateKey,
+			BesuGenesisFile,
+			BesuDataPath,
+			BesuNetwork,
+			BesuDiscoveryEnabled,
+			BesuP2PPort,
+			BesuP2PHost,
+			BesuBootnodes,
+			BesuSyncMode,
+			BesuMinerEnabled,
+			BesuMinerCoinbase,
+			BesuRPCHTTPCorsOrigins,
+			BesuRPCHTTPEnabled,
+			BesuRPCHTTPPort,
+			BesuRPCHTTPHost,
+			BesuRPCHTTPAPI,
+			BesuRPCWSEnabled,
+			BesuRPCWSPort,
+			BesuRPCWSHost,
+			BesuRPCWSAPI,
+			BesuGraphQLHTTPEnabled,
+			BesuGraphQLHTTPPort,
+			BesuGraphQLHTTPHost,
+			BesuGraphQLHTTPCorsOrigins,
+			BesuMetricsEnabled,
+			BesuMetricsHost,
+			BesuMetricsPort,
+			BesuHostAllowlist,
+			BesuStaticNodesFile,
+		}
+	case ethereumv1alpha1.GethClient:
+		flags = []string{
+			GethLogging,
+			GethConfig,
+			GethNetworkID,
+			GethNodeKey,
+			GethNoDiscovery,
+			GethDataDir,
+			GethDisableIPC,
+			GethP2PPort,
+			GethNat,
+			GethBootnodes,
+			GethSyncMode,
+			GethMinerEnabled,
+			GethMinerCoinbase,
+			GethRPCHTTPCorsOrigins,
+			GethRPCHTTPEnabled,
+			GethRPCHTTPPort,
+			GethRPCHTTPHost,
+			GethRPCHTTPAPI,
+			GethRPCHostWhitelist,
+			GethRPCWSEnabled,
+			GethRPCWSPort,
+			GethRPCWSHost,
+			GethRPCWSAPI,
+			GethWSOrigins,
+			GethGraphQLHTTPEnabled,
+			GethGraphQLHTTPCorsOrigins,
+			GethGraphQLHostWhitelist,
+			GethMetrics,
+			GethMetricsHost,
+			GethMetricsPort,
+			GethUnlock,
+			GethPassword,
+		}
+		// geth public networks are joined using --<network> flag
+		if node.Spec.Network != "" {
+			flags = append(flags, fmt.Sprintf("--%s", node.Spec.Network))
+		}
+	case ethereumv1alpha1.NethermindClient:
+		flags = []string{
+			NethermindLogging,
+			NethermindNodePrivateKey,
+			NethermindStaticNodesFile,
+			NethermindBootnodes,
+			NethermindGenesisFile,
+			NethermindDataPath,
+			NethermindNetwork,
+			NethermindDiscoveryEnabled,
+			NethermindP2PPort,
+			NethermindExternalIP,
+			NethermindFastSync,
+			NethermindFastBlocks,
+			NethermindDownloadBodiesInFastSync,
+			NethermindDownloadReceiptsInFastSync,
+			NethermindDownloadHeadersInFastSync,
+			NethermindMinerCoinbase,
+			NethermindRPCHTTPEnabled,
+			NethermindRPCHTTPHost,
+			NethermindRPCHTTPPort,
+			NethermindRPCHTTPAPI,
+			NethermindRPCWSEnabled,
+			NethermindRPCWSPort,
+			NethermindUnlockAccounts,
+			NethermindPasswordFiles,
+			NethermindMiningEnabled,
+			NethermindMetricsEnabled,
+			NethermindMetricsPort,
+		}
+	}
+
+	return flags
+}
@@ -8,7 +8,9 @@ import (
 
 	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
 	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
+	corev1 "k8s.io/api/core/v1"
 )
 
 const (
@@ -54,6 +56,10 @@ func (n *NethermindClient) Args() (args []string) {
 	appendArg(NethermindP2PPort, fmt.Sprintf("%d", node.Spec.P2PPort))
 	appendArg(NethermindLogging, n.LoggingArgFromVerbosity(node.Spec.Logging))
 
+	if externalIP := node.Spec.Expose.GetExternalIP(); externalIP != "" {
+		appendArg(NethermindExternalIP, externalIP)
+	}
+
 	if node.Spec.NodePrivateKeySecretName != "" {
 		// use enode private key in binary format
 		// that has been converted using nethermind_convert_enode_privatekey.sh script
@@ -104,7 +110,7 @@ func (n *NethermindClient) Args() (args []string) {
 	if node.Spec.RPC {
 		appendArg(NethermindRPCHTTPEnabled, "true")
 		appendArg(NethermindRPCHTTPPort, fmt.Sprintf("%d", node.Spec.RPCPort))
-		appendArg(NethermindRPCHTTPHost, DefaultHost)
+		appendArg(NethermindRPCHTTPHost, clients.RPCHost(node.Spec.RPCGateway, DefaultHost))
 		// JSON-RPC API
 		apis := []string{}
 		for _, api := range node.Spec.RPCAPI {
@@ -121,6 +127,12 @@ func (n *NethermindClient) Args() (args []string) {
 		// nethermind ws reuses enabled JSON-RPC modules
 	}
 
+	if node.Spec.Metrics.Enabled {
+		appendArg(NethermindMetricsEnabled, "true")
+		appendArg(NethermindMetricsPort, fmt.Sprintf("%d", node.Spec.Metrics.Port))
+		// no option for metrics host, metrics server listens on all interfaces
+	}
+
 	return args
 }
 
@@ -142,8 +154,45 @@ func (n *NethermindClient) EncodeStaticNodes() string {
 
 // Image returns nethermind docker image
 func (n *NethermindClient) Image() string {
+	if img := n.node.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvNethermindImage) == "" {
 		return DefaultNethermindImage
 	}
 	return os.Getenv(EnvNethermindImage)
 }
+
+// Command is Nethermind client entrypoint
+func (n *NethermindClient) Command() []string {
+	return nil
+}
+
+// Env returns environment variables for the client
+func (n *NethermindClient) Env() []corev1.EnvVar {
+	return nil
+}
+
+// HealthCheck checks JSON-RPC port if enabled, otherwise p2p port
+// JSON-RPC server listens on localhost only if node has RPC gateway, p2p port is checked instead
+func (n *NethermindClient) HealthCheck() *corev1.ProbeHandler {
+	if n.node.Spec.RPC && n.node.Spec.RPCGateway == nil {
+		return clients.TCPHealthCheck(n.node.Spec.RPCPort)
+	}
+	return clients.TCPHealthCheck(n.node.Spec.P2PPort)
+}
+
+// ReadinessCheck sends HTTP request to JSON-RPC server if enabled, otherwise health check is used
+// TCP check passes as soon as JSON-RPC port is bound, before nethermind is serving requests
+func (n *NethermindClient) ReadinessCheck() *corev1.ProbeHandler {
+	if n.node.Spec.RPC && n.node.Spec.RPCGateway == nil {
+		return clients.HTTPHealthCheck("/", n.node.Spec.RPCPort)
+	}
+	return nil
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (n *NethermindClient) MetricsPath() string {
+	return "/metrics"
+}
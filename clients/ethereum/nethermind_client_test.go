@@ -6,9 +6,11 @@ import (
 
 	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
 	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -114,6 +116,41 @@ var _ = Describe("Nethermind Client", func() {
 
 		})
 
+		It("should check JSON-RPC server readiness", func() {
+			client, _ := NewClient(&node)
+			Expect(client.HealthCheck()).To(Equal(clients.TCPHealthCheck(8799)))
+			Expect(client.(clients.ReadinessChecker).ReadinessCheck()).To(Equal(clients.HTTPHealthCheck("/", 8799)))
+		})
+
+	})
+
+	Context("exposed by load balancer with static external ip", func() {
+		node := &ethereumv1alpha1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "nethermind-exposed-node",
+			},
+			Spec: ethereumv1alpha1.NodeSpec{
+				Network: ethereumv1alpha1.MainNetwork,
+				Client:  ethereumv1alpha1.NethermindClient,
+				Expose: &sharedAPI.Expose{
+					ServiceType: corev1.ServiceTypeLoadBalancer,
+					ExternalIP:  "203.0.113.10",
+				},
+			},
+		}
+		node.Default()
+
+		It("should announce external ip to peers", func() {
+
+			client, err := NewClient(node)
+
+			Expect(err).To(BeNil())
+			Expect(client.Args()).To(ContainElements(
+				NethermindExternalIP,
+				"203.0.113.10",
+			))
+		})
+
 	})
 
 	Context("miner in private PoW network", func() {
@@ -30,6 +30,8 @@ const (
 	BesuDiscoveryEnabled = "--discovery-enabled"
 	// BesuP2PPort is the argument used for p2p port
 	BesuP2PPort = "--p2p-port"
+	// BesuP2PHost is the argument used for p2p host advertised to peers
+	BesuP2PHost = "--p2p-host"
 	// BesuBootnodes is the argument used for bootnodes
 	BesuBootnodes = "--bootnodes"
 	// BesuSyncMode is the argument used for sync mode
@@ -64,6 +66,12 @@ const (
 	BesuGraphQLHTTPHost = "--graphql-http-host"
 	// BesuGraphQLHTTPCorsOrigins is the argument used for GraphQL HTTP Cors origins
 	BesuGraphQLHTTPCorsOrigins = "--graphql-http-cors-origins"
+	// BesuMetricsEnabled is the argument used to enable prometheus metrics
+	BesuMetricsEnabled = "--metrics-enabled"
+	// BesuMetricsHost is the argument used for prometheus metrics host
+	BesuMetricsHost = "--metrics-host"
+	// BesuMetricsPort is the argument used for prometheus metrics port
+	BesuMetricsPort = "--metrics-port"
 	// BesuHostAllowlist is the argument used for whitelisting hosts
 	BesuHostAllowlist = "--host-allowlist"
 	// BesuStaticNodesFile is the argument used to locate static nodes file
@@ -88,6 +96,8 @@ const (
 	GethDisableIPC = "--ipcdisable"
 	// GethP2PPort is the argument used for p2p port
 	GethP2PPort = "--port"
+	// GethNat is the argument used for nat port mapping mechanism
+	GethNat = "--nat"
 	// GethBootnodes is the argument used for bootnodes
 	GethBootnodes = "--bootnodes"
 	// GethSyncMode is the argument used for sync mode
@@ -128,6 +138,12 @@ const (
 	GethGraphQLHTTPCorsOrigins = "--graphql.corsdomain"
 	// GethGraphQLHostWhitelist is the argument used for whitelisting hosts
 	GethGraphQLHostWhitelist = "--graphql.vhosts"
+	// GethMetrics is the argument used to enable metrics collection and reporting
+	GethMetrics = "--metrics"
+	// GethMetricsHost is the argument used for metrics HTTP server listening host
+	GethMetricsHost = "--metrics.addr"
+	// GethMetricsPort is the argument used for metrics HTTP server listening port
+	GethMetricsPort = "--metrics.port"
 	// GethUnlock is the argument used for unlocking imported ethereum account
 	GethUnlock = "--unlock"
 	// GethPassword is the argument used for locking imported ethereum address
@@ -213,6 +229,8 @@ const (
 	NethermindDiscoveryEnabled = "--Init.DiscoveryEnabled"
 	// NethermindP2PPort is the argument used for p2p port
 	NethermindP2PPort = "--Network.P2PPort"
+	// NethermindExternalIP is the argument used for external ip advertised to peers
+	NethermindExternalIP = "--Network.ExternalIp"
 	// NethermindFastSync is the argument used to enable beam sync
 	NethermindFastSync = "--Sync.FastSync"
 	// NethermindFastBlocks is the argument used to enable fast blocks sync
@@ -243,4 +261,8 @@ const (
 	NethermindPasswordFiles = "--KeyStore.PasswordFiles"
 	// NethermindMiningEnabled is the argument used for turning on mining
 	NethermindMiningEnabled = "--Mining.Enabled"
+	// NethermindMetricsEnabled is the argument used to enable metrics
+	NethermindMetricsEnabled = "--Metrics.Enabled"
+	// NethermindMetricsPort is the argument used for prometheus metrics port
+	NethermindMetricsPort = "--Metrics.ExposePort"
 )
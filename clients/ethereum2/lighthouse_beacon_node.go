@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -62,11 +63,26 @@ func (t *LighthouseBeaconNode) Args() (args []string) {
 		}
 	}
 
+	if node.Spec.Metrics.Enabled {
+		args = append(args, LighthouseMetrics)
+		args = append(args, LighthouseMetricsAddress, node.Spec.Metrics.Host)
+		args = append(args, LighthouseMetricsPort, fmt.Sprintf("%d", node.Spec.Metrics.Port))
+	}
+
 	if node.Spec.P2PPort != 0 {
 		args = append(args, LighthousePort, fmt.Sprintf("%d", node.Spec.P2PPort))
 		args = append(args, LighthouseDiscoveryPort, fmt.Sprintf("%d", node.Spec.P2PPort))
 	}
 
+	if externalIP := node.Spec.Expose.GetExternalIP(); externalIP != "" {
+		args = append(args, LighthouseENRAddress, externalIP)
+	}
+
+	if port := node.Spec.Expose.AnnouncedPort(node.Spec.P2PPort); port != node.Spec.P2PPort {
+		args = append(args, LighthouseENRTCPPort, fmt.Sprintf("%d", port))
+		args = append(args, LighthouseENRUDPPort, fmt.Sprintf("%d", port))
+	}
+
 	return
 }
 
@@ -78,8 +94,25 @@ func (t *LighthouseBeaconNode) Command() (command []string) {
 
 // Image returns prysm docker image
 func (t *LighthouseBeaconNode) Image() string {
+	if img := t.node.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvLighthouseBeaconNodeImage) == "" {
 		return DefaultLighthouseBeaconNodeImage
 	}
 	return os.Getenv(EnvLighthouseBeaconNodeImage)
 }
+
+// HealthCheck returns beacon node version endpoint if REST API is enabled, otherwise p2p port check
+func (t *LighthouseBeaconNode) HealthCheck() *corev1.ProbeHandler {
+	if t.node.Spec.REST {
+		return clients.HTTPHealthCheck("/eth/v1/node/version", t.node.Spec.RESTPort)
+	}
+	return clients.TCPHealthCheck(t.node.Spec.P2PPort)
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (t *LighthouseBeaconNode) MetricsPath() string {
+	return "/metrics"
+}
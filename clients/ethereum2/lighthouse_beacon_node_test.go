@@ -8,6 +8,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 )
 
 var _ = Describe("Lighthouse Ethereum 2.0 client arguments", func() {
@@ -161,6 +162,46 @@ var _ = Describe("Lighthouse Ethereum 2.0 client arguments", func() {
 				"*",
 			},
 		},
+		{
+			title: "beacon node exposed by load balancer with static external ip",
+			node: &ethereum2v1alpha1.BeaconNode{
+				Spec: ethereum2v1alpha1.BeaconNodeSpec{
+					Client:        ethereum2v1alpha1.LighthouseClient,
+					P2PPort:       9000,
+					Network:       "mainnet",
+					Eth1Endpoints: []string{"https://localhost:8545"},
+					Expose: &sharedAPI.Expose{
+						ServiceType: corev1.ServiceTypeLoadBalancer,
+						ExternalIP:  "203.0.113.10",
+					},
+				},
+			},
+			result: []string{
+				LighthouseENRAddress,
+				"203.0.113.10",
+			},
+		},
+		{
+			title: "beacon node exposed by node port",
+			node: &ethereum2v1alpha1.BeaconNode{
+				Spec: ethereum2v1alpha1.BeaconNodeSpec{
+					Client:        ethereum2v1alpha1.LighthouseClient,
+					P2PPort:       9000,
+					Network:       "mainnet",
+					Eth1Endpoints: []string{"https://localhost:8545"},
+					Expose: &sharedAPI.Expose{
+						ServiceType: corev1.ServiceTypeNodePort,
+						NodePort:    31000,
+					},
+				},
+			},
+			result: []string{
+				LighthouseENRTCPPort,
+				"31000",
+				LighthouseENRUDPPort,
+				"31000",
+			},
+		},
 	}
 
 	for _, c := range cases {
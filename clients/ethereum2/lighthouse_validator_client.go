@@ -1,6 +1,7 @@
 package ethereum2
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
@@ -52,6 +53,12 @@ func (t *LighthouseValidatorClient) Args() (args []string) {
 		args = append(args, LighthouseGraffiti, validator.Spec.Graffiti)
 	}
 
+	if validator.Spec.Metrics.Enabled {
+		args = append(args, LighthouseMetrics)
+		args = append(args, LighthouseMetricsAddress, validator.Spec.Metrics.Host)
+		args = append(args, LighthouseMetricsPort, fmt.Sprintf("%d", validator.Spec.Metrics.Port))
+	}
+
 	return
 }
 
@@ -63,8 +70,22 @@ func (t *LighthouseValidatorClient) Command() (command []string) {
 
 // Image returns prysm docker image
 func (t *LighthouseValidatorClient) Image() string {
+	if img := t.validator.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvLighthouseValidatorImage) == "" {
 		return DefaultLighthouseValidatorImage
 	}
 	return os.Getenv(EnvLighthouseValidatorImage)
 }
+
+// HealthCheck is not supported by validator clients which expose no ports
+func (t *LighthouseValidatorClient) HealthCheck() *corev1.ProbeHandler {
+	return nil
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (t *LighthouseValidatorClient) MetricsPath() string {
+	return "/metrics"
+}
@@ -0,0 +1,165 @@
+package ethereum2
+
+import (
+	"fmt"
+
+	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+)
+
+func init() {
+	ethereum2v1alpha1.BeaconNodeManagedFlags = beaconNodeManagedFlags
+	ethereum2v1alpha1.ValidatorManagedFlags = validatorManagedFlags
+}
+
+// beaconNodeManagedFlags returns beacon node client flags set by Kotal that can't be used in extra args
+func beaconNodeManagedFlags(node *ethereum2v1alpha1.BeaconNode) []string {
+	var flags []string
+
+	switch node.Spec.Client {
+	case ethereum2v1alpha1.TekuClient:
+		flags = []string{
+			TekuNetwork,
+			TekuEth1Endpoints,
+			TekuDataPath,
+			TekuRestEnabled,
+			TekuRestPort,
+			TekuRestHost,
+			TekuP2PPort,
+			TekuP2PAdvertisedIP,
+			TekuP2PAdvertisedPort,
+			TekuRESTAPICorsOrigins,
+			TekuRESTAPIHostAllowlist,
+			TekuLogging,
+			TekuMetricsEnabled,
+			TekuMetricsInterface,
+			TekuMetricsPort,
+			TekuMetricsHostAllowlist,
+		}
+	case ethereum2v1alpha1.PrysmClient:
+		flags = []string{
+			PrysmDataDir,
+			PrysmWeb3Provider,
+			PrysmFallbackWeb3Provider,
+			PrysmAcceptTermsOfUse,
+			PrysmRPCPort,
+			PrysmRPCHost,
+			PrysmDisableGRPC,
+			PrysmGRPCPort,
+			PrysmGRPCHost,
+			PrysmP2PTCPPort,
+			PrysmP2PUDPPort,
+			PrysmP2PHostIP,
+			PrysmGRPCGatewayCorsDomains,
+			PrysmLogging,
+			PrysmTLSCert,
+			PrysmTLSKey,
+			PrysmMonitoringHost,
+			PrysmMonitoringPort,
+			PrysmDisableMonitoring,
+			// prysm networks are joined using --<network> flag
+			fmt.Sprintf("--%s", node.Spec.Network),
+		}
+	case ethereum2v1alpha1.LighthouseClient:
+		flags = []string{
+			LighthouseDataDir,
+			LighthouseNetwork,
+			LighthouseEth1,
+			LighthouseHTTP,
+			LighthouseAllowOrigins,
+			LighthouseHTTPPort,
+			LighthouseHTTPAddress,
+			LighthouseEth1Endpoints,
+			LighthousePort,
+			LighthouseDiscoveryPort,
+			LighthouseENRAddress,
+			LighthouseENRTCPPort,
+			LighthouseENRUDPPort,
+			LighthouseDebugLevel,
+			LighthouseMetrics,
+			LighthouseMetricsAddress,
+			LighthouseMetricsPort,
+		}
+	case ethereum2v1alpha1.NimbusClient:
+		flags = []string{
+			NimbusDataDir,
+			NimbusNonInteractive,
+			NimbusNetwork,
+			NimbusEth1Endpoint,
+			NimbusRPC,
+			NimbusRPCPort,
+			NimbusRPCAddress,
+			NimbusTCPPort,
+			NimbusUDPPort,
+			NimbusNat,
+			NimbusLogging,
+			NimbusMetrics,
+			NimbusMetricsAddress,
+			NimbusMetricsPort,
+		}
+	}
+
+	return flags
+}
+
+// validatorManagedFlags returns validator client flags set by Kotal that can't be used in extra args
+func validatorManagedFlags(node *ethereum2v1alpha1.Validator) []string {
+	var flags []string
+
+	switch node.Spec.Client {
+	case ethereum2v1alpha1.TekuClient:
+		flags = []string{
+			TekuNetwork,
+			TekuDataPath,
+			TekuBeaconNodeEndpoint,
+			TekuGraffiti,
+			TekuValidatorKeys,
+			TekuValidatorsKeystoreLockingEnabled,
+			TekuMetricsEnabled,
+			TekuMetricsInterface,
+			TekuMetricsPort,
+			TekuMetricsHostAllowlist,
+		}
+	case ethereum2v1alpha1.PrysmClient:
+		flags = []string{
+			PrysmDataDir,
+			PrysmAcceptTermsOfUse,
+			PrysmLogging,
+			PrysmTLSCert,
+			PrysmBeaconRPCProvider,
+			PrysmGraffiti,
+			PrysmWalletDir,
+			PrysmWalletPasswordFile,
+			PrysmMonitoringHost,
+			PrysmMonitoringPort,
+			PrysmDisableMonitoring,
+			// prysm networks are joined using --<network> flag
+			fmt.Sprintf("--%s", node.Spec.Network),
+		}
+	case ethereum2v1alpha1.LighthouseClient:
+		flags = []string{
+			LighthouseDataDir,
+			LighthouseNetwork,
+			LighthouseDebugLevel,
+			LighthouseBeaconNodeEndpoints,
+			LighthouseGraffiti,
+			LighthouseMetrics,
+			LighthouseMetricsAddress,
+			LighthouseMetricsPort,
+		}
+	case ethereum2v1alpha1.NimbusClient:
+		flags = []string{
+			NimbusDataDir,
+			NimbusNonInteractive,
+			NimbusLogging,
+			NimbusGraffiti,
+			NimbusValidatorsDir,
+			NimbusSecretsDir,
+			NimbusBeaconNodes,
+			NimbusMetrics,
+			NimbusMetricsAddress,
+			NimbusMetricsPort,
+		}
+	}
+
+	return flags
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -60,11 +61,21 @@ func (t *NimbusBeaconNode) Args() (args []string) {
 		}
 	}
 
+	if node.Spec.Metrics.Enabled {
+		args = append(args, NimbusMetrics)
+		args = append(args, argWithVal(NimbusMetricsAddress, node.Spec.Metrics.Host))
+		args = append(args, argWithVal(NimbusMetricsPort, fmt.Sprintf("%d", node.Spec.Metrics.Port)))
+	}
+
 	if node.Spec.P2PPort != 0 {
 		args = append(args, argWithVal(NimbusTCPPort, fmt.Sprintf("%d", node.Spec.P2PPort)))
 		args = append(args, argWithVal(NimbusUDPPort, fmt.Sprintf("%d", node.Spec.P2PPort)))
 	}
 
+	if externalIP := node.Spec.Expose.GetExternalIP(); externalIP != "" {
+		args = append(args, argWithVal(NimbusNat, fmt.Sprintf("extip:%s", externalIP)))
+	}
+
 	return
 }
 
@@ -76,6 +87,10 @@ func (t *NimbusBeaconNode) Command() (command []string) {
 
 // Image returns prysm docker image
 func (t *NimbusBeaconNode) Image() string {
+	if img := t.node.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvNimbusBeaconNodeImage) == "" {
 		return DefaultNimbusBeaconNodeImage
 	}
@@ -87,3 +102,16 @@ func (t *NimbusBeaconNode) Image() string {
 func argWithVal(arg, val string) string {
 	return fmt.Sprintf("%s=%s", arg, val)
 }
+
+// HealthCheck checks JSON-RPC port if enabled, otherwise p2p port
+func (t *NimbusBeaconNode) HealthCheck() *corev1.ProbeHandler {
+	if t.node.Spec.RPC {
+		return clients.TCPHealthCheck(t.node.Spec.RPCPort)
+	}
+	return clients.TCPHealthCheck(t.node.Spec.P2PPort)
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (t *NimbusBeaconNode) MetricsPath() string {
+	return "/metrics"
+}
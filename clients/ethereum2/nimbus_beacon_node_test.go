@@ -7,6 +7,7 @@ import (
 	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 )
 
 var _ = Describe("Nimbus Ethereum 2.0 client arguments", func() {
@@ -127,6 +128,24 @@ var _ = Describe("Nimbus Ethereum 2.0 client arguments", func() {
 				argWithVal(NimbusRPCAddress, "0.0.0.0"),
 			},
 		},
+		{
+			title: "beacon node exposed by load balancer with static external ip",
+			node: &ethereum2v1alpha1.BeaconNode{
+				Spec: ethereum2v1alpha1.BeaconNodeSpec{
+					Client:        ethereum2v1alpha1.NimbusClient,
+					P2PPort:       9000,
+					Network:       "mainnet",
+					Eth1Endpoints: []string{"https://localhost:8545"},
+					Expose: &sharedAPI.Expose{
+						ServiceType: corev1.ServiceTypeLoadBalancer,
+						ExternalIP:  "203.0.113.10",
+					},
+				},
+			},
+			result: []string{
+				argWithVal(NimbusNat, "extip:203.0.113.10"),
+			},
+		},
 	}
 
 	for _, c := range cases {
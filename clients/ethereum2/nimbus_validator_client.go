@@ -55,6 +55,12 @@ func (t *NimbusValidatorClient) Args() (args []string) {
 		args = append(args, argWithVal(NimbusGraffiti, validator.Spec.Graffiti))
 	}
 
+	if validator.Spec.Metrics.Enabled {
+		args = append(args, NimbusMetrics)
+		args = append(args, argWithVal(NimbusMetricsAddress, validator.Spec.Metrics.Host))
+		args = append(args, argWithVal(NimbusMetricsPort, fmt.Sprintf("%d", validator.Spec.Metrics.Port)))
+	}
+
 	return
 }
 
@@ -66,8 +72,22 @@ func (t *NimbusValidatorClient) Command() (command []string) {
 
 // Image returns prysm docker image
 func (t *NimbusValidatorClient) Image() string {
+	if img := t.validator.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvNimbusValidatorImage) == "" {
 		return DefaultNimbusValidatorImage
 	}
 	return os.Getenv(EnvNimbusValidatorImage)
 }
+
+// HealthCheck is not supported by validator clients which expose no ports
+func (t *NimbusValidatorClient) HealthCheck() *corev1.ProbeHandler {
+	return nil
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (t *NimbusValidatorClient) MetricsPath() string {
+	return "/metrics"
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -80,11 +81,22 @@ func (t *PrysmBeaconNode) Args() (args []string) {
 		args = append(args, PrysmTLSKey, fmt.Sprintf("%s/tls.key", shared.PathSecrets(t.HomeDir())))
 	}
 
+	if node.Spec.Metrics.Enabled {
+		args = append(args, PrysmMonitoringHost, node.Spec.Metrics.Host)
+		args = append(args, PrysmMonitoringPort, fmt.Sprintf("%d", node.Spec.Metrics.Port))
+	} else {
+		args = append(args, PrysmDisableMonitoring)
+	}
+
 	if node.Spec.P2PPort != 0 {
 		args = append(args, PrysmP2PTCPPort, fmt.Sprintf("%d", node.Spec.P2PPort))
 		args = append(args, PrysmP2PUDPPort, fmt.Sprintf("%d", node.Spec.P2PPort))
 	}
 
+	if externalIP := node.Spec.Expose.GetExternalIP(); externalIP != "" {
+		args = append(args, PrysmP2PHostIP, externalIP)
+	}
+
 	return
 }
 
@@ -96,8 +108,25 @@ func (t *PrysmBeaconNode) Command() (command []string) {
 
 // Image returns prysm docker image
 func (t *PrysmBeaconNode) Image() string {
+	if img := t.node.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvPrysmBeaconNodeImage) == "" {
 		return DefaultPrysmBeaconNodeImage
 	}
 	return os.Getenv(EnvPrysmBeaconNodeImage)
 }
+
+// HealthCheck checks RPC port if set, otherwise p2p port
+func (t *PrysmBeaconNode) HealthCheck() *corev1.ProbeHandler {
+	if t.node.Spec.RPCPort != 0 {
+		return clients.TCPHealthCheck(t.node.Spec.RPCPort)
+	}
+	return clients.TCPHealthCheck(t.node.Spec.P2PPort)
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (t *PrysmBeaconNode) MetricsPath() string {
+	return "/metrics"
+}
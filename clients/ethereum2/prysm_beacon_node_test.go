@@ -8,6 +8,7 @@ import (
 	"github.com/kotalco/kotal/controllers/shared"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 )
 
 var _ = Describe("Prysm Ethereum 2.0 client arguments", func() {
@@ -219,6 +220,25 @@ var _ = Describe("Prysm Ethereum 2.0 client arguments", func() {
 				"*",
 			},
 		},
+		{
+			title: "beacon node exposed by load balancer with static external ip",
+			node: &ethereum2v1alpha1.BeaconNode{
+				Spec: ethereum2v1alpha1.BeaconNodeSpec{
+					Client:        ethereum2v1alpha1.PrysmClient,
+					P2PPort:       9000,
+					Network:       "mainnet",
+					Eth1Endpoints: []string{"https://localhost:8545"},
+					Expose: &sharedAPI.Expose{
+						ServiceType: corev1.ServiceTypeLoadBalancer,
+						ExternalIP:  "203.0.113.10",
+					},
+				},
+			},
+			result: []string{
+				PrysmP2PHostIP,
+				"203.0.113.10",
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -62,6 +62,13 @@ func (t *PrysmValidatorClient) Args() (args []string) {
 		args = append(args, PrysmTLSCert, fmt.Sprintf("%s/cert/tls.crt", shared.PathSecrets(t.HomeDir())))
 	}
 
+	if validator.Spec.Metrics.Enabled {
+		args = append(args, PrysmMonitoringHost, validator.Spec.Metrics.Host)
+		args = append(args, PrysmMonitoringPort, fmt.Sprintf("%d", validator.Spec.Metrics.Port))
+	} else {
+		args = append(args, PrysmDisableMonitoring)
+	}
+
 	return args
 }
 
@@ -73,8 +80,22 @@ func (t *PrysmValidatorClient) Command() (command []string) {
 
 // Image returns prysm docker image
 func (t *PrysmValidatorClient) Image() string {
+	if img := t.validator.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvPrysmValidatorImage) == "" {
 		return DefaultPrysmValidatorImage
 	}
 	return os.Getenv(EnvPrysmValidatorImage)
 }
+
+// HealthCheck is not supported by validator clients which expose no ports
+func (t *PrysmValidatorClient) HealthCheck() *corev1.ProbeHandler {
+	return nil
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (t *PrysmValidatorClient) MetricsPath() string {
+	return "/metrics"
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -56,10 +57,25 @@ func (t *TekuBeaconNode) Args() (args []string) {
 		}
 	}
 
+	if node.Spec.Metrics.Enabled {
+		args = append(args, TekuMetricsEnabled)
+		args = append(args, TekuMetricsInterface, node.Spec.Metrics.Host)
+		args = append(args, TekuMetricsPort, fmt.Sprintf("%d", node.Spec.Metrics.Port))
+		args = append(args, TekuMetricsHostAllowlist, "*")
+	}
+
 	if node.Spec.P2PPort != 0 {
 		args = append(args, TekuP2PPort, fmt.Sprintf("%d", node.Spec.P2PPort))
 	}
 
+	if externalIP := node.Spec.Expose.GetExternalIP(); externalIP != "" {
+		args = append(args, TekuP2PAdvertisedIP, externalIP)
+	}
+
+	if port := node.Spec.Expose.AnnouncedPort(node.Spec.P2PPort); port != node.Spec.P2PPort {
+		args = append(args, TekuP2PAdvertisedPort, fmt.Sprintf("%d", port))
+	}
+
 	return
 }
 
@@ -75,8 +91,25 @@ func (t *TekuBeaconNode) Env() []corev1.EnvVar {
 
 // Image returns teku docker image
 func (t *TekuBeaconNode) Image() string {
+	if img := t.node.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvTekuBeaconNodeImage) == "" {
 		return DefaultTekuBeaconNodeImage
 	}
 	return os.Getenv(EnvTekuBeaconNodeImage)
 }
+
+// HealthCheck returns Teku liveness endpoint if REST API is enabled, otherwise p2p port check
+func (t *TekuBeaconNode) HealthCheck() *corev1.ProbeHandler {
+	if t.node.Spec.REST {
+		return clients.HTTPHealthCheck("/teku/v1/admin/liveness", t.node.Spec.RESTPort)
+	}
+	return clients.TCPHealthCheck(t.node.Spec.P2PPort)
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (t *TekuBeaconNode) MetricsPath() string {
+	return "/metrics"
+}
@@ -8,6 +8,7 @@ import (
 	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 )
 
 var _ = Describe("Teku Ethereum 2.0 client arguments", func() {
@@ -167,6 +168,47 @@ var _ = Describe("Teku Ethereum 2.0 client arguments", func() {
 				"*",
 			},
 		},
+		{
+			title: "beacon node exposed by load balancer with static external ip",
+			node: &ethereum2v1alpha1.BeaconNode{
+				Spec: ethereum2v1alpha1.BeaconNodeSpec{
+					Client:        ethereum2v1alpha1.TekuClient,
+					P2PPort:       9000,
+					Network:       "mainnet",
+					Eth1Endpoints: []string{"https://localhost:8545"},
+					Expose: &sharedAPI.Expose{
+						ServiceType: corev1.ServiceTypeLoadBalancer,
+						ExternalIP:  "203.0.113.10",
+					},
+				},
+			},
+			result: []string{
+				TekuP2PAdvertisedIP,
+				"203.0.113.10",
+			},
+		},
+		{
+			title: "beacon node exposed by node port",
+			node: &ethereum2v1alpha1.BeaconNode{
+				Spec: ethereum2v1alpha1.BeaconNodeSpec{
+					Client:        ethereum2v1alpha1.TekuClient,
+					P2PPort:       9000,
+					Network:       "mainnet",
+					Eth1Endpoints: []string{"https://localhost:8545"},
+					Expose: &sharedAPI.Expose{
+						ServiceType: corev1.ServiceTypeNodePort,
+						ExternalIP:  "203.0.113.10",
+						NodePort:    31000,
+					},
+				},
+			},
+			result: []string{
+				TekuP2PAdvertisedIP,
+				"203.0.113.10",
+				TekuP2PAdvertisedPort,
+				"31000",
+			},
+		},
 	}
 
 	for _, c := range cases {
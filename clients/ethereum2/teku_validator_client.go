@@ -62,6 +62,13 @@ func (t *TekuValidatorClient) Args() (args []string) {
 
 	args = append(args, TekuValidatorKeys, strings.Join(keyPass, ","))
 
+	if validator.Spec.Metrics.Enabled {
+		args = append(args, TekuMetricsEnabled)
+		args = append(args, TekuMetricsInterface, validator.Spec.Metrics.Host)
+		args = append(args, TekuMetricsPort, fmt.Sprintf("%d", validator.Spec.Metrics.Port))
+		args = append(args, TekuMetricsHostAllowlist, "*")
+	}
+
 	return args
 }
 
@@ -72,8 +79,22 @@ func (t *TekuValidatorClient) Command() (command []string) {
 
 // Image returns teku docker image
 func (t *TekuValidatorClient) Image() string {
+	if img := t.validator.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvTekuValidatorImage) == "" {
 		return DefaultTekuValidatorImage
 	}
 	return os.Getenv(EnvTekuValidatorImage)
 }
+
+// HealthCheck is not supported by validator clients which expose no ports
+func (t *TekuValidatorClient) HealthCheck() *corev1.ProbeHandler {
+	return nil
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (t *TekuValidatorClient) MetricsPath() string {
+	return "/metrics"
+}
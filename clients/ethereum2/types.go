@@ -28,6 +28,10 @@ const (
 	TekuRestHost = "--rest-api-interface"
 	// TekuP2PPort is the argument used p2p and discovery port
 	TekuP2PPort = "--p2p-port"
+	// TekuP2PAdvertisedIP is the argument used for p2p ip advertised to peers
+	TekuP2PAdvertisedIP = "--p2p-advertised-ip"
+	// TekuP2PAdvertisedPort is the argument used for p2p port advertised to peers
+	TekuP2PAdvertisedPort = "--p2p-advertised-port"
 	// TekuRESTAPICorsOrigins is the argument used to whitelist domains for cross domain requests
 	TekuRESTAPICorsOrigins = "--rest-api-cors-origins"
 	// TekuRESTAPIHostAllowlist is the argument used to whitelist hosts for API access
@@ -45,6 +49,14 @@ const (
 	TekuValidatorKeys = "--validator-keys"
 	// TekuValidatorsKeystoreLockingEnabled is the argument used to enable keystore locking files
 	TekuValidatorsKeystoreLockingEnabled = "--validators-keystore-locking-enabled"
+	// TekuMetricsEnabled is the argument used to enable prometheus metrics
+	TekuMetricsEnabled = "--metrics-enabled"
+	// TekuMetricsInterface is the argument used for prometheus metrics host
+	TekuMetricsInterface = "--metrics-interface"
+	// TekuMetricsPort is the argument used for prometheus metrics port
+	TekuMetricsPort = "--metrics-port"
+	// TekuMetricsHostAllowlist is the argument used for hosts allowed to scrape prometheus metrics
+	TekuMetricsHostAllowlist = "--metrics-host-allowlist"
 )
 
 // Prysm client arguments
@@ -71,6 +83,8 @@ const (
 	PrysmP2PTCPPort = "--p2p-tcp-port"
 	// PrysmP2PUDPPort is the argument used p2p discovery udp port
 	PrysmP2PUDPPort = "--p2p-udp-port"
+	// PrysmP2PHostIP is the argument used for p2p ip advertised to peers
+	PrysmP2PHostIP = "--p2p-host-ip"
 	// PrysmGRPCGatewayCorsDomains is the argument used to whitelist domains for cross domain requests
 	PrysmGRPCGatewayCorsDomains = "--grpc-gateway-corsdomain"
 	// PrysmLogging is the argument used to set logging verbosity level
@@ -93,6 +107,12 @@ const (
 	PrysmAccountPasswordFile = "--account-password-file"
 	// PrysmWalletPasswordFile is the argument used to locate wallet password file
 	PrysmWalletPasswordFile = "--wallet-password-file"
+	// PrysmMonitoringHost is the argument used for prometheus metrics host
+	PrysmMonitoringHost = "--monitoring-host"
+	// PrysmMonitoringPort is the argument used for prometheus metrics port
+	PrysmMonitoringPort = "--monitoring-port"
+	// PrysmDisableMonitoring is the argument used to disable prometheus metrics
+	PrysmDisableMonitoring = "--disable-monitoring"
 )
 
 // Lighthouse client arguments
@@ -117,6 +137,12 @@ const (
 	LighthousePort = "--port"
 	// LighthouseDiscoveryPort is the argument used for discovery udp port
 	LighthouseDiscoveryPort = "--discovery-port"
+	// LighthouseENRAddress is the argument used for ip address advertised to peers in node ENR
+	LighthouseENRAddress = "--enr-address"
+	// LighthouseENRTCPPort is the argument used for p2p tcp port advertised to peers in node ENR
+	LighthouseENRTCPPort = "--enr-tcp-port"
+	// LighthouseENRUDPPort is the argument used for discovery udp port advertised to peers in node ENR
+	LighthouseENRUDPPort = "--enr-udp-port"
 	// LighthouseDebugLevel is the argument used to set logging verbosity level
 	LighthouseDebugLevel = "--debug-level"
 
@@ -134,6 +160,12 @@ const (
 	LighthouseKeystore = "--keystore"
 	// LighthousePasswordFile is the argument used to locate password file
 	LighthousePasswordFile = "--password-file"
+	// LighthouseMetrics is the argument used to enable prometheus metrics
+	LighthouseMetrics = "--metrics"
+	// LighthouseMetricsAddress is the argument used for prometheus metrics host
+	LighthouseMetricsAddress = "--metrics-address"
+	// LighthouseMetricsPort is the argument used for prometheus metrics port
+	LighthouseMetricsPort = "--metrics-port"
 )
 
 // Nimbus client arguments
@@ -156,6 +188,8 @@ const (
 	NimbusTCPPort = "--tcp-port"
 	// NimbusUDPPort is the argument used for discovery udp port
 	NimbusUDPPort = "--udp-port"
+	// NimbusNat is the argument used for nat port mapping mechanism
+	NimbusNat = "--nat"
 	// NimbusLogging is the argument used to set logging verbosity level
 	NimbusLogging = "--log-level"
 
@@ -167,4 +201,10 @@ const (
 	NimbusSecretsDir = "--secrets-dir"
 	// NimbusBeaconNodes is the argument used to set one or more beacon node HTTP REST APIs
 	NimbusBeaconNodes = "--beacon-node"
+	// NimbusMetrics is the argument used to enable prometheus metrics
+	NimbusMetrics = "--metrics"
+	// NimbusMetricsAddress is the argument used for prometheus metrics host
+	NimbusMetricsAddress = "--metrics-address"
+	// NimbusMetricsPort is the argument used for prometheus metrics port
+	NimbusMetricsPort = "--metrics-port"
 )
@@ -4,6 +4,7 @@ import (
 	"os"
 
 	filecoinv1alpha1 "github.com/kotalco/kotal/apis/filecoin/v1alpha1"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -28,6 +29,10 @@ const (
 
 // Image returns lotus image for node's network
 func (c *LotusClient) Image() string {
+	if img := c.node.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvLotusImage) == "" {
 		switch c.node.Spec.Network {
 		case filecoinv1alpha1.MainNetwork:
@@ -68,3 +73,16 @@ func (c *LotusClient) Args() (args []string) {
 func (c *LotusClient) HomeDir() string {
 	return LotusHomeDir
 }
+
+// HealthCheck returns lotus liveness endpoint if API is enabled, otherwise p2p port check
+func (c *LotusClient) HealthCheck() *corev1.ProbeHandler {
+	if c.node.Spec.API {
+		return clients.HTTPHealthCheck("/health/livez", c.node.Spec.APIPort)
+	}
+	return clients.TCPHealthCheck(c.node.Spec.P2PPort)
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (c *LotusClient) MetricsPath() string {
+	return "/debug/metrics"
+}
@@ -33,6 +33,10 @@ var _ = Describe("Lotus Filecoin Client", func() {
 		testImage := "kotalco/lotus:test"
 		os.Setenv(EnvLotusImage, testImage)
 		Expect(client.Image()).To(Equal(testImage))
+		// after setting node image
+		node.Spec.Image = "kotalco/lotus:canary"
+		Expect(client.Image()).To(Equal("kotalco/lotus:canary"))
+		node.Spec.Image = ""
 	})
 
 	It("Should get correct args", func() {
@@ -0,0 +1,26 @@
+package clients
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// TCPHealthCheck checks that the client is accepting connections on the given port
+func TCPHealthCheck(port uint) *corev1.ProbeHandler {
+	return &corev1.ProbeHandler{
+		TCPSocket: &corev1.TCPSocketAction{
+			Port: intstr.FromInt(int(port)),
+		},
+	}
+}
+
+// HTTPHealthCheck checks that the client responds successfully to HTTP GET request on the given path and port
+func HTTPHealthCheck(path string, port uint) *corev1.ProbeHandler {
+	return &corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path:   path,
+			Port:   intstr.FromInt(int(port)),
+			Scheme: corev1.URISchemeHTTP,
+		},
+	}
+}
@@ -9,4 +9,12 @@ type Interface interface {
 	Env() []corev1.EnvVar
 	HomeDir() string
 	Image() string
+	HealthCheck() *corev1.ProbeHandler
+	MetricsPath() string
+}
+
+// ReadinessChecker is implemented by clients checking readiness differently from liveness and startup
+type ReadinessChecker interface {
+	// ReadinessCheck returns readiness probe handler, client health check is used if it's nil
+	ReadinessCheck() *corev1.ProbeHandler
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	"github.com/kotalco/kotal/clients"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -23,3 +24,18 @@ func NewClient(obj runtime.Object) (IPFSClient, error) {
 	}
 	return nil, fmt.Errorf("no client support for %s", obj)
 }
+
+// AnnounceAddresses returns swarm tcp and quic addresses announced to peers if external IP is set
+func AnnounceAddresses(expose *sharedAPI.Expose, swarmPort uint) []string {
+	externalIP := expose.GetExternalIP()
+	if externalIP == "" {
+		return []string{}
+	}
+
+	port := expose.AnnouncedPort(swarmPort)
+
+	return []string{
+		fmt.Sprintf("/ip4/%s/tcp/%d", externalIP, port),
+		fmt.Sprintf("/ip4/%s/udp/%d/quic", externalIP, port),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 
 	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -22,10 +23,16 @@ const (
 	DefaultGoIPFSImage = "kotalco/go-ipfs:v0.11.0"
 	//  GoIPFSHomeDir is go ipfs image home dir
 	GoIPFSHomeDir = "/home/ipfs"
+	// GoIPFSSwarmPort is go ipfs swarm port
+	GoIPFSSwarmPort = 4001
 )
 
 // Image returns go-ipfs image
 func (c *GoIPFSClient) Image() string {
+	if img := c.peer.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvGoIPFSImage) == "" {
 		return DefaultGoIPFSImage
 	}
@@ -66,3 +73,13 @@ func (c *GoIPFSClient) Args() (args []string) {
 func (c *GoIPFSClient) HomeDir() string {
 	return GoIPFSHomeDir
 }
+
+// HealthCheck checks ipfs API port
+func (c *GoIPFSClient) HealthCheck() *corev1.ProbeHandler {
+	return clients.TCPHealthCheck(c.peer.Spec.APIPort)
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (c *GoIPFSClient) MetricsPath() string {
+	return "/debug/metrics/prometheus"
+}
@@ -28,6 +28,11 @@ var _ = Describe("Go IPFS Client", func() {
 		os.Setenv(EnvGoIPFSImage, testImage)
 		img = client.Image()
 		Expect(img).To(Equal(testImage))
+		// after setting node image
+		peer.Spec.Image = "kotalco/go-ipfs:canary"
+		img = client.Image()
+		Expect(img).To(Equal("kotalco/go-ipfs:canary"))
+		peer.Spec.Image = ""
 	})
 
 	It("Should get correct command", func() {
@@ -1,10 +1,12 @@
 package ipfs
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
 	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -22,10 +24,16 @@ const (
 	DefaultGoIPFSClusterImage = "kotalco/ipfs-cluster:v0.14.2"
 	//  GoIPFSClusterHomeDir is go ipfs cluster image home dir
 	GoIPFSClusterHomeDir = "/home/ipfs-cluster"
+	// GoIPFSClusterSwarmPort is go ipfs cluster swarm port
+	GoIPFSClusterSwarmPort = 9096
 )
 
 // Image returns go ipfs cluster image
 func (c *GoIPFSClusterClient) Image() string {
+	if img := c.peer.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvGoIPFSClusterImage) == "" {
 		return DefaultGoIPFSClusterImage
 	}
@@ -39,7 +47,7 @@ func (c *GoIPFSClusterClient) Command() []string {
 
 // Command returns environment variables for the client
 func (c *GoIPFSClusterClient) Env() []corev1.EnvVar {
-	return []corev1.EnvVar{
+	env := []corev1.EnvVar{
 		{
 			Name:  EnvIPFSClusterPath,
 			Value: shared.PathData(c.HomeDir()),
@@ -53,6 +61,25 @@ func (c *GoIPFSClusterClient) Env() []corev1.EnvVar {
 			Value: string(c.peer.Spec.Logging),
 		},
 	}
+
+	if addresses := AnnounceAddresses(c.peer.Spec.Expose, GoIPFSClusterSwarmPort); len(addresses) != 0 {
+		env = append(env, corev1.EnvVar{
+			Name:  EnvIPFSClusterAnnounceAddresses,
+			Value: strings.Join(addresses, ","),
+		})
+	}
+
+	if c.peer.Spec.Metrics.Enabled {
+		env = append(env, corev1.EnvVar{
+			Name:  EnvIPFSClusterMetricsEnableStats,
+			Value: "true",
+		}, corev1.EnvVar{
+			Name:  EnvIPFSClusterMetricsPromListenAddr,
+			Value: fmt.Sprintf("/ip4/%s/tcp/%d", c.peer.Spec.Metrics.Host, c.peer.Spec.Metrics.Port),
+		})
+	}
+
+	return env
 }
 
 // Arg returns go ipfs cluster arguments
@@ -70,3 +97,13 @@ func (c *GoIPFSClusterClient) Args() (args []string) {
 func (c *GoIPFSClusterClient) HomeDir() string {
 	return GoIPFSClusterHomeDir
 }
+
+// HealthCheck checks ipfs cluster swarm port
+func (c *GoIPFSClusterClient) HealthCheck() *corev1.ProbeHandler {
+	return clients.TCPHealthCheck(GoIPFSClusterSwarmPort)
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (c *GoIPFSClusterClient) MetricsPath() string {
+	return "/metrics"
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 
 	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	"github.com/kotalco/kotal/controllers/shared"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -54,6 +55,20 @@ var _ = Describe("Go IPFS Cluster Client", func() {
 		))
 	})
 
+	It("Should announce external addresses of exposed peer", func() {
+		exposed := peer.DeepCopy()
+		exposed.Spec.Expose = &sharedAPI.Expose{
+			ServiceType: corev1.ServiceTypeNodePort,
+			ExternalIP:  "203.0.113.10",
+			NodePort:    31000,
+		}
+		exposedClient, _ := NewClient(exposed)
+		Expect(exposedClient.Env()).To(ContainElement(corev1.EnvVar{
+			Name:  EnvIPFSClusterAnnounceAddresses,
+			Value: "/ip4/203.0.113.10/tcp/31000,/ip4/203.0.113.10/udp/31000/quic",
+		}))
+	})
+
 	It("Should get correct command", func() {
 		Expect(client.Command()).To(ConsistOf("ipfs-cluster-service"))
 	})
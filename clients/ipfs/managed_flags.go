@@ -0,0 +1,22 @@
+package ipfs
+
+import ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+
+func init() {
+	ipfsv1alpha1.PeerManagedFlags = peerManagedFlags
+	ipfsv1alpha1.ClusterPeerManagedFlags = clusterPeerManagedFlags
+}
+
+// peerManagedFlags returns go-ipfs flags set by Kotal that can't be used in extra args
+func peerManagedFlags(node *ipfsv1alpha1.Peer) []string {
+	return []string{
+		GoIPFSRoutingArg,
+	}
+}
+
+// clusterPeerManagedFlags returns ipfs cluster service flags set by Kotal that can't be used in extra args
+func clusterPeerManagedFlags(node *ipfsv1alpha1.ClusterPeer) []string {
+	return []string{
+		GoIPFSClusterBootstrapArg,
+	}
+}
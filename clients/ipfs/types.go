@@ -19,6 +19,8 @@ const (
 	EnvIPFSInitProfiles = "IPFS_INIT_PROFILES"
 	// EnvIPFSProfiles is the environment variables used for configuration profiles after peer intialization
 	EnvIPFSProfiles = "IPFS_PROFILES"
+	// EnvIPFSAnnounceAddresses is the environment variable used for swarm addresses announced to peers
+	EnvIPFSAnnounceAddresses = "IPFS_ANNOUNCE_ADDRESSES"
 
 	// EnvIPFSClusterPath is the environment variables used for ipfs-cluster-service path
 	EnvIPFSClusterPath = "IPFS_CLUSTER_PATH"
@@ -36,6 +38,12 @@ const (
 	EnvIPFSClusterId = "CLUSTER_ID"
 	// EnvIPFSClusterPrivateKey is the environment variables used for ipfs cluster private key
 	EnvIPFSClusterPrivateKey = "CLUSTER_PRIVATEKEY"
+	// EnvIPFSClusterMetricsEnableStats is the environment variables used to enable ipfs cluster metrics
+	EnvIPFSClusterMetricsEnableStats = "CLUSTER_METRICS_ENABLESTATS"
+	// EnvIPFSClusterMetricsPromListenAddr is the environment variables used for ipfs cluster prometheus metrics address
+	EnvIPFSClusterMetricsPromListenAddr = "CLUSTER_METRICS_PROMLISTENADDR"
+	// EnvIPFSClusterAnnounceAddresses is the environment variables used for ipfs cluster swarm addresses announced to peers
+	EnvIPFSClusterAnnounceAddresses = "CLUSTER_ANNOUNCEMULTIADDRESS"
 )
 
 const (
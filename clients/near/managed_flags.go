@@ -0,0 +1,22 @@
+package near
+
+import nearv1alpha1 "github.com/kotalco/kotal/apis/near/v1alpha1"
+
+func init() {
+	nearv1alpha1.NodeManagedFlags = nodeManagedFlags
+}
+
+// nodeManagedFlags returns NEAR core flags set by Kotal that can't be used in extra args
+func nodeManagedFlags(node *nearv1alpha1.Node) []string {
+	return []string{
+		NearArgHome,
+		NearArgDisableRPC,
+		NearArgRPCAddress,
+		NearArgPrometheusAddress,
+		NearArgBootnodes,
+		NearArgNetworkAddress,
+		NearArgMinimumPeers,
+		NearArgTelemetryURL,
+		NearArgArchive,
+	}
+}
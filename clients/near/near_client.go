@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	nearv1alpha1 "github.com/kotalco/kotal/apis/near/v1alpha1"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -29,6 +30,10 @@ const (
 
 // Image returns NEAR core client image
 func (c *NearClient) Image() string {
+	if img := c.node.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvNearImage) == "" {
 		return DefaultNearImage
 	}
@@ -57,7 +62,7 @@ func (c *NearClient) Args() (args []string) {
 	args = append(args, NearArgNetworkAddress, fmt.Sprintf("%s:%d", node.Spec.P2PHost, node.Spec.P2PPort))
 
 	if node.Spec.RPC {
-		args = append(args, NearArgRPCAddress, fmt.Sprintf("%s:%d", node.Spec.RPCHost, node.Spec.RPCPort))
+		args = append(args, NearArgRPCAddress, fmt.Sprintf("%s:%d", clients.RPCHost(node.Spec.RPCGateway, node.Spec.RPCHost), node.Spec.RPCPort))
 		args = append(args, NearArgPrometheusAddress, fmt.Sprintf("%s:%d", node.Spec.PrometheusHost, node.Spec.PrometheusPort))
 	} else {
 		args = append(args, NearArgDisableRPC)
@@ -84,3 +89,17 @@ func (c *NearClient) Args() (args []string) {
 func (c *NearClient) HomeDir() string {
 	return NearHomeDir
 }
+
+// HealthCheck returns NEAR status endpoint if JSON-RPC is enabled, otherwise p2p port check
+// JSON-RPC server listens on localhost only if node has RPC gateway, p2p port is checked instead
+func (c *NearClient) HealthCheck() *corev1.ProbeHandler {
+	if c.node.Spec.RPC && c.node.Spec.RPCGateway == nil {
+		return clients.HTTPHealthCheck("/status", c.node.Spec.RPCPort)
+	}
+	return clients.TCPHealthCheck(c.node.Spec.P2PPort)
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (c *NearClient) MetricsPath() string {
+	return "/metrics"
+}
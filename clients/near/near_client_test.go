@@ -4,6 +4,7 @@ import (
 	"os"
 
 	nearv1alpha1 "github.com/kotalco/kotal/apis/near/v1alpha1"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -49,6 +50,11 @@ var _ = Describe("NEAR core client", func() {
 		os.Setenv(EnvNearImage, testImage)
 		img = client.Image()
 		Expect(img).To(Equal(testImage))
+		// after setting node image
+		node.Spec.Image = "kotalco/near:canary"
+		img = client.Image()
+		Expect(img).To(Equal("kotalco/near:canary"))
+		node.Spec.Image = ""
 	})
 
 	It("Should get correct command", func() {
@@ -59,6 +65,10 @@ var _ = Describe("NEAR core client", func() {
 		Expect(client.HomeDir()).To(Equal(NearHomeDir))
 	})
 
+	It("Should get correct health check", func() {
+		Expect(client.HealthCheck()).To(Equal(clients.HTTPHealthCheck("/status", 7444)))
+	})
+
 	It("Should generate correct client arguments", func() {
 		Expect(client.Args()).To(ContainElements([]string{
 			"neard",
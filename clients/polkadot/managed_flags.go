@@ -0,0 +1,34 @@
+package polkadot
+
+import polkadotv1alpha1 "github.com/kotalco/kotal/apis/polkadot/v1alpha1"
+
+func init() {
+	polkadotv1alpha1.NodeManagedFlags = nodeManagedFlags
+}
+
+// nodeManagedFlags returns polkadot flags set by Kotal that can't be used in extra args
+func nodeManagedFlags(node *polkadotv1alpha1.Node) []string {
+	return []string{
+		PolkadotArgChain,
+		PolkadotArgName,
+		PolkadotArgPort,
+		PolkadotArgPublicAddr,
+		PolkadotArgBasePath,
+		PolkadotArgSync,
+		PolkadotArgPruning,
+		PolkadotArgLogging,
+		PolkadotArgRPCExternal,
+		PolkadotArgRPCPort,
+		PolkadotArgRPCCors,
+		PolkadotArgWSExternal,
+		PolkadotArgWSPort,
+		PolkadotArgNodeKeyFile,
+		PolkadotArgNodeKeyType,
+		PolkadotArgValidator,
+		PolkadotArgNoTelemetry,
+		PolkadotArgTelemetryURL,
+		PolkadotArgNoPrometheus,
+		PolkadotArgPrometheusExternal,
+		PolkadotArgPrometheusPort,
+	}
+}
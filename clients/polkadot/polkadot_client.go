@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	polkadotv1alpha1 "github.com/kotalco/kotal/apis/polkadot/v1alpha1"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -28,6 +29,10 @@ const (
 
 // Image returns go-ipfs image
 func (c *PolkadotClient) Image() string {
+	if img := c.node.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvPolkadotImage) == "" {
 		return DefaultPolkadotImage
 	}
@@ -53,6 +58,10 @@ func (c *PolkadotClient) Args() (args []string) {
 	args = append(args, PolkadotArgChain, node.Spec.Network)
 	args = append(args, PolkadotArgName, node.Name)
 	args = append(args, PolkadotArgPort, fmt.Sprintf("%d", node.Spec.P2PPort))
+
+	if externalIP := node.Spec.Expose.GetExternalIP(); externalIP != "" {
+		args = append(args, PolkadotArgPublicAddr, fmt.Sprintf("/ip4/%s/tcp/%d", externalIP, node.Spec.Expose.AnnouncedPort(node.Spec.P2PPort)))
+	}
 	args = append(args, PolkadotArgSync, string(node.Spec.SyncMode))
 	args = append(args, PolkadotArgLogging, string(node.Spec.Logging))
 
@@ -65,13 +74,20 @@ func (c *PolkadotClient) Args() (args []string) {
 		}
 	}
 
+	// JSON-RPC and websocket servers listen on localhost only if node has RPC gateway
+	external := node.Spec.RPCGateway == nil
+
 	if node.Spec.RPC {
-		args = append(args, PolkadotArgRPCExternal)
+		if external {
+			args = append(args, PolkadotArgRPCExternal)
+		}
 		args = append(args, PolkadotArgRPCPort, fmt.Sprintf("%d", node.Spec.RPCPort))
 	}
 
 	if node.Spec.WS {
-		args = append(args, PolkadotArgWSExternal)
+		if external {
+			args = append(args, PolkadotArgWSExternal)
+		}
 		args = append(args, PolkadotArgWSPort, fmt.Sprintf("%d", node.Spec.WSPort))
 	}
 
@@ -107,3 +123,17 @@ func (c *PolkadotClient) Args() (args []string) {
 func (c *PolkadotClient) HomeDir() string {
 	return PolkadotHomeDir
 }
+
+// HealthCheck returns polkadot health endpoint if JSON-RPC is enabled, otherwise p2p port check
+// JSON-RPC server listens on localhost only if node has RPC gateway, p2p port is checked instead
+func (c *PolkadotClient) HealthCheck() *corev1.ProbeHandler {
+	if c.node.Spec.RPC && c.node.Spec.RPCGateway == nil {
+		return clients.HTTPHealthCheck("/health", c.node.Spec.RPCPort)
+	}
+	return clients.TCPHealthCheck(c.node.Spec.P2PPort)
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (c *PolkadotClient) MetricsPath() string {
+	return "/metrics"
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/kotalco/kotal/controllers/shared"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -37,6 +38,10 @@ var _ = Describe("Polkadot client arguments", func() {
 				PrometheusPort:           5432,
 				Pruning:                  &t,
 				CORSDomains:              []string{"kotal.com"},
+				Expose: &sharedAPI.Expose{
+					ServiceType: corev1.ServiceTypeLoadBalancer,
+					ExternalIP:  "203.0.113.10",
+				},
 				// TODO: create test for node with telemetry disabled
 				// TODO: create test for node with prometheus disabled
 				// TODO: create test for node with pruning true
@@ -56,6 +61,8 @@ var _ = Describe("Polkadot client arguments", func() {
 			"kusama-node",
 			PolkadotArgPort,
 			"4444",
+			PolkadotArgPublicAddr,
+			"/ip4/203.0.113.10/tcp/4444",
 			PolkadotArgValidator,
 			PolkadotArgLogging,
 			string(sharedAPI.WarnLogs),
@@ -82,4 +89,32 @@ var _ = Describe("Polkadot client arguments", func() {
 
 	})
 
+	It("Should announce node port to peers", func() {
+		node := &polkadotv1alpha1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kusama-node",
+				Namespace: "default",
+			},
+			Spec: polkadotv1alpha1.NodeSpec{
+				Network: "kusama",
+				P2PPort: 4444,
+				Expose: &sharedAPI.Expose{
+					ServiceType: corev1.ServiceTypeNodePort,
+					ExternalIP:  "203.0.113.10",
+					NodePort:    31000,
+				},
+			},
+		}
+
+		node.Default()
+		args := NewClient(node).Args()
+
+		Expect(args).To(ContainElements([]string{
+			PolkadotArgPort,
+			"4444",
+			PolkadotArgPublicAddr,
+			"/ip4/203.0.113.10/tcp/31000",
+		}))
+	})
+
 })
@@ -7,6 +7,8 @@ const (
 	PolkadotArgName = "--name"
 	// PolkadotArgPort is argument used to set p2p tcp port
 	PolkadotArgPort = "--port"
+	// PolkadotArgPublicAddr is argument used to set public address advertised to peers
+	PolkadotArgPublicAddr = "--public-addr"
 	// PolkadotArgBasePath is argument to set base path
 	PolkadotArgBasePath = "--base-path"
 	// PolkadotArgSync is argument to set blockchain sync mode
@@ -0,0 +1,15 @@
+package clients
+
+import sharedAPI "github.com/kotalco/kotal/apis/shared"
+
+// LocalHost is the loopback address node APIs listen on if node has RPC gateway
+const LocalHost = "127.0.0.1"
+
+// RPCHost returns the host node APIs listen on
+// node APIs are only reachable through RPC gateway sidecar if node has one, otherwise host is used
+func RPCHost(gateway *sharedAPI.RPCGateway, host string) string {
+	if gateway != nil {
+		return LocalHost
+	}
+	return host
+}
@@ -0,0 +1,15 @@
+package stacks
+
+import stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+
+func init() {
+	stacksv1alpha1.NodeManagedFlags = nodeManagedFlags
+}
+
+// nodeManagedFlags returns stacks node flags set by Kotal that can't be used in extra args
+// stacks node is configured using config.toml, config overrides should be used instead
+func nodeManagedFlags(node *stacksv1alpha1.Node) []string {
+	return []string{
+		StacksArgConfig,
+	}
+}
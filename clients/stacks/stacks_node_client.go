@@ -5,6 +5,7 @@ import (
 	"os"
 
 	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -28,6 +29,10 @@ const (
 
 // Image returns Stacks node client image
 func (c *StacksNodeClient) Image() string {
+	if img := c.node.Spec.Image; img != "" {
+		return img
+	}
+
 	if os.Getenv(EnvStacksNodeImage) == "" {
 		return DefaultStacksNodeImage
 	}
@@ -60,3 +65,13 @@ func (c *StacksNodeClient) Args() (args []string) {
 func (c *StacksNodeClient) HomeDir() string {
 	return StacksNodeHomeDir
 }
+
+// HealthCheck returns stacks node info endpoint
+func (c *StacksNodeClient) HealthCheck() *corev1.ProbeHandler {
+	return clients.HTTPHealthCheck("/v2/info", c.node.Spec.RPCPort)
+}
+
+// MetricsPath returns prometheus metrics endpoint path
+func (c *StacksNodeClient) MetricsPath() string {
+	return "/metrics"
+}
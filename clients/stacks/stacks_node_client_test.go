@@ -36,6 +36,11 @@ var _ = Describe("Stacks node client", func() {
 		os.Setenv(EnvStacksNodeImage, testImage)
 		img = client.Image()
 		Expect(img).To(Equal(testImage))
+		// after setting node image
+		node.Spec.Image = "kotalco/stacks-node:canary"
+		img = client.Image()
+		Expect(img).To(Equal("kotalco/stacks-node:canary"))
+		node.Spec.Image = ""
 	})
 
 	It("Should get correct command", func() {
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+)
+
+// enode prints Ethereum node enode URL
+// node can be referenced by name or name.namespace
+func enode(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: kotalctl enode <node>")
+	}
+
+	name, ns := args[0], namespace
+	if parts := strings.Split(args[0], "."); len(parts) > 1 {
+		name, ns = parts[0], parts[1]
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	node := &ethereumv1alpha1.Node{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: ns}, node); err != nil {
+		return err
+	}
+
+	if node.Status.EnodeURL == "" {
+		return fmt.Errorf("node %s/%s enode URL is not available yet", ns, name)
+	}
+
+	fmt.Println(node.Status.EnodeURL)
+
+	return nil
+}
+
+// peers lists Ethereum nodes enode URLs that can be used as static nodes or bootnodes
+func peers(args []string) error {
+	if len(args) != 0 {
+		return errors.New("usage: kotalctl peers")
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	nodes := &ethereumv1alpha1.NodeList{}
+	if err := c.List(context.Background(), nodes, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCLIENT\tNETWORK\tENODE")
+
+	for _, node := range nodes.Items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", node.Name, node.Spec.Client, node.Status.Network, node.Status.EnodeURL)
+	}
+
+	return w.Flush()
+}
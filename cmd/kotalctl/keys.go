@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kotalco/kotal/helpers"
+)
+
+// generators is key generators by protocol
+// generator returns secret data and key information to print
+var generators = map[string]func(account bool) (map[string][]byte, string, error){
+	"ethereum":     generateEthereumKey,
+	"polkadot":     generatePolkadotKey,
+	"near":         generateNEARKey,
+	"ipfs":         generateSwarmKey,
+	"ipfs-cluster": generateClusterSecret,
+}
+
+// keys generates node keys or accounts and writes them into secrets
+func keys(args []string) error {
+	if len(args) == 0 || args[0] != "generate" {
+		return errors.New("usage: kotalctl keys generate <protocol> -name <secret>")
+	}
+
+	flags := flag.NewFlagSet("keys generate", flag.ExitOnError)
+	name := flags.String("name", "", "name of the secret to create")
+	account := flags.Bool("account", false, "generate Ethereum account instead of node key")
+
+	// protocol comes before flags
+	if len(args) < 2 {
+		return fmt.Errorf("protocol is required, one of %s", strings.Join(protocols(), ", "))
+	}
+	protocol := args[1]
+	flags.Parse(args[2:])
+
+	generate, ok := generators[protocol]
+	if !ok {
+		return fmt.Errorf("unsupported protocol %s, one of %s", protocol, strings.Join(protocols(), ", "))
+	}
+
+	if *account && protocol != "ethereum" {
+		return fmt.Errorf("accounts can only be generated for ethereum")
+	}
+
+	if *name == "" {
+		return errors.New("secret name is required")
+	}
+
+	data, info, err := generate(*account)
+	if err != nil {
+		return err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      *name,
+			Namespace: namespace,
+		},
+		Data: data,
+	}
+
+	if err := c.Create(context.Background(), secret); err != nil {
+		return err
+	}
+
+	fmt.Printf("secret %s/%s created\n", namespace, *name)
+	if info != "" {
+		fmt.Println(info)
+	}
+
+	return nil
+}
+
+// protocols returns sorted protocols supported by keys command
+func protocols() []string {
+	names := []string{}
+	for protocol := range generators {
+		names = append(names, protocol)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// randomHex returns n random bytes encoded in hex
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// generateEthereumKey generates secp256k1 node private key or account private key and password
+func generateEthereumKey(account bool) (map[string][]byte, string, error) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// hex private key without the leading 0x
+	key := hex.EncodeToString(crypto.FromECDSA(privateKey))
+
+	if account {
+		password, err := randomHex(16)
+		if err != nil {
+			return nil, "", err
+		}
+		address, err := helpers.DeriveAddress(key)
+		if err != nil {
+			return nil, "", err
+		}
+		data := map[string][]byte{
+			"key":      []byte(key),
+			"password": []byte(password),
+		}
+		return data, fmt.Sprintf("account address: %s", address), nil
+	}
+
+	publicKey, err := helpers.DerivePublicKey(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return map[string][]byte{"key": []byte(key)}, fmt.Sprintf("node public key: %s", publicKey), nil
+}
+
+// generatePolkadotKey generates ed25519 node private key
+func generatePolkadotKey(bool) (map[string][]byte, string, error) {
+	key, err := randomHex(ed25519.SeedSize)
+	if err != nil {
+		return nil, "", err
+	}
+	return map[string][]byte{"key": []byte(key)}, "", nil
+}
+
+// generateNEARKey generates ed25519 node key file
+func generateNEARKey(bool) (map[string][]byte, string, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nodeKey, err := json.Marshal(map[string]string{
+		"account_id": "node",
+		"public_key": "ed25519:" + base58(publicKey),
+		"secret_key": "ed25519:" + base58(privateKey),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return map[string][]byte{"key": nodeKey}, fmt.Sprintf("node public key: ed25519:%s", base58(publicKey)), nil
+}
+
+// generateSwarmKey generates IPFS private swarm key
+func generateSwarmKey(bool) (map[string][]byte, string, error) {
+	key, err := randomHex(32)
+	if err != nil {
+		return nil, "", err
+	}
+	swarmKey := fmt.Sprintf("/key/swarm/psk/1.0.0/\n/base16/\n%s", key)
+	return map[string][]byte{"swarm.key": []byte(swarmKey)}, "", nil
+}
+
+// generateClusterSecret generates IPFS cluster secret shared by all cluster peers
+func generateClusterSecret(bool) (map[string][]byte, string, error) {
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, "", err
+	}
+	return map[string][]byte{"secret": []byte(secret)}, "", nil
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58 encodes bytes using bitcoin base58 alphabet
+func base58(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+
+	encoded := []byte{}
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+
+	// leading zero bytes are encoded as leading 1s
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+
+	return string(encoded)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Key generation", func() {
+
+	It("Should encode base58", func() {
+		tests := []struct {
+			in   []byte
+			want string
+		}{
+			{[]byte{}, ""},
+			{[]byte{0}, "1"},
+			{[]byte{0, 0, 1}, "112"},
+			{[]byte("hello world"), "StV1DL6CwTryKyV"},
+		}
+
+		for _, test := range tests {
+			Expect(base58(test.in)).To(Equal(test.want), "base58(%v)", test.in)
+		}
+	})
+
+	It("Should generate ethereum node key", func() {
+		data, info, err := generateEthereumKey(false)
+		Expect(err).NotTo(HaveOccurred())
+
+		key, err := hex.DecodeString(string(data["key"]))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(HaveLen(32))
+		Expect(info).To(HavePrefix("node public key: "))
+	})
+
+	It("Should generate ethereum account", func() {
+		data, info, err := generateEthereumKey(true)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(data["password"]).NotTo(BeEmpty())
+		Expect(info).To(HavePrefix("account address: 0x"))
+	})
+
+	It("Should generate NEAR node key", func() {
+		data, _, err := generateNEARKey(false)
+		Expect(err).NotTo(HaveOccurred())
+
+		nodeKey := map[string]string{}
+		Expect(json.Unmarshal(data["key"], &nodeKey)).To(Succeed())
+		Expect(nodeKey["public_key"]).To(HavePrefix("ed25519:"))
+		Expect(nodeKey["secret_key"]).To(HavePrefix("ed25519:"))
+	})
+
+	It("Should generate swarm key", func() {
+		data, _, err := generateSwarmKey(false)
+		Expect(err).NotTo(HaveOccurred())
+
+		lines := strings.Split(string(data["swarm.key"]), "\n")
+		Expect(lines).To(HaveLen(3))
+		Expect(lines[0]).To(Equal("/key/swarm/psk/1.0.0/"))
+		Expect(lines[1]).To(Equal("/base16/"))
+		Expect(lines[2]).To(HaveLen(64))
+	})
+
+})
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	backupv1alpha1 "github.com/kotalco/kotal/apis/backup/v1alpha1"
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	chainlinkv1alpha1 "github.com/kotalco/kotal/apis/chainlink/v1alpha1"
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+	filecoinv1alpha1 "github.com/kotalco/kotal/apis/filecoin/v1alpha1"
+	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	nearv1alpha1 "github.com/kotalco/kotal/apis/near/v1alpha1"
+	polkadotv1alpha1 "github.com/kotalco/kotal/apis/polkadot/v1alpha1"
+	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+)
+
+const usage = `kotalctl manages Kotal blockchain nodes
+
+Usage:
+  kotalctl [flags] <command> [arguments]
+
+Commands:
+  status                      list all Kotal resources with their sync state
+  keys generate <protocol>    generate node key or account and write it into a secret
+  enode <node>                print Ethereum node enode URL
+  peers                       list Ethereum nodes enode URLs
+  render -f <file>            print resources created for node spec without a cluster
+
+Flags:
+`
+
+var (
+	scheme    = runtime.NewScheme()
+	namespace string
+)
+
+func init() {
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	_ = ethereumv1alpha1.AddToScheme(scheme)
+	_ = ethereum2v1alpha1.AddToScheme(scheme)
+	_ = ipfsv1alpha1.AddToScheme(scheme)
+	_ = filecoinv1alpha1.AddToScheme(scheme)
+	_ = polkadotv1alpha1.AddToScheme(scheme)
+	_ = chainlinkv1alpha1.AddToScheme(scheme)
+	_ = nearv1alpha1.AddToScheme(scheme)
+	_ = bitcoinv1alpha1.AddToScheme(scheme)
+	_ = stacksv1alpha1.AddToScheme(scheme)
+	_ = backupv1alpha1.AddToScheme(scheme)
+}
+
+// newClient returns kubernetes client using kubeconfig flag, KUBECONFIG or in-cluster config
+func newClient() (client.Client, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme})
+}
+
+func main() {
+	flag.StringVar(&namespace, "namespace", "default", "namespace of Kotal resources")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	commands := map[string]func(args []string) error{
+		"status": status,
+		"keys":   keys,
+		"enode":  enode,
+		"peers":  peers,
+		"render": renderNodes,
+	}
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %s\n\n", args[0])
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := command(args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+
+	"github.com/kotalco/kotal/controllers/render"
+)
+
+// renderNodes prints resources created for node spec without contacting the cluster
+// other objects in the file (like secrets) are made available to the controllers
+func renderNodes(args []string) error {
+	flags := flag.NewFlagSet("render", flag.ExitOnError)
+	file := flags.String("f", "", "file holding node spec, - for standard input")
+	flags.Parse(args)
+
+	if *file == "" {
+		return errors.New("usage: kotalctl render -f <file>")
+	}
+
+	var in io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	objects, err := render.Decode(in)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objects {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+	}
+
+	rendered, err := render.RenderObjects(objects)
+	if err != nil {
+		return err
+	}
+
+	data, err := render.Marshal(rendered)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.Write(data)
+	return err
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// resources is Kotal node resources listed by status command
+var resources = []schema.GroupVersionKind{
+	{Group: "ethereum.kotal.io", Version: "v1alpha1", Kind: "Node"},
+	{Group: "ethereum2.kotal.io", Version: "v1alpha1", Kind: "BeaconNode"},
+	{Group: "ethereum2.kotal.io", Version: "v1alpha1", Kind: "Validator"},
+	{Group: "ipfs.kotal.io", Version: "v1alpha1", Kind: "Peer"},
+	{Group: "ipfs.kotal.io", Version: "v1alpha1", Kind: "ClusterPeer"},
+	{Group: "filecoin.kotal.io", Version: "v1alpha1", Kind: "Node"},
+	{Group: "polkadot.kotal.io", Version: "v1alpha1", Kind: "Node"},
+	{Group: "chainlink.kotal.io", Version: "v1alpha1", Kind: "Node"},
+	{Group: "near.kotal.io", Version: "v1alpha1", Kind: "Node"},
+	{Group: "bitcoin.kotal.io", Version: "v1alpha1", Kind: "Node"},
+	{Group: "stacks.kotal.io", Version: "v1alpha1", Kind: "Node"},
+}
+
+// status lists all Kotal resources with their sync state
+func status(args []string) error {
+	flags := flag.NewFlagSet("status", flag.ExitOnError)
+	allNamespaces := flags.Bool("all-namespaces", false, "list resources across all namespaces")
+	flags.Parse(args)
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	opts := []client.ListOption{}
+	if !*allNamespaces {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tRESOURCE\tNAME\tCLIENT\tPHASE\tREADY")
+
+	for _, gvk := range resources {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+		if err := c.List(context.Background(), list, opts...); err != nil {
+			// CRD of this protocol is not installed
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return err
+		}
+
+		for _, item := range list.Items {
+			client, _, _ := unstructured.NestedString(item.Object, "spec", "client")
+			if client == "" {
+				client, _, _ = unstructured.NestedString(item.Object, "status", "client")
+			}
+			phase, _, _ := unstructured.NestedString(item.Object, "status", "phase")
+			if phase == "" {
+				phase = "Unknown"
+			}
+			fmt.Fprintf(w, "%s\t%s.%s\t%s\t%s\t%s\t%s\n", item.GetNamespace(), gvk.Kind, gvk.Group, item.GetName(), client, phase, readyCondition(&item))
+		}
+	}
+
+	return w.Flush()
+}
+
+// readyCondition returns Ready condition status of the resource
+func readyCondition(item *unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(item.Object, "status", "conditions")
+	for _, condition := range conditions {
+		condition, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == "Ready" {
+			if status, ok := condition["status"].(string); ok {
+				return status
+			}
+		}
+	}
+	return "Unknown"
+}
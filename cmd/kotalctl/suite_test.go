@@ -0,0 +1,13 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestKotalctl(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Kotalctl Suite")
+}
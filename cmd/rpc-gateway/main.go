@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kotalco/kotal/rpcgateway"
+)
+
+const (
+	// apiKeysFile is secret key holding newline separated API keys
+	apiKeysFile = "api-keys"
+	// jwtSecretFile is secret key holding JWT HMAC secret
+	jwtSecretFile = "jwt-secret"
+	// reloadInterval is how often credentials are read again from the mounted secret
+	reloadInterval = 30 * time.Second
+)
+
+// splitMethods splits comma separated JSON-RPC methods
+func splitMethods(methods string) (result []string) {
+	for _, method := range strings.Split(methods, ",") {
+		if method = strings.TrimSpace(method); method != "" {
+			result = append(result, method)
+		}
+	}
+	return
+}
+
+func main() {
+	var (
+		listen     = flag.String("listen", ":8080", "gateway listening address")
+		upstream   = flag.String("upstream", "http://127.0.0.1:8545", "node RPC server URL")
+		wsUpstream = flag.String("ws-upstream", "", "node websocket server URL, websocket connections are rejected if empty")
+		auth       = flag.String("auth", "APIKey", "client authentication method: APIKey or JWT")
+		secretDir  = flag.String("secret-dir", "/etc/rpc-gateway", "directory of mounted secret holding api-keys or jwt-secret")
+		rateLimit  = flag.Uint("rate-limit", 0, "JSON-RPC calls per second allowed per client, 0 disables rate limiting")
+		burst      = flag.Uint("burst", 10, "maximum JSON-RPC calls burst allowed per client")
+		allow      = flag.String("allow", "", "comma separated JSON-RPC methods allowed, all methods are allowed if empty")
+		deny       = flag.String("deny", "", "comma separated JSON-RPC methods denied")
+	)
+	flag.Parse()
+
+	upstreamURL, err := url.Parse(*upstream)
+	if err != nil {
+		log.Fatalf("invalid upstream URL %s: %s", *upstream, err)
+	}
+
+	var wsUpstreamURL *url.URL
+	if *wsUpstream != "" {
+		if wsUpstreamURL, err = url.Parse(*wsUpstream); err != nil {
+			log.Fatalf("invalid websocket upstream URL %s: %s", *wsUpstream, err)
+		}
+	}
+
+	var authenticator *rpcgateway.FileAuthenticator
+
+	switch *auth {
+	case "APIKey":
+		authenticator, err = rpcgateway.NewFileAuthenticator(filepath.Join(*secretDir, apiKeysFile), func(keys string) rpcgateway.Authenticator {
+			return rpcgateway.NewAPIKeyAuthenticator(keys)
+		})
+	case "JWT":
+		authenticator, err = rpcgateway.NewFileAuthenticator(filepath.Join(*secretDir, jwtSecretFile), func(secret string) rpcgateway.Authenticator {
+			return rpcgateway.NewJWTAuthenticator(secret)
+		})
+	default:
+		log.Fatalf("unsupported authentication method %s", *auth)
+	}
+
+	if err != nil {
+		log.Fatalf("failed to load credentials: %s", err)
+	}
+
+	go func() {
+		for range time.Tick(reloadInterval) {
+			if err := authenticator.Reload(); err != nil {
+				log.Printf("failed to reload credentials: %s", err)
+			}
+		}
+	}()
+
+	var limiter *rpcgateway.RateLimiter
+	if *rateLimit != 0 {
+		limiter = rpcgateway.NewRateLimiter(*rateLimit, *burst)
+	}
+
+	filter := rpcgateway.MethodFilter{
+		Allowed: splitMethods(*allow),
+		Denied:  splitMethods(*deny),
+	}
+
+	gateway := rpcgateway.NewGateway(upstreamURL, wsUpstreamURL, authenticator, limiter, filter)
+
+	log.Printf("proxying JSON-RPC requests from %s to %s", *listen, upstreamURL)
+	log.Fatal(http.ListenAndServe(*listen, gateway))
+}
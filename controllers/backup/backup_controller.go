@@ -0,0 +1,327 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	backupv1alpha1 "github.com/kotalco/kotal/apis/backup/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+	"github.com/kotalco/kotal/helpers/cron"
+)
+
+// BackupReconciler reconciles a Backup object
+type BackupReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+const (
+	// BackupLabel is volume snapshot label holding the backup name
+	BackupLabel = "backup.kotal.io/name"
+	// pollInterval is how long to wait before checking node pods and volume snapshots again
+	pollInterval = 5 * time.Second
+	// retryInterval is how long to wait before taking a failed backup again
+	retryInterval = 5 * time.Minute
+)
+
+// +kubebuilder:rbac:groups=backup.kotal.io,resources=backups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.kotal.io,resources=backups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=bitcoin.kotal.io;chainlink.kotal.io;ethereum.kotal.io;ethereum2.kotal.io;filecoin.kotal.io;ipfs.kotal.io;near.kotal.io;polkadot.kotal.io;stacks.kotal.io,resources=nodes;beaconnodes;validators;peers;clusterpeers,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile Kotal node backup
+func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	var backup backupv1alpha1.Backup
+
+	if err = r.Client.Get(ctx, req.NamespacedName, &backup); err != nil {
+		err = client.IgnoreNotFound(err)
+		return
+	}
+
+	defer func() { shared.RecordError(r.Recorder, &backup, err) }()
+
+	// backup is in progress
+	if backup.Status.Current != "" {
+		return r.reconcileSnapshot(ctx, &backup)
+	}
+
+	next, err := nextBackupTime(&backup)
+	if err != nil {
+		return
+	}
+
+	// single backup has been taken already
+	if next.IsZero() {
+		return
+	}
+
+	if wait := time.Until(next); wait > 0 {
+		result.RequeueAfter = wait
+		err = r.updateStatus(ctx, &backup, func(status *backupv1alpha1.BackupStatus) {
+			if status.Phase == "" {
+				status.Phase = backupv1alpha1.BackupPending
+			}
+			status.NextBackupTime = &metav1.Time{Time: next}
+		})
+		return
+	}
+
+	return r.startBackup(ctx, &backup)
+}
+
+// nextBackupTime returns next backup time, or zero time if no more backups should be taken
+// failed backup is taken again at its retry time
+func nextBackupTime(backup *backupv1alpha1.Backup) (time.Time, error) {
+	last := backup.Status.LastBackupTime
+
+	var next time.Time
+
+	if backup.Spec.Schedule == "" {
+		if last != nil {
+			return time.Time{}, nil
+		}
+		next = backup.CreationTimestamp.Time
+	} else {
+		schedule, err := cron.Parse(backup.Spec.Schedule)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		from := backup.CreationTimestamp.Time
+		if last != nil {
+			from = last.Time
+		}
+
+		next = schedule.Next(from)
+	}
+
+	if retry := backup.Status.NextBackupTime; backup.Status.Phase == backupv1alpha1.BackupFailed && retry != nil && next.Before(retry.Time) {
+		next = retry.Time
+	}
+
+	return next, nil
+}
+
+// updateStatus updates backup status using the given mutation
+func (r *BackupReconciler) updateStatus(ctx context.Context, backup *backupv1alpha1.Backup, mutate func(*backupv1alpha1.BackupStatus)) error {
+	mutate(&backup.Status)
+
+	if err := r.Status().Update(ctx, backup); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update backup status")
+		return err
+	}
+
+	return nil
+}
+
+// startBackup suspends the node and records the volume snapshot to be taken
+func (r *BackupReconciler) startBackup(ctx context.Context, backup *backupv1alpha1.Backup) (result ctrl.Result, err error) {
+	node, err := getNode(ctx, r.Client, backup.Namespace, backup.Spec.Node)
+	if apierrors.IsNotFound(err) {
+		result.RequeueAfter = pollInterval
+		err = r.updateStatus(ctx, backup, func(status *backupv1alpha1.BackupStatus) {
+			status.Phase = backupv1alpha1.BackupPending
+			status.Message = fmt.Sprintf("node %s is not found", backup.Spec.Node.Name)
+		})
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	suspended, err := suspendNode(ctx, r.Client, node, backup.Name, nil)
+	if err != nil {
+		return
+	}
+	if !suspended {
+		result.RequeueAfter = pollInterval
+		err = r.updateStatus(ctx, backup, func(status *backupv1alpha1.BackupStatus) {
+			status.Message = fmt.Sprintf("node is suspended by %s", node.GetAnnotations()[shared.SuspendAnnotation])
+		})
+		return
+	}
+
+	now := time.Now().UTC()
+
+	log.FromContext(ctx).Info("starting node backup", "node", node.GetName())
+
+	result.RequeueAfter = pollInterval
+	err = r.updateStatus(ctx, backup, func(status *backupv1alpha1.BackupStatus) {
+		status.Phase = backupv1alpha1.BackupInProgress
+		status.Message = "waiting for node pods to terminate"
+		status.Current = fmt.Sprintf("%s-%s", backup.Name, now.Format("20060102-150405"))
+		status.NextBackupTime = nil
+	})
+
+	return
+}
+
+// failBackup records failure of the current backup, and schedules taking it again after retry interval
+func (r *BackupReconciler) failBackup(ctx context.Context, backup *backupv1alpha1.Backup, msg string) (result ctrl.Result, err error) {
+	result.RequeueAfter = retryInterval
+	err = r.updateStatus(ctx, backup, func(status *backupv1alpha1.BackupStatus) {
+		status.Phase = backupv1alpha1.BackupFailed
+		status.Message = msg
+		status.Current = ""
+		status.NextBackupTime = &metav1.Time{Time: time.Now().Add(retryInterval)}
+	})
+	return
+}
+
+// reconcileSnapshot takes volume snapshot of the suspended node, resumes the node once it's cut
+// and completes the backup once it's ready to use
+func (r *BackupReconciler) reconcileSnapshot(ctx context.Context, backup *backupv1alpha1.Backup) (result ctrl.Result, err error) {
+	node, err := getNode(ctx, r.Client, backup.Namespace, backup.Spec.Node)
+	if apierrors.IsNotFound(err) {
+		return r.failBackup(ctx, backup, fmt.Sprintf("node %s is not found", backup.Spec.Node.Name))
+	}
+	if err != nil {
+		return
+	}
+
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(shared.VolumeSnapshotGVK)
+
+	err = r.Client.Get(ctx, types.NamespacedName{Name: backup.Status.Current, Namespace: backup.Namespace}, snapshot)
+	if apierrors.IsNotFound(err) {
+		// node is scaled down for a consistent snapshot
+		var scaledDown bool
+		scaledDown, err = isScaledDown(ctx, r.Client, node)
+		if err != nil || !scaledDown {
+			result.RequeueAfter = pollInterval
+			return
+		}
+
+		r.specSnapshot(backup, node, snapshot)
+		log.FromContext(ctx).Info("taking volume snapshot", "name", snapshot.GetName())
+		result.RequeueAfter = pollInterval
+		err = r.Client.Create(ctx, snapshot)
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if msg, found, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message"); found {
+		if err = resumeNode(ctx, r.Client, node, backup.Name); err != nil {
+			return
+		}
+		// failed snapshot isn't usable, it's taken again with a new name on retry
+		if err = r.Client.Delete(ctx, snapshot); client.IgnoreNotFound(err) != nil {
+			return
+		}
+		return r.failBackup(ctx, backup, msg)
+	}
+
+	// node can be resumed once the snapshot is cut, even if it's not ready to use yet
+	if _, found, _ := unstructured.NestedString(snapshot.Object, "status", "creationTime"); !found {
+		result.RequeueAfter = pollInterval
+		return
+	}
+
+	if err = resumeNode(ctx, r.Client, node, backup.Name); err != nil {
+		return
+	}
+
+	if ready, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse"); !ready {
+		result.RequeueAfter = pollInterval
+		if msg := "waiting for volume snapshot to be ready"; backup.Status.Message != msg {
+			err = r.updateStatus(ctx, backup, func(status *backupv1alpha1.BackupStatus) {
+				status.Message = msg
+			})
+		}
+		return
+	}
+
+	snapshots := append(backup.Status.Snapshots, backup.Status.Current)
+	if snapshots, err = r.pruneSnapshots(ctx, backup, snapshots); err != nil {
+		return
+	}
+
+	err = r.updateStatus(ctx, backup, func(status *backupv1alpha1.BackupStatus) {
+		status.Phase = backupv1alpha1.BackupCompleted
+		status.Message = ""
+		status.Current = ""
+		status.Snapshots = snapshots
+		status.LastBackupTime = &metav1.Time{Time: time.Now().UTC()}
+	})
+
+	// requeue to schedule the next backup
+	if err == nil && backup.Spec.Schedule != "" {
+		result.Requeue = true
+	}
+
+	return
+}
+
+// pruneSnapshots deletes the oldest volume snapshots exceeding backup retention
+func (r *BackupReconciler) pruneSnapshots(ctx context.Context, backup *backupv1alpha1.Backup, snapshots []string) ([]string, error) {
+	retention := backup.Spec.Retention
+	if retention == 0 || len(snapshots) <= retention {
+		return snapshots, nil
+	}
+
+	expired := snapshots[:len(snapshots)-retention]
+
+	for _, name := range expired {
+		snapshot := &unstructured.Unstructured{}
+		snapshot.SetGroupVersionKind(shared.VolumeSnapshotGVK)
+		snapshot.SetName(name)
+		snapshot.SetNamespace(backup.Namespace)
+
+		log.FromContext(ctx).Info("deleting expired volume snapshot", "name", name)
+
+		if err := r.Client.Delete(ctx, snapshot); client.IgnoreNotFound(err) != nil {
+			return snapshots, err
+		}
+	}
+
+	return snapshots[len(expired):], nil
+}
+
+// specSnapshot updates volume snapshot of the node persistent volume claim
+// replicas are interchangeable, the first replica persistent volume claim is snapshotted for replicated nodes
+// snapshot is not owned by the backup, so deleting the backup doesn't delete its snapshots
+func (r *BackupReconciler) specSnapshot(backup *backupv1alpha1.Backup, node *unstructured.Unstructured, snapshot *unstructured.Unstructured) {
+	labels := map[string]string{}
+	for k, v := range node.GetLabels() {
+		labels[k] = v
+	}
+	labels[BackupLabel] = backup.Name
+
+	snapshot.SetName(backup.Status.Current)
+	snapshot.SetNamespace(backup.Namespace)
+	snapshot.SetLabels(labels)
+
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": nodePVCNames(node)[0],
+		},
+	}
+
+	if backup.Spec.VolumeSnapshotClassName != nil {
+		spec["volumeSnapshotClassName"] = *backup.Spec.VolumeSnapshotClassName
+	}
+
+	snapshot.Object["spec"] = spec
+}
+
+func (r *BackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&backupv1alpha1.Backup{}).
+		Complete(r)
+}
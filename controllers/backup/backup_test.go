@@ -0,0 +1,241 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	backupv1alpha1 "github.com/kotalco/kotal/apis/backup/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+)
+
+var _ = Describe("Backup", func() {
+
+	created := time.Date(2022, time.April, 15, 10, 30, 0, 0, time.UTC)
+	last := metav1.NewTime(time.Date(2022, time.April, 16, 0, 0, 0, 0, time.UTC))
+	retry := metav1.NewTime(time.Date(2022, time.April, 16, 0, 5, 0, 0, time.UTC))
+	failed := backupv1alpha1.BackupStatus{Phase: backupv1alpha1.BackupFailed, NextBackupTime: &retry}
+
+	cases := []struct {
+		title    string
+		schedule string
+		status   backupv1alpha1.BackupStatus
+		next     time.Time
+	}{
+		{"single backup not taken yet", "", backupv1alpha1.BackupStatus{}, created},
+		{"single backup already taken", "", backupv1alpha1.BackupStatus{LastBackupTime: &last}, time.Time{}},
+		{"failed single backup", "", failed, retry.Time},
+		{"first scheduled backup", "@daily", backupv1alpha1.BackupStatus{}, time.Date(2022, time.April, 16, 0, 0, 0, 0, time.UTC)},
+		{"next scheduled backup", "@daily", backupv1alpha1.BackupStatus{LastBackupTime: &last}, time.Date(2022, time.April, 17, 0, 0, 0, 0, time.UTC)},
+		{"failed scheduled backup", "@daily", failed, retry.Time},
+	}
+
+	for _, c := range cases {
+		func() {
+			cc := c
+			It(fmt.Sprintf("Should get next backup time of %s", cc.title), func() {
+				backup := &backupv1alpha1.Backup{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+					Spec:       backupv1alpha1.BackupSpec{Schedule: cc.schedule},
+					Status:     cc.status,
+				}
+
+				next, err := nextBackupTime(backup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(next).To(BeTemporally("==", cc.next))
+			})
+		}()
+	}
+
+	It("Should spec volume snapshot of node data volume", func() {
+		class := "csi-snapclass"
+
+		backup := &backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "default"},
+			Spec:       backupv1alpha1.BackupSpec{VolumeSnapshotClassName: &class},
+			Status:     backupv1alpha1.BackupStatus{Current: "daily-20220416-000000"},
+		}
+
+		node := &unstructured.Unstructured{}
+		node.SetName("my-node")
+		node.SetLabels(map[string]string{"app.kubernetes.io/instance": "my-node"})
+
+		snapshot := &unstructured.Unstructured{}
+		(&BackupReconciler{}).specSnapshot(backup, node, snapshot)
+
+		Expect(snapshot.GetName()).To(Equal("daily-20220416-000000"))
+		Expect(snapshot.GetLabels()).To(Equal(map[string]string{
+			"app.kubernetes.io/instance": "my-node",
+			BackupLabel:                  "daily",
+		}))
+
+		source, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName")
+		Expect(source).To(Equal("my-node"))
+
+		snapshotClass, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName")
+		Expect(snapshotClass).To(Equal(class))
+	})
+
+	It("Should snapshot first replica data volume of replicated node", func() {
+		backup := &backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "default"},
+			Status:     backupv1alpha1.BackupStatus{Current: "daily-20220416-000000"},
+		}
+
+		node := &unstructured.Unstructured{}
+		node.SetName("my-node")
+		unstructured.SetNestedField(node.Object, int64(3), "spec", "replicas")
+
+		snapshot := &unstructured.Unstructured{}
+		(&BackupReconciler{}).specSnapshot(backup, node, snapshot)
+
+		source, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName")
+		Expect(source).To(Equal("data-my-node-0"))
+	})
+
+	// reconciler returns backup reconciler of suspended node and its volume snapshot with the given status
+	reconciler := func(status map[string]interface{}) (*BackupReconciler, *backupv1alpha1.Backup) {
+		backup := &backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "default"},
+			Spec: backupv1alpha1.BackupSpec{
+				Node: backupv1alpha1.NodeReference{APIVersion: "ethereum.kotal.io/v1alpha1", Kind: "Node", Name: "my-node"},
+			},
+			Status: backupv1alpha1.BackupStatus{
+				Phase:   backupv1alpha1.BackupInProgress,
+				Current: "daily-20220416-000000",
+			},
+		}
+
+		node := &unstructured.Unstructured{}
+		node.SetGroupVersionKind(backup.Spec.Node.GroupVersionKind())
+		node.SetName("my-node")
+		node.SetNamespace("default")
+		node.SetAnnotations(map[string]string{shared.SuspendAnnotation: backup.Name})
+
+		snapshot := &unstructured.Unstructured{}
+		snapshot.SetGroupVersionKind(shared.VolumeSnapshotGVK)
+		(&BackupReconciler{}).specSnapshot(backup, node, snapshot)
+		snapshot.Object["status"] = status
+
+		scheme := runtime.NewScheme()
+		backupv1alpha1.AddToScheme(scheme)
+		scheme.AddKnownTypeWithName(backup.Spec.Node.GroupVersionKind(), &unstructured.Unstructured{})
+		scheme.AddKnownTypeWithName(shared.VolumeSnapshotGVK, &unstructured.Unstructured{})
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(backup, node, snapshot).Build()
+
+		fetched := &backupv1alpha1.Backup{}
+		Expect(c.Get(context.Background(), types.NamespacedName{Name: "daily", Namespace: "default"}, fetched)).To(Succeed())
+
+		return &BackupReconciler{Client: c, Scheme: scheme}, fetched
+	}
+
+	It("Should complete backup once volume snapshot is ready to use", func() {
+		r, backup := reconciler(map[string]interface{}{"creationTime": "2022-04-16T00:00:10Z", "readyToUse": true})
+
+		_, err := r.reconcileSnapshot(context.Background(), backup)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backup.Status.Phase).To(Equal(backupv1alpha1.BackupCompleted))
+		Expect(backup.Status.Current).To(BeEmpty())
+		Expect(backup.Status.Snapshots).To(ConsistOf("daily-20220416-000000"))
+		Expect(backup.Status.LastBackupTime).NotTo(BeNil())
+	})
+
+	It("Should not record backup time before volume snapshot is ready to use", func() {
+		r, backup := reconciler(map[string]interface{}{"creationTime": "2022-04-16T00:00:10Z", "readyToUse": false})
+
+		result, err := r.reconcileSnapshot(context.Background(), backup)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(pollInterval))
+		Expect(backup.Status.Phase).To(Equal(backupv1alpha1.BackupInProgress))
+		Expect(backup.Status.Current).To(Equal("daily-20220416-000000"))
+		Expect(backup.Status.LastBackupTime).To(BeNil())
+
+		// node is resumed once the snapshot is cut
+		node := &unstructured.Unstructured{}
+		node.SetGroupVersionKind(backup.Spec.Node.GroupVersionKind())
+		Expect(r.Get(context.Background(), types.NamespacedName{Name: "my-node", Namespace: "default"}, node)).To(Succeed())
+		Expect(node.GetAnnotations()).NotTo(HaveKey(shared.SuspendAnnotation))
+	})
+
+	It("Should take failed single backup again", func() {
+		r, backup := reconciler(map[string]interface{}{"error": map[string]interface{}{"message": "snapshot class not found"}})
+
+		result, err := r.reconcileSnapshot(context.Background(), backup)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(retryInterval))
+		Expect(backup.Status.Phase).To(Equal(backupv1alpha1.BackupFailed))
+		Expect(backup.Status.Current).To(BeEmpty())
+		Expect(backup.Status.LastBackupTime).To(BeNil())
+
+		// failed snapshot is deleted
+		snapshot := &unstructured.Unstructured{}
+		snapshot.SetGroupVersionKind(shared.VolumeSnapshotGVK)
+		err = r.Get(context.Background(), types.NamespacedName{Name: "daily-20220416-000000", Namespace: "default"}, snapshot)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		next, err := nextBackupTime(backup)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(next).To(BeTemporally("==", backup.Status.NextBackupTime.Time))
+		Expect(next).To(BeTemporally("~", time.Now().Add(retryInterval), time.Minute))
+	})
+
+	It("Should prune snapshots exceeding retention", func() {
+		r := &BackupReconciler{Client: fake.NewClientBuilder().Build()}
+
+		backup := &backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "default"},
+			Spec:       backupv1alpha1.BackupSpec{Retention: 2},
+		}
+
+		kept, err := r.pruneSnapshots(context.Background(), backup, []string{"daily-1", "daily-2", "daily-3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kept).To(Equal([]string{"daily-2", "daily-3"}))
+	})
+
+})
+
+var _ = Describe("Restore", func() {
+
+	It("Should restore persistent volume claims of all replicas", func() {
+		apiGroup := shared.VolumeSnapshotGVK.Group
+
+		restored := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data-my-node-0", Namespace: "default"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				DataSource: &corev1.TypedLocalObjectReference{APIGroup: &apiGroup, Kind: shared.VolumeSnapshotGVK.Kind, Name: "daily-1"},
+			},
+		}
+		stale := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data-my-node-1", Namespace: "default"},
+		}
+
+		r := &RestoreReconciler{Client: fake.NewClientBuilder().WithObjects(restored, stale).Build()}
+
+		node := &unstructured.Unstructured{}
+		node.SetAPIVersion("ethereum.kotal.io/v1alpha1")
+		node.SetKind("Node")
+		node.SetName("my-node")
+		node.SetNamespace("default")
+		unstructured.SetNestedField(node.Object, int64(2), "spec", "replicas")
+
+		done, err := r.reconcilePVC(context.Background(), node, "daily-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(done).To(BeFalse())
+
+		// replica claim not created from the snapshot is deleted to be created again from it
+		err = r.Get(context.Background(), types.NamespacedName{Name: "data-my-node-1", Namespace: "default"}, &corev1.PersistentVolumeClaim{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+})
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/kotalco/kotal/apis/backup/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+)
+
+// getNode gets referenced Kotal node
+func getNode(ctx context.Context, c client.Client, namespace string, ref backupv1alpha1.NodeReference) (*unstructured.Unstructured, error) {
+	node := &unstructured.Unstructured{}
+	node.SetGroupVersionKind(ref.GroupVersionKind())
+
+	err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, node)
+
+	return node, err
+}
+
+// nodePVCNames returns node persistent volume claim names
+// replicated nodes have a persistent volume claim per replica
+func nodePVCNames(node *unstructured.Unstructured) []string {
+	replicas, _, _ := unstructured.NestedInt64(node.Object, "spec", "replicas")
+	return shared.PVCNames(node, uint(replicas))
+}
+
+// suspendNode annotates the node to be suspended by the given backup or restore
+// it returns false if the node is already suspended by another operation
+func suspendNode(ctx context.Context, c client.Client, node *unstructured.Unstructured, by string, annotations map[string]string) (bool, error) {
+	current := node.GetAnnotations()
+	if suspendedBy, ok := current[shared.SuspendAnnotation]; ok && suspendedBy != by {
+		return false, nil
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+
+	if current == nil {
+		current = map[string]string{}
+	}
+	current[shared.SuspendAnnotation] = by
+	for k, v := range annotations {
+		current[k] = v
+	}
+	node.SetAnnotations(current)
+
+	return true, c.Patch(ctx, node, patch)
+}
+
+// resumeNode removes node suspend annotation and the given annotations if the node is suspended by the given operation
+func resumeNode(ctx context.Context, c client.Client, node *unstructured.Unstructured, by string, annotations ...string) error {
+	current := node.GetAnnotations()
+	if current[shared.SuspendAnnotation] != by {
+		return nil
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+
+	delete(current, shared.SuspendAnnotation)
+	for _, k := range annotations {
+		delete(current, k)
+	}
+	node.SetAnnotations(current)
+
+	return c.Patch(ctx, node, patch)
+}
+
+// isScaledDown returns true if node statefulset has no pods
+func isScaledDown(ctx context.Context, c client.Client, node *unstructured.Unstructured) (bool, error) {
+	sts := &appsv1.StatefulSet{}
+
+	err := c.Get(ctx, types.NamespacedName{Name: node.GetName(), Namespace: node.GetNamespace()}, sts)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return sts.Spec.Replicas != nil && *sts.Spec.Replicas == 0 && sts.Status.Replicas == 0, nil
+}
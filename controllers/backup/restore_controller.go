@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	backupv1alpha1 "github.com/kotalco/kotal/apis/backup/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+)
+
+// RestoreReconciler reconciles a Restore object
+type RestoreReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=backup.kotal.io,resources=restores,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.kotal.io,resources=restores/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=watch;get;list;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile Kotal node restore
+// node is suspended, and its persistent volume claim is deleted and recreated by the node controller from the volume snapshot
+func (r *RestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	var restore backupv1alpha1.Restore
+
+	if err = r.Client.Get(ctx, req.NamespacedName, &restore); err != nil {
+		err = client.IgnoreNotFound(err)
+		return
+	}
+
+	defer func() { shared.RecordError(r.Recorder, &restore, err) }()
+
+	// restore is a one-off operation
+	if phase := restore.Status.Phase; phase == backupv1alpha1.RestoreCompleted || phase == backupv1alpha1.RestoreFailed {
+		return
+	}
+
+	snapshotName, err := r.snapshotName(ctx, &restore)
+	if err != nil {
+		return
+	}
+
+	if snapshotName == "" {
+		return r.pending(ctx, &restore, fmt.Sprintf("backup %s has no volume snapshots", restore.Spec.BackupName))
+	}
+
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(shared.VolumeSnapshotGVK)
+
+	err = r.Client.Get(ctx, types.NamespacedName{Name: snapshotName, Namespace: restore.Namespace}, snapshot)
+	if apierrors.IsNotFound(err) {
+		return r.pending(ctx, &restore, fmt.Sprintf("volume snapshot %s is not found", snapshotName))
+	}
+	if err != nil {
+		return
+	}
+
+	if ready, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse"); !ready {
+		return r.pending(ctx, &restore, fmt.Sprintf("volume snapshot %s is not ready to use", snapshotName))
+	}
+
+	node, err := getNode(ctx, r.Client, restore.Namespace, restore.Spec.Node)
+	if apierrors.IsNotFound(err) {
+		return r.pending(ctx, &restore, fmt.Sprintf("node %s is not found", restore.Spec.Node.Name))
+	}
+	if err != nil {
+		return
+	}
+
+	annotations := map[string]string{shared.RestoreSnapshotAnnotation: snapshotName}
+
+	suspended, err := suspendNode(ctx, r.Client, node, restore.Name, annotations)
+	if err != nil {
+		return
+	}
+	if !suspended {
+		return r.pending(ctx, &restore, fmt.Sprintf("node is suspended by %s", node.GetAnnotations()[shared.SuspendAnnotation]))
+	}
+
+	restored, err := r.reconcilePVC(ctx, node, snapshotName)
+	if err != nil {
+		return
+	}
+
+	if !restored {
+		result.RequeueAfter = pollInterval
+		err = r.updateStatus(ctx, &restore, func(status *backupv1alpha1.RestoreStatus) {
+			status.Phase = backupv1alpha1.RestoreInProgress
+			status.Message = "recreating node storage from volume snapshot"
+			status.SnapshotName = snapshotName
+		})
+		return
+	}
+
+	if err = resumeNode(ctx, r.Client, node, restore.Name, shared.RestoreSnapshotAnnotation); err != nil {
+		return
+	}
+
+	log.FromContext(ctx).Info("node storage has been restored", "node", node.GetName(), "snapshot", snapshotName)
+
+	now := metav1.Now()
+	err = r.updateStatus(ctx, &restore, func(status *backupv1alpha1.RestoreStatus) {
+		status.Phase = backupv1alpha1.RestoreCompleted
+		status.Message = ""
+		status.SnapshotName = snapshotName
+		status.CompletionTime = &now
+	})
+
+	return
+}
+
+// snapshotName returns the volume snapshot to restore
+// it's either the given snapshot or the latest snapshot of the given backup
+func (r *RestoreReconciler) snapshotName(ctx context.Context, restore *backupv1alpha1.Restore) (string, error) {
+	if restore.Status.SnapshotName != "" {
+		return restore.Status.SnapshotName, nil
+	}
+
+	if restore.Spec.SnapshotName != "" {
+		return restore.Spec.SnapshotName, nil
+	}
+
+	var backup backupv1alpha1.Backup
+	key := types.NamespacedName{Name: restore.Spec.BackupName, Namespace: restore.Namespace}
+	if err := r.Client.Get(ctx, key, &backup); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+
+	if count := len(backup.Status.Snapshots); count != 0 {
+		return backup.Status.Snapshots[count-1], nil
+	}
+
+	return "", nil
+}
+
+// reconcilePVC deletes node persistent volume claims that are not created from the volume snapshot
+// it returns true if all node persistent volume claims have been created from the volume snapshot
+// replicas are interchangeable, every replica persistent volume claim is restored from the same snapshot
+func (r *RestoreReconciler) reconcilePVC(ctx context.Context, node *unstructured.Unstructured, snapshotName string) (bool, error) {
+	restored := true
+
+	for _, claim := range nodePVCNames(node) {
+		pvc := &corev1.PersistentVolumeClaim{}
+
+		err := r.Client.Get(ctx, types.NamespacedName{Name: claim, Namespace: node.GetNamespace()}, pvc)
+		// node controller will recreate it from the volume snapshot
+		if apierrors.IsNotFound(err) {
+			restored = false
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+
+		if source := pvc.Spec.DataSource; source != nil && source.Kind == shared.VolumeSnapshotGVK.Kind && source.Name == snapshotName {
+			continue
+		}
+
+		restored = false
+
+		if !pvc.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		// node is scaled down before deleting its storage
+		scaledDown, err := isScaledDown(ctx, r.Client, node)
+		if err != nil || !scaledDown {
+			return false, err
+		}
+
+		log.FromContext(ctx).Info("deleting node persistent volume claim", "name", pvc.Name)
+
+		if err := r.Client.Delete(ctx, pvc); err != nil {
+			return false, err
+		}
+	}
+
+	return restored, nil
+}
+
+// pending updates restore status to pending with the given reason and requeues it
+func (r *RestoreReconciler) pending(ctx context.Context, restore *backupv1alpha1.Restore, msg string) (result ctrl.Result, err error) {
+	result.RequeueAfter = pollInterval
+	err = r.updateStatus(ctx, restore, func(status *backupv1alpha1.RestoreStatus) {
+		status.Phase = backupv1alpha1.RestorePending
+		status.Message = msg
+	})
+	return
+}
+
+// updateStatus updates restore status using the given mutation
+func (r *RestoreReconciler) updateStatus(ctx context.Context, restore *backupv1alpha1.Restore, mutate func(*backupv1alpha1.RestoreStatus)) error {
+	mutate(&restore.Status)
+
+	if err := r.Status().Update(ctx, restore); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update restore status")
+		return err
+	}
+
+	return nil
+}
+
+func (r *RestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&backupv1alpha1.Restore{}).
+		Complete(r)
+}
@@ -4,12 +4,10 @@ import (
 	"context"
 
 	bitcoinClients "github.com/kotalco/kotal/clients/bitcoin"
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -21,13 +19,25 @@ import (
 // NodeReconciler reconciles a Node object
 type NodeReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=bitcoin.kotal.io,resources=nodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=bitcoin.kotal.io,resources=nodes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=bitcoin.kotal.io,resources=nodes/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
-// +kubebuilder:rbac:groups=core,resources=services;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=services;configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=watch;get;list
+// +kubebuilder:rbac:groups=core,resources=nodes/proxy,verbs=get
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=watch;get;list
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // Reconcile Bitcoin node
 func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
@@ -38,6 +48,22 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
+	defer func() { shared.RecordError(r.Recorder, &node, err) }()
+	defer func() { shared.ObserveReconcile(r.Scheme, &node, string(node.Spec.Network), err) }()
+
+	if !node.DeletionTimestamp.IsZero() {
+		result, err = shared.FinalizeStorage(ctx, r.Client, &node, node.Spec.RetentionPolicy, node.Spec.Replicas)
+		return
+	}
+
+	if err = shared.EnsureRetentionFinalizer(ctx, r.Client, &node); err != nil {
+		return
+	}
+
+	if result.RequeueAfter, err = shared.AutoExpandStorage(ctx, r.Client, r.Recorder, &node, &node.Spec.Resources); err != nil {
+		return
+	}
+
 	// default the node if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		node.Default()
@@ -45,15 +71,22 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 
 	shared.UpdateLabels(&node, "bitcoind")
 
-	if err = r.reconcilePVC(ctx, &node); err != nil {
+	descriptor, err := r.Descriptor(&node)
+	if err != nil {
 		return
 	}
 
-	if err = r.reconcileService(ctx, &node); err != nil {
+	requeueAfter, err := shared.PlanRollout(ctx, r.Client, r.Recorder, &node, &node.Status.Status, descriptor)
+	if err != nil {
 		return
 	}
 
-	if err = r.reconcileStatefulset(ctx, &node); err != nil {
+	// canary pod is checked more often than data volume usage
+	if requeueAfter != 0 {
+		result.RequeueAfter = requeueAfter
+	}
+
+	if err = shared.ReconcileNode(ctx, r.Client, r.Scheme, r.Recorder, &node, descriptor); err != nil {
 		return
 	}
 
@@ -68,6 +101,10 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 func (r *NodeReconciler) updateStatus(ctx context.Context, node *bitcoinv1alpha1.Node) error {
 	node.Status.Client = "bitcoincore"
 
+	if err := shared.UpdateStatus(ctx, r.Client, node, &node.Status.Status); err != nil {
+		return err
+	}
+
 	if err := r.Status().Update(ctx, node); err != nil {
 		log.FromContext(ctx).Error(err, "unable to update node status")
 		return err
@@ -76,78 +113,11 @@ func (r *NodeReconciler) updateStatus(ctx context.Context, node *bitcoinv1alpha1
 	return nil
 }
 
-// reconcilePVC reconciles Bitcoin node persistent volume claim
-func (r *NodeReconciler) reconcilePVC(ctx context.Context, node *bitcoinv1alpha1.Node) error {
-	pvc := &corev1.PersistentVolumeClaim{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, pvc, func() error {
-		if err := ctrl.SetControllerReference(node, pvc, r.Scheme); err != nil {
-			return err
-		}
-
-		r.specPVC(node, pvc)
-
-		return nil
-	})
-
-	return err
-}
-
-// specPVC updates Bitcoin node persistent volume claim
-func (r *NodeReconciler) specPVC(node *bitcoinv1alpha1.Node, pvc *corev1.PersistentVolumeClaim) {
-	request := corev1.ResourceList{
-		corev1.ResourceStorage: resource.MustParse(node.Spec.Storage),
-	}
-
-	// spec is immutable after creation except resources.requests for bound claims
-	if !pvc.CreationTimestamp.IsZero() {
-		pvc.Spec.Resources.Requests = request
-		return
-	}
-
-	pvc.ObjectMeta.Labels = node.Labels
-	pvc.Spec = corev1.PersistentVolumeClaimSpec{
-		AccessModes: []corev1.PersistentVolumeAccessMode{
-			corev1.ReadWriteOnce,
-		},
-		Resources: corev1.ResourceRequirements{
-			Requests: request,
-		},
-	}
-}
-
-// reconcileService reconciles Bitcoin node service
-func (r *NodeReconciler) reconcileService(ctx context.Context, node *bitcoinv1alpha1.Node) error {
-	svc := &corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, svc, func() error {
-		if err := ctrl.SetControllerReference(node, svc, r.Scheme); err != nil {
-			return err
-		}
-		r.specService(node, svc)
-		return nil
-	})
-
-	return err
-}
-
-// specService updates Bitcoin node service spec
-func (r *NodeReconciler) specService(node *bitcoinv1alpha1.Node, svc *corev1.Service) {
-	labels := node.Labels
-
-	svc.ObjectMeta.Labels = labels
+// Descriptor returns Bitcoin node resources that are reconciled by the shared node reconciler
+func (r *NodeReconciler) Descriptor(node *bitcoinv1alpha1.Node) (*shared.NodeDescriptor, error) {
+	client := bitcoinClients.NewClient(node, r.Client)
 
-	svc.Spec.Ports = []corev1.ServicePort{
+	ports := []corev1.ServicePort{
 		{
 			Name:       "p2p",
 			Port:       int32(node.Spec.P2PPort),
@@ -156,111 +126,67 @@ func (r *NodeReconciler) specService(node *bitcoinv1alpha1.Node, svc *corev1.Ser
 		},
 	}
 
+	endpoints := []shared.IngressEndpoint{}
+
 	if node.Spec.RPC {
-		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+		ports = append(ports, corev1.ServicePort{
 			Name:       "rpc",
 			Port:       int32(node.Spec.RPCPort),
-			TargetPort: intstr.FromInt(int(node.Spec.RPCPort)),
+			TargetPort: shared.RPCGatewayTargetPort(node.Spec.RPCGateway, node.Spec.RPCPort),
 			Protocol:   corev1.ProtocolTCP,
 		})
+		endpoints = append(endpoints, shared.IngressEndpoint{Name: "rpc", Port: node.Spec.RPCPort})
 	}
 
-	svc.Spec.Selector = labels
-}
-
-// reconcileStatefulset reconciles node statefulset
-func (r *NodeReconciler) reconcileStatefulset(ctx context.Context, node *bitcoinv1alpha1.Node) error {
-	sts := &appsv1.StatefulSet{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	client := bitcoinClients.NewClient(node, r.Client)
+	var metrics *shared.MetricsEndpoint
+	var sidecars []corev1.Container
 
-	img := client.Image()
-	homeDir := client.HomeDir()
-	cmd := client.Command()
-	args := client.Args()
-	env := client.Env()
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, sts, func() error {
-		if err := ctrl.SetControllerReference(node, sts, r.Scheme); err != nil {
-			return err
-		}
-		if err := r.specStatefulSet(node, sts, img, homeDir, env, cmd, args); err != nil {
-			return err
+	if node.Spec.Metrics.Enabled {
+		ports = append(ports, corev1.ServicePort{
+			Name:       "metrics",
+			Port:       int32(node.Spec.Metrics.Port),
+			TargetPort: intstr.FromInt(int(node.Spec.Metrics.Port)),
+			Protocol:   corev1.ProtocolTCP,
+		})
+		metrics = &shared.MetricsEndpoint{
+			Port: "metrics",
+			Path: client.MetricsPath(),
 		}
-		return nil
-	})
-
-	return err
-}
-
-// specStatefulSet updates node statefulset spec
-func (r *NodeReconciler) specStatefulSet(node *bitcoinv1alpha1.Node, sts *appsv1.StatefulSet, img, homeDir string, env []corev1.EnvVar, cmd, args []string) error {
-
-	sts.ObjectMeta.Labels = node.Labels
-
-	sts.Spec = appsv1.StatefulSetSpec{
-		Selector: &metav1.LabelSelector{
-			MatchLabels: node.Labels,
-		},
-		ServiceName: node.Name,
-		Template: corev1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: node.Labels,
-			},
-			Spec: corev1.PodSpec{
-				SecurityContext: shared.SecurityContext(),
-				Containers: []corev1.Container{
-					{
-						Name:    "node",
-						Image:   img,
-						Command: cmd,
-						Args:    args,
-						Env:     env,
-						VolumeMounts: []corev1.VolumeMount{
-							{
-								Name:      "data",
-								MountPath: shared.PathData(homeDir),
-							},
-						},
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Spec.CPU),
-								corev1.ResourceMemory: resource.MustParse(node.Spec.Memory),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Spec.CPULimit),
-								corev1.ResourceMemory: resource.MustParse(node.Spec.MemoryLimit),
-							},
-						},
-					},
-				},
-				Volumes: []corev1.Volume{
-					{
-						Name: "data",
-						VolumeSource: corev1.VolumeSource{
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-								ClaimName: node.Name,
-							},
-						},
-					},
-				},
-			},
-		},
+		sidecars = append(sidecars, corev1.Container{
+			Name:  "exporter",
+			Image: bitcoinClients.ExporterImage(),
+			Env:   bitcoinClients.ExporterEnv(node),
+		})
 	}
 
-	return nil
+	return &shared.NodeDescriptor{
+		Client:           client,
+		Resources:        &node.Spec.Resources,
+		Scheduling:       &node.Spec.Scheduling,
+		ExtraConfig:      &node.Spec.ExtraConfig,
+		Disruption:       node.Spec.Disruption,
+		NetworkPolicy:    node.Spec.NetworkPolicy,
+		Probes:           node.Spec.Probes,
+		Bootstrap:        node.Spec.Bootstrap,
+		Ports:            ports,
+		Expose:           node.Spec.Expose,
+		P2PPorts:         []string{"p2p"},
+		Sidecars:         sidecars,
+		RPCGateway:       node.Spec.RPCGateway,
+		RPCPort:          node.Spec.RPCPort,
+		Replicas:         node.Spec.Replicas,
+		UpdateStrategy:   node.Spec.UpdateStrategy,
+		Metrics:          metrics,
+		Ingress:          node.Spec.Ingress,
+		IngressEndpoints: endpoints,
+	}, nil
 }
 
 func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&bitcoinv1alpha1.Node{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.Service{}).
-		Complete(r)
+	b, err := shared.NodeControllerManagedBy(mgr, &bitcoinv1alpha1.Node{})
+	if err != nil {
+		return err
+	}
+
+	return b.Complete(r)
 }
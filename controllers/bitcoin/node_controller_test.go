@@ -80,6 +80,13 @@ var _ = Describe("Bitcoin node controller", func() {
 		fetched := &appsv1.StatefulSet{}
 		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
 		Expect(fetched.OwnerReferences).To(ContainElements(nodeOwnerReference))
+		Expect(fetched.Spec.Selector.MatchLabels).To(Equal(map[string]string{
+			"app.kubernetes.io/name":       "bitcoind",
+			"app.kubernetes.io/instance":   toCreate.Name,
+			"app.kubernetes.io/component":  "bitcoin-node",
+			"app.kubernetes.io/managed-by": "kotal",
+			"app.kubernetes.io/created-by": "bitcoin-node-controller",
+		}))
 		Expect(*fetched.Spec.Template.Spec.SecurityContext).To(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
 			"RunAsUser":    gstruct.PointTo(Equal(int64(1000))),
 			"RunAsGroup":   gstruct.PointTo(Equal(int64(3000))),
@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+)
+
+// Render returns Bitcoin node child resources without contacting the api server
+// objects holds resources referenced by the node spec like RPC users password secrets
+func Render(node *bitcoinv1alpha1.Node, objects ...client.Object) ([]client.Object, error) {
+	node = node.DeepCopy()
+	node.Default()
+	shared.UpdateLabels(node, "bitcoind")
+
+	c, scheme := shared.NewRenderClient(bitcoinv1alpha1.AddToScheme, objects...)
+	r := &NodeReconciler{Client: c, Scheme: scheme}
+
+	descriptor, err := r.Descriptor(node)
+	if err != nil {
+		return nil, err
+	}
+
+	return shared.RenderNode(context.Background(), c, node, descriptor)
+}
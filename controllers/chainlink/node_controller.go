@@ -8,12 +8,10 @@ import (
 	chainlinkv1alpha1 "github.com/kotalco/kotal/apis/chainlink/v1alpha1"
 	chainlinkClients "github.com/kotalco/kotal/clients/chainlink"
 	"github.com/kotalco/kotal/controllers/shared"
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -22,7 +20,8 @@ import (
 // NodeReconciler reconciles a Node object
 type NodeReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 var (
@@ -32,8 +31,19 @@ var (
 
 // +kubebuilder:rbac:groups=chainlink.kotal.io,resources=nodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=chainlink.kotal.io,resources=nodes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=chainlink.kotal.io,resources=nodes/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
 // +kubebuilder:rbac:groups=core,resources=services;configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=watch;get;list
+// +kubebuilder:rbac:groups=core,resources=nodes/proxy,verbs=get
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=watch;get;list
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 
@@ -44,26 +54,35 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
-	// default the node if webhooks are disabled
-	if !shared.IsWebhookEnabled() {
-		node.Default()
-	}
+	defer func() { shared.RecordError(r.Recorder, &node, err) }()
+	defer func() { shared.ObserveReconcile(r.Scheme, &node, fmt.Sprintf("%d", node.Spec.EthereumChainId), err) }()
 
-	shared.UpdateLabels(&node, "chainlink")
+	if !node.DeletionTimestamp.IsZero() {
+		result, err = shared.FinalizeStorage(ctx, r.Client, &node, node.Spec.RetentionPolicy, 1)
+		return
+	}
 
-	if err = r.reconcileService(ctx, &node); err != nil {
+	if err = shared.EnsureRetentionFinalizer(ctx, r.Client, &node); err != nil {
 		return
 	}
 
-	if err = r.reconcileConfigmap(ctx, &node); err != nil {
+	if result.RequeueAfter, err = shared.AutoExpandStorage(ctx, r.Client, r.Recorder, &node, &node.Spec.Resources); err != nil {
 		return
 	}
 
-	if err = r.reconcilePVC(ctx, &node); err != nil {
+	// default the node if webhooks are disabled
+	if !shared.IsWebhookEnabled() {
+		node.Default()
+	}
+
+	shared.UpdateLabels(&node, "chainlink")
+
+	descriptor, err := r.Descriptor(&node)
+	if err != nil {
 		return
 	}
 
-	if err = r.reconcileStatefulset(ctx, &node); err != nil {
+	if err = shared.ReconcileNode(ctx, r.Client, r.Scheme, r.Recorder, &node, descriptor); err != nil {
 		return
 	}
 
@@ -78,6 +97,10 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 func (r *NodeReconciler) updateStatus(ctx context.Context, node *chainlinkv1alpha1.Node) error {
 	node.Status.Client = "chainlink"
 
+	if err := shared.UpdateStatus(ctx, r.Client, node, &node.Status.Status); err != nil {
+		return err
+	}
+
 	if err := r.Status().Update(ctx, node); err != nil {
 		log.FromContext(ctx).Error(err, "unable to update node status")
 		return err
@@ -86,33 +109,12 @@ func (r *NodeReconciler) updateStatus(ctx context.Context, node *chainlinkv1alph
 	return nil
 }
 
-// reconcileService reconciles node service
-func (r *NodeReconciler) reconcileService(ctx context.Context, node *chainlinkv1alpha1.Node) error {
-	svc := &corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, svc, func() error {
-		if err := ctrl.SetControllerReference(node, svc, r.Scheme); err != nil {
-			return err
-		}
-		r.specService(node, svc)
-		return nil
-	})
-
-	return err
-}
-
-// specService updates node service spec
-func (r *NodeReconciler) specService(node *chainlinkv1alpha1.Node, svc *corev1.Service) {
-	labels := node.Labels
-
-	svc.ObjectMeta.Labels = labels
+// Descriptor returns chainlink node resources that are reconciled by the shared node reconciler
+func (r *NodeReconciler) Descriptor(node *chainlinkv1alpha1.Node) (*shared.NodeDescriptor, error) {
+	client := chainlinkClients.NewClient(node)
+	homeDir := client.HomeDir()
 
-	svc.Spec.Ports = []corev1.ServicePort{
+	ports := []corev1.ServicePort{
 		{
 			Name:       "p2p",
 			Port:       int32(node.Spec.P2PPort),
@@ -128,7 +130,7 @@ func (r *NodeReconciler) specService(node *chainlinkv1alpha1.Node, svc *corev1.S
 	}
 
 	if node.Spec.TLSPort != 0 {
-		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+		ports = append(ports, corev1.ServicePort{
 			Name:       "tls",
 			Port:       int32(node.Spec.TLSPort),
 			TargetPort: intstr.FromInt(int(node.Spec.TLSPort)),
@@ -136,68 +138,15 @@ func (r *NodeReconciler) specService(node *chainlinkv1alpha1.Node, svc *corev1.S
 		})
 	}
 
-	svc.Spec.Selector = labels
-}
-
-// reconcileConfigmap reconciles chainlink node configmap
-func (r *NodeReconciler) reconcileConfigmap(ctx context.Context, node *chainlinkv1alpha1.Node) error {
-	config := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
+	var metrics *shared.MetricsEndpoint
 
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, config, func() error {
-		if err := ctrl.SetControllerReference(node, config, r.Scheme); err != nil {
-			return err
+	if node.Spec.Metrics.Enabled {
+		metrics = &shared.MetricsEndpoint{
+			Port: "api",
+			Path: client.MetricsPath(),
 		}
-
-		r.specConfigmap(node, config)
-
-		return nil
-	})
-
-	return err
-
-}
-
-// specConfigmap updates chainlink node configmap spec
-func (r *NodeReconciler) specConfigmap(node *chainlinkv1alpha1.Node, config *corev1.ConfigMap) {
-	config.ObjectMeta.Labels = node.Labels
-
-	if config.Data == nil {
-		config.Data = make(map[string]string)
 	}
 
-	config.Data["copy_api_credentials.sh"] = CopyAPICredentials
-}
-
-func (r *NodeReconciler) createVolumes(node *chainlinkv1alpha1.Node) []corev1.Volume {
-	volumes := []corev1.Volume{}
-
-	// data volume
-	volumes = append(volumes, corev1.Volume{
-		Name: "data",
-		VolumeSource: corev1.VolumeSource{
-			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: node.Name,
-			},
-		},
-	})
-
-	// config volume
-	volumes = append(volumes, corev1.Volume{
-		Name: "config",
-		VolumeSource: corev1.VolumeSource{
-			ConfigMap: &corev1.ConfigMapVolumeSource{
-				LocalObjectReference: corev1.LocalObjectReference{
-					Name: node.Name,
-				},
-			},
-		},
-	})
-
 	// projected volume sources
 	sources := []corev1.VolumeProjection{
 		{
@@ -238,188 +187,77 @@ func (r *NodeReconciler) createVolumes(node *chainlinkv1alpha1.Node) []corev1.Vo
 		})
 	}
 
-	// secrets volume
-	volumes = append(volumes, corev1.Volume{
-		Name: "secrets",
-		VolumeSource: corev1.VolumeSource{
-			Projected: &corev1.ProjectedVolumeSource{
-				Sources: sources,
-			},
+	descriptor := &shared.NodeDescriptor{
+		Client:        client,
+		Resources:     &node.Spec.Resources,
+		Scheduling:    &node.Spec.Scheduling,
+		ExtraConfig:   &node.Spec.ExtraConfig,
+		Disruption:    node.Spec.Disruption,
+		NetworkPolicy: node.Spec.NetworkPolicy,
+		Probes:        node.Spec.Probes,
+		Ports:         ports,
+		Expose:        node.Spec.Expose,
+		P2PPorts:      []string{"p2p"},
+		// chainlink chmod the root dir
+		// we mount data volume at home dir
+		// chainlink root dir will be mounted at $data/kotal-data
+		DataMountPath: homeDir,
+		ConfigFiles: map[string]string{
+			"copy_api_credentials.sh": CopyAPICredentials,
 		},
-	})
-
-	return volumes
-}
-
-func (r *NodeReconciler) createVolumeMounts(node *chainlinkv1alpha1.Node, homeDir string) []corev1.VolumeMount {
-	// chainlink chmod the root dir
-	// we mount data volume at home dir
-	// chainlink root dir will be mounted at $data/kotal-data
-	return []corev1.VolumeMount{
-		{
-			Name:      "data",
-			MountPath: homeDir,
-		},
-		{
-			Name:      "config",
-			MountPath: shared.PathConfig(homeDir),
+		Volumes: []corev1.Volume{
+			{
+				Name: "secrets",
+				VolumeSource: corev1.VolumeSource{
+					Projected: &corev1.ProjectedVolumeSource{
+						Sources: sources,
+					},
+				},
+			},
 		},
-		{
-			Name:      "secrets",
-			MountPath: shared.PathSecrets(homeDir),
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "secrets",
+				MountPath: shared.PathSecrets(homeDir),
+			},
 		},
-	}
-}
-
-// reconcileStatefulset reconciles node statefulset
-func (r *NodeReconciler) reconcileStatefulset(ctx context.Context, node *chainlinkv1alpha1.Node) error {
-	sts := &appsv1.StatefulSet{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
+		Metrics: metrics,
+		Ingress: node.Spec.Ingress,
+		IngressEndpoints: []shared.IngressEndpoint{
+			{Name: "api", Port: node.Spec.APIPort},
 		},
 	}
 
-	client := chainlinkClients.NewClient(node)
-
-	img := client.Image()
-	command := client.Command()
-	args := client.Args()
-	env := client.Env()
-	homeDir := client.HomeDir()
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, sts, func() error {
-		if err := ctrl.SetControllerReference(node, sts, r.Scheme); err != nil {
-			return err
-		}
-		if err := r.specStatefulSet(node, sts, img, homeDir, command, args, env); err != nil {
-			return err
-		}
-		return nil
-	})
-
-	return err
-}
-
-// specStatefulSet updates node statefulset spec
-func (r *NodeReconciler) specStatefulSet(node *chainlinkv1alpha1.Node, sts *appsv1.StatefulSet, image, homeDir string, command, args []string, env []corev1.EnvVar) error {
-
-	sts.ObjectMeta.Labels = node.Labels
-
-	sts.Spec = appsv1.StatefulSetSpec{
-		Selector: &metav1.LabelSelector{
-			MatchLabels: node.Labels,
-		},
-		ServiceName: node.Name,
-		Template: corev1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: node.Labels,
+	descriptor.InitContainers = append(descriptor.InitContainers, corev1.Container{
+		Name:    "copy-api-credentials",
+		Image:   shared.BusyboxImage,
+		Command: []string{"/bin/sh"},
+		Env: []corev1.EnvVar{
+			{
+				Name:  "KOTAL_DATA_PATH",
+				Value: shared.PathData(homeDir),
 			},
-			Spec: corev1.PodSpec{
-				SecurityContext: shared.SecurityContext(),
-				InitContainers: []corev1.Container{
-					{
-						Name:    "copy-api-credentials",
-						Image:   shared.BusyboxImage,
-						Command: []string{"/bin/sh"},
-						Env: []corev1.EnvVar{
-							{
-								Name:  "KOTAL_DATA_PATH",
-								Value: shared.PathData(homeDir),
-							},
-							{
-								Name:  "KOTAL_EMAIL",
-								Value: node.Spec.APICredentials.Email,
-							},
-							{
-								Name:  "KOTAL_SECRETS_PATH",
-								Value: shared.PathSecrets(homeDir),
-							},
-						},
-						Args:         []string{fmt.Sprintf("%s/copy_api_credentials.sh", shared.PathConfig(homeDir))},
-						VolumeMounts: r.createVolumeMounts(node, homeDir),
-					},
-				},
-				Containers: []corev1.Container{
-					{
-						Name:    "node",
-						Image:   image,
-						Command: command,
-						Args:    args,
-						Env:     env,
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Spec.Resources.CPU),
-								corev1.ResourceMemory: resource.MustParse(node.Spec.Resources.Memory),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Spec.Resources.CPULimit),
-								corev1.ResourceMemory: resource.MustParse(node.Spec.Resources.MemoryLimit),
-							},
-						},
-						VolumeMounts: r.createVolumeMounts(node, homeDir),
-					},
-				},
-				Volumes: r.createVolumes(node),
+			{
+				Name:  "KOTAL_EMAIL",
+				Value: node.Spec.APICredentials.Email,
+			},
+			{
+				Name:  "KOTAL_SECRETS_PATH",
+				Value: shared.PathSecrets(homeDir),
 			},
 		},
-	}
-
-	return nil
-}
-
-// reconcilePVC reconciles chainlink node persistent volume claim
-func (r *NodeReconciler) reconcilePVC(ctx context.Context, node *chainlinkv1alpha1.Node) error {
-	pvc := &corev1.PersistentVolumeClaim{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, pvc, func() error {
-		if err := ctrl.SetControllerReference(node, pvc, r.Scheme); err != nil {
-			return err
-		}
-
-		r.specPVC(node, pvc)
-
-		return nil
+		Args:         []string{fmt.Sprintf("%s/copy_api_credentials.sh", shared.PathConfig(homeDir))},
+		VolumeMounts: shared.NodeVolumeMounts(descriptor),
 	})
 
-	return err
+	return descriptor, nil
 }
 
-// specPVC updates chainlink persistent volume claim
-func (r *NodeReconciler) specPVC(node *chainlinkv1alpha1.Node, pvc *corev1.PersistentVolumeClaim) {
-	request := corev1.ResourceList{
-		corev1.ResourceStorage: resource.MustParse(node.Spec.Storage),
-	}
-
-	// spec is immutable after creation except resources.requests for bound claims
-	if !pvc.CreationTimestamp.IsZero() {
-		pvc.Spec.Resources.Requests = request
-		return
-	}
-
-	pvc.ObjectMeta.Labels = node.Labels
-	pvc.Spec = corev1.PersistentVolumeClaimSpec{
-		AccessModes: []corev1.PersistentVolumeAccessMode{
-			corev1.ReadWriteOnce,
-		},
-		Resources: corev1.ResourceRequirements{
-			Requests: request,
-		},
-		StorageClassName: node.Spec.StorageClass,
+func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b, err := shared.NodeControllerManagedBy(mgr, &chainlinkv1alpha1.Node{})
+	if err != nil {
+		return err
 	}
-}
 
-func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&chainlinkv1alpha1.Node{}).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&corev1.Service{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Complete(r)
+	return b.Complete(r)
 }
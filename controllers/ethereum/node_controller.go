@@ -6,13 +6,12 @@ import (
 	"fmt"
 	"strings"
 
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -26,7 +25,8 @@ import (
 // NodeReconciler reconciles a Node object
 type NodeReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 var (
@@ -42,8 +42,18 @@ var (
 
 // +kubebuilder:rbac:groups=ethereum.kotal.io,resources=nodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ethereum.kotal.io,resources=nodes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ethereum.kotal.io,resources=nodes/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
 // +kubebuilder:rbac:groups=core,resources=secrets;services;configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=watch;get;list
+// +kubebuilder:rbac:groups=core,resources=nodes/proxy,verbs=get
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // Reconcile reconciles ethereum networks
 func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
@@ -55,6 +65,22 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
+	defer func() { shared.RecordError(r.Recorder, &node, err) }()
+	defer func() { shared.ObserveReconcile(r.Scheme, &node, node.Status.Network, err) }()
+
+	if !node.DeletionTimestamp.IsZero() {
+		result, err = shared.FinalizeStorage(ctx, r.Client, &node, node.Spec.RetentionPolicy, node.Spec.Replicas)
+		return
+	}
+
+	if err = shared.EnsureRetentionFinalizer(ctx, r.Client, &node); err != nil {
+		return
+	}
+
+	if result.RequeueAfter, err = shared.AutoExpandStorage(ctx, r.Client, r.Recorder, &node, &node.Spec.Resources); err != nil {
+		return
+	}
+
 	// default the node if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		node.Default()
@@ -64,25 +90,33 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 	r.updateStaticNodes(ctx, &node)
 	r.updateBootnodes(ctx, &node)
 
-	if err = r.reconcilePVC(ctx, &node); err != nil {
+	descriptor, err := r.Descriptor(&node)
+	if err != nil {
 		return
 	}
 
-	if err = r.reconcileConfigmap(ctx, &node); err != nil {
+	requeueAfter, err := shared.PlanRollout(ctx, r.Client, r.Recorder, &node, &node.Status.Status, descriptor)
+	if err != nil {
 		return
 	}
 
-	ip, err := r.reconcileService(ctx, &node)
-	if err != nil {
-		return
+	// canary pod is checked more often than data volume usage
+	if requeueAfter != 0 {
+		result.RequeueAfter = requeueAfter
+	}
+
+	var ip, publicKey string
+
+	descriptor.Hooks.Service = func(svc *corev1.Service) {
+		ip = shared.ServiceIP(svc, node.Spec.Expose)
 	}
 
-	if err = r.reconcileStatefulSet(ctx, &node); err != nil {
+	descriptor.Hooks.Secret = func(ctx context.Context) (err error) {
+		publicKey, err = r.reconcileSecret(ctx, &node)
 		return
 	}
 
-	var publicKey string
-	if publicKey, err = r.reconcileSecret(ctx, &node); err != nil {
+	if err = shared.ReconcileNode(ctx, r.Client, r.Scheme, r.Recorder, &node, descriptor); err != nil {
 		return
 	}
 
@@ -92,7 +126,7 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
-	return ctrl.Result{}, nil
+	return
 }
 
 // getEnodeURL fetch enodeURL from enode that has the format of node.namespace
@@ -133,6 +167,7 @@ func (r *NodeReconciler) updateStaticNodes(ctx context.Context, node *ethereumv1
 				// don't return the error, node maybe not up and running yet
 				node.Spec.StaticNodes = append(node.Spec.StaticNodes[:i], node.Spec.StaticNodes[i+1:]...)
 				log.Error(err, "failed to get static node")
+				shared.Eventf(r.Recorder, node, corev1.EventTypeWarning, shared.ReasonStaticNodeUnresolved, "Unable to resolve static node %s: %s", enode, err)
 				continue
 			}
 			log.Info("static node enodeURL", string(enode), enodeURL)
@@ -142,6 +177,7 @@ func (r *NodeReconciler) updateStaticNodes(ctx context.Context, node *ethereumv1
 			} else {
 				// remove static node reference, so it won't be included into static nodes file
 				node.Spec.StaticNodes = append(node.Spec.StaticNodes[:i], node.Spec.StaticNodes[i+1:]...)
+				shared.Eventf(r.Recorder, node, corev1.EventTypeWarning, shared.ReasonStaticNodeUnresolved, "Static node %s has no enode URL yet", enode)
 			}
 		}
 	}
@@ -158,6 +194,7 @@ func (r *NodeReconciler) updateBootnodes(ctx context.Context, node *ethereumv1al
 				// don't return the error, node maybe not up and running yet
 				node.Spec.Bootnodes = append(node.Spec.Bootnodes[:i], node.Spec.Bootnodes[i+1:]...)
 				log.Error(err, "failed to get bootnode")
+				shared.Eventf(r.Recorder, node, corev1.EventTypeWarning, shared.ReasonStaticNodeUnresolved, "Unable to resolve bootnode %s: %s", enode, err)
 				continue
 			}
 			log.Info("bootnode enodeURL", string(enode), enodeURL)
@@ -167,6 +204,7 @@ func (r *NodeReconciler) updateBootnodes(ctx context.Context, node *ethereumv1al
 			} else {
 				// remove bootnode reference, so it won't be included into bootnodes
 				node.Spec.Bootnodes = append(node.Spec.Bootnodes[:i], node.Spec.Bootnodes[i+1:]...)
+				shared.Eventf(r.Recorder, node, corev1.EventTypeWarning, shared.ReasonStaticNodeUnresolved, "Bootnode %s has no enode URL yet", enode)
 			}
 		}
 	}
@@ -218,6 +256,10 @@ func (r *NodeReconciler) updateStatus(ctx context.Context, node *ethereumv1alpha
 
 	node.Status.EnodeURL = enodeURL
 
+	if err := shared.UpdateStatus(ctx, r.Client, node, &node.Status.Status); err != nil {
+		return err
+	}
+
 	if err := r.Status().Update(ctx, node); err != nil {
 		log.Error(err, "unable to update node status")
 		return err
@@ -226,146 +268,89 @@ func (r *NodeReconciler) updateStatus(ctx context.Context, node *ethereumv1alpha
 	return nil
 }
 
-// specConfigmap updates genesis configmap spec
-func (r *NodeReconciler) specConfigmap(node *ethereumv1alpha1.Node, configmap *corev1.ConfigMap, genesis, staticNodes string) {
-	if configmap.Data == nil {
-		configmap.Data = map[string]string{}
+// staticNodesKey returns config file holding static nodes
+func staticNodesKey(node *ethereumv1alpha1.Node) string {
+	if node.Spec.Client == ethereumv1alpha1.GethClient {
+		return "config.toml"
 	}
+	return "static-nodes.json"
+}
 
-	var key, importAccountScript string
-
-	switch node.Spec.Client {
-	case ethereumv1alpha1.GethClient:
-		key = "config.toml"
-		importAccountScript = gethImportAccountScript
-	case ethereumv1alpha1.BesuClient:
-		key = "static-nodes.json"
-	case ethereumv1alpha1.NethermindClient:
-		key = "static-nodes.json"
+// isSigner returns true if node produces or signs blocks
+// IBFT2 validators sign blocks using node private key, they aren't miners
+func isSigner(node *ethereumv1alpha1.Node) bool {
+	if node.Spec.Miner {
+		return true
 	}
+	return node.Spec.Genesis != nil && node.Spec.Genesis.IBFT2 != nil && node.Spec.NodePrivateKeySecretName != ""
+}
 
-	if node.Spec.Genesis != nil {
-		configmap.Data["genesis.json"] = genesis
-		if node.Spec.Client == ethereumv1alpha1.GethClient {
-			configmap.Data["geth-init-genesis.sh"] = GethInitGenesisScript
-		}
-	}
+// specConfigmap updates genesis configmap spec
+func (r *NodeReconciler) specConfigmap(node *ethereumv1alpha1.Node, configmap *corev1.ConfigMap, files map[string]string) {
+	configmap.ObjectMeta.Labels = node.GetLabels()
 
-	if node.Spec.Import != nil {
-		configmap.Data["import-account.sh"] = importAccountScript
+	if configmap.Data == nil {
+		configmap.Data = map[string]string{}
 	}
 
-	if node.Spec.Client == ethereumv1alpha1.NethermindClient {
-		configmap.Data["nethermind_convert_enode_privatekey.sh"] = nethermindConvertEnodePrivateKeyScript
-		configmap.Data["nethermind_copy_keystore.sh"] = nethermindConvertCopyKeystoreScript
+	key := staticNodesKey(node)
+
+	for file, content := range files {
+		if file != key {
+			configmap.Data[file] = content
+		}
 	}
 
 	currentStaticNodes := configmap.Data[key]
+	staticNodes := files[key]
 	// update static nodes config if it's empty
 	// update static nodes config if more static nodes has been created
 	if currentStaticNodes == "" || len(currentStaticNodes) < len(staticNodes) {
 		configmap.Data[key] = staticNodes
 	}
-
-	// create empty config for ptivate networks so it won't be ovverriden by
-	if node.Spec.Client == ethereumv1alpha1.NethermindClient && node.Spec.Genesis != nil {
-		configmap.Data["empty.cfg"] = "{}"
-	}
-
 }
 
-// reconcileConfigmap creates genesis config map if it doesn't exist or update it
-func (r *NodeReconciler) reconcileConfigmap(ctx context.Context, node *ethereumv1alpha1.Node) error {
-
-	var genesis string
-
-	log := log.FromContext(ctx)
-
-	configmap := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
+// configFiles returns node config files
+func (r *NodeReconciler) configFiles(node *ethereumv1alpha1.Node, client ethereumClients.EthereumClient) (map[string]string, error) {
+	files := map[string]string{}
 
-	client, err := ethereumClients.NewClient(node)
-	if err != nil {
-		return err
-	}
-
-	staticNodes := client.EncodeStaticNodes()
-
-	// private network with custom genesis
 	if node.Spec.Genesis != nil {
-
 		// create client specific genesis configuration
-		if genesis, err = client.Genesis(); err != nil {
-			return err
+		genesis, err := client.Genesis()
+		if err != nil {
+			return nil, err
 		}
-	}
-
-	_, err = ctrl.CreateOrUpdate(ctx, r.Client, configmap, func() error {
-		if err := ctrl.SetControllerReference(node, configmap, r.Scheme); err != nil {
-			log.Error(err, "Unable to set controller reference on genesis configmap")
-			return err
+		files["genesis.json"] = genesis
+		if node.Spec.Client == ethereumv1alpha1.GethClient {
+			files["geth-init-genesis.sh"] = GethInitGenesisScript
 		}
-
-		r.specConfigmap(node, configmap, genesis, staticNodes)
-
-		return nil
-	})
-
-	return err
-}
-
-// specPVC update node data pvc spec
-func (r *NodeReconciler) specPVC(node *ethereumv1alpha1.Node, pvc *corev1.PersistentVolumeClaim) {
-	request := corev1.ResourceList{
-		corev1.ResourceStorage: resource.MustParse(node.Spec.Resources.Storage),
 	}
 
-	// spec is immutable after creation except resources.requests for bound claims
-	if !pvc.CreationTimestamp.IsZero() {
-		pvc.Spec.Resources.Requests = request
-		return
+	if node.Spec.Import != nil {
+		var importAccountScript string
+		if node.Spec.Client == ethereumv1alpha1.GethClient {
+			importAccountScript = gethImportAccountScript
+		}
+		files["import-account.sh"] = importAccountScript
 	}
 
-	pvc.ObjectMeta.Labels = node.GetLabels()
-	pvc.Spec = corev1.PersistentVolumeClaimSpec{
-		AccessModes: []corev1.PersistentVolumeAccessMode{
-			corev1.ReadWriteOnce,
-		},
-		Resources: corev1.ResourceRequirements{
-			Requests: request,
-		},
-		StorageClassName: node.Spec.Resources.StorageClass,
+	if node.Spec.Client == ethereumv1alpha1.NethermindClient {
+		files["nethermind_convert_enode_privatekey.sh"] = nethermindConvertEnodePrivateKeyScript
+		files["nethermind_copy_keystore.sh"] = nethermindConvertCopyKeystoreScript
 	}
-}
 
-// reconcilePVC creates node data pvc if it doesn't exist
-func (r *NodeReconciler) reconcilePVC(ctx context.Context, node *ethereumv1alpha1.Node) error {
+	files[staticNodesKey(node)] = client.EncodeStaticNodes()
 
-	pvc := &corev1.PersistentVolumeClaim{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
+	// create empty config for ptivate networks so it won't be ovverriden by
+	if node.Spec.Client == ethereumv1alpha1.NethermindClient && node.Spec.Genesis != nil {
+		files["empty.cfg"] = "{}"
 	}
 
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, pvc, func() error {
-		if err := ctrl.SetControllerReference(node, pvc, r.Scheme); err != nil {
-			return err
-		}
-		r.specPVC(node, pvc)
-		return nil
-	})
-
-	return err
+	return files, nil
 }
 
-// createNodeVolumes creates all the required volumes for the node
+// createNodeVolumes creates secrets volume required by the node
 func (r *NodeReconciler) createNodeVolumes(node *ethereumv1alpha1.Node) []corev1.Volume {
-
 	volumes := []corev1.Volume{}
 	projections := []corev1.VolumeProjection{}
 
@@ -446,34 +431,11 @@ func (r *NodeReconciler) createNodeVolumes(node *ethereumv1alpha1.Node) []corev1
 		volumes = append(volumes, secretsVolume)
 	}
 
-	configVolume := corev1.Volume{
-		Name: "config",
-		VolumeSource: corev1.VolumeSource{
-			ConfigMap: &corev1.ConfigMapVolumeSource{
-				LocalObjectReference: corev1.LocalObjectReference{
-					Name: node.Name,
-				},
-			},
-		},
-	}
-	volumes = append(volumes, configVolume)
-
-	dataVolume := corev1.Volume{
-		Name: "data",
-		VolumeSource: corev1.VolumeSource{
-			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: node.Name,
-			},
-		},
-	}
-	volumes = append(volumes, dataVolume)
-
 	return volumes
 }
 
-// createNodeVolumeMounts creates all required volume mounts for the node
+// createNodeVolumeMounts creates secrets volume mount required by the node
 func (r *NodeReconciler) createNodeVolumeMounts(node *ethereumv1alpha1.Node, homedir string) []corev1.VolumeMount {
-
 	volumeMounts := []corev1.VolumeMount{}
 
 	if node.Spec.NodePrivateKeySecretName != "" || node.Spec.Import != nil {
@@ -485,19 +447,6 @@ func (r *NodeReconciler) createNodeVolumeMounts(node *ethereumv1alpha1.Node, hom
 		volumeMounts = append(volumeMounts, nodekeyMount)
 	}
 
-	genesisMount := corev1.VolumeMount{
-		Name:      "config",
-		MountPath: shared.PathConfig(homedir),
-		ReadOnly:  true,
-	}
-	volumeMounts = append(volumeMounts, genesisMount)
-
-	dataMount := corev1.VolumeMount{
-		Name:      "data",
-		MountPath: shared.PathData(homedir),
-	}
-	volumeMounts = append(volumeMounts, dataMount)
-
 	return volumeMounts
 }
 
@@ -508,10 +457,12 @@ func (r *NodeReconciler) getNodeAffinity(node *ethereumv1alpha1.Node) *corev1.Af
 			PodAntiAffinity: &corev1.PodAntiAffinity{
 				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
 					{
+						// node pods carry the labels set by shared.UpdateLabels
+						// pods are spread from pods of the same node only
 						LabelSelector: &metav1.LabelSelector{
 							MatchLabels: map[string]string{
-								"name":    "node",
-								"network": node.Name,
+								"app.kubernetes.io/component": node.Labels["app.kubernetes.io/component"],
+								"app.kubernetes.io/instance":  node.Labels["app.kubernetes.io/instance"],
 							},
 						},
 						TopologyKey: node.Spec.TopologyKey,
@@ -523,28 +474,10 @@ func (r *NodeReconciler) getNodeAffinity(node *ethereumv1alpha1.Node) *corev1.Af
 	return nil
 }
 
-// specStatefulset updates node statefulset spec
-func (r *NodeReconciler) specStatefulset(node *ethereumv1alpha1.Node, sts *appsv1.StatefulSet, img, homedir string, args []string, volumes []corev1.Volume, volumeMounts []corev1.VolumeMount, affinity *corev1.Affinity) {
-	labels := node.GetLabels()
-	// used by geth to init genesis and import account(s)
+// createInitContainers creates init containers used by geth to init genesis and import account(s)
+// and by nethermind to convert node private key and copy account keystore
+func (r *NodeReconciler) createInitContainers(node *ethereumv1alpha1.Node, img, homedir string, volumeMounts []corev1.VolumeMount) []corev1.Container {
 	initContainers := []corev1.Container{}
-	// node client container
-	nodeContainer := corev1.Container{
-		Name:  "node",
-		Image: img,
-		Args:  args,
-		Resources: corev1.ResourceRequirements{
-			Requests: corev1.ResourceList{
-				corev1.ResourceCPU:    resource.MustParse(node.Spec.Resources.CPU),
-				corev1.ResourceMemory: resource.MustParse(node.Spec.Resources.Memory),
-			},
-			Limits: corev1.ResourceList{
-				corev1.ResourceCPU:    resource.MustParse(node.Spec.Resources.CPULimit),
-				corev1.ResourceMemory: resource.MustParse(node.Spec.Resources.MemoryLimit),
-			},
-		},
-		VolumeMounts: volumeMounts,
-	}
 
 	if node.Spec.Client == ethereumv1alpha1.GethClient {
 		if node.Spec.Genesis != nil {
@@ -636,52 +569,80 @@ func (r *NodeReconciler) specStatefulset(node *ethereumv1alpha1.Node, sts *appsv
 		}
 	}
 
-	sts.ObjectMeta.Labels = labels
-	if sts.Spec.Selector == nil {
-		sts.Spec.Selector = &metav1.LabelSelector{}
-	}
-	sts.Spec.ServiceName = node.Name
-	sts.Spec.Selector.MatchLabels = labels
-	sts.Spec.Template.ObjectMeta.Labels = labels
-	sts.Spec.Template.Spec = corev1.PodSpec{
-		SecurityContext: shared.SecurityContext(),
-		Volumes:         volumes,
-		InitContainers:  initContainers,
-		Containers:      []corev1.Container{nodeContainer},
-		Affinity:        affinity,
-	}
+	return initContainers
 }
 
-// reconcileStatefulSet creates node statefulset if it doesn't exist, update it if it does exist
-func (r *NodeReconciler) reconcileStatefulSet(ctx context.Context, node *ethereumv1alpha1.Node) error {
-
-	sts := &appsv1.StatefulSet{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
+// Descriptor returns node resources that are reconciled by the shared node reconciler
+func (r *NodeReconciler) Descriptor(node *ethereumv1alpha1.Node) (*shared.NodeDescriptor, error) {
 	client, err := ethereumClients.NewClient(node)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	img := client.Image()
+
 	homedir := client.HomeDir()
-	args := client.Args()
-	volumes := r.createNodeVolumes(node)
-	mounts := r.createNodeVolumeMounts(node, homedir)
-	affinity := r.getNodeAffinity(node)
 
-	_, err = ctrl.CreateOrUpdate(ctx, r.Client, sts, func() error {
-		if err := ctrl.SetControllerReference(node, sts, r.Scheme); err != nil {
-			return err
+	files, err := r.configFiles(node, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var metrics *shared.MetricsEndpoint
+
+	if node.Spec.Metrics.Enabled {
+		metrics = &shared.MetricsEndpoint{
+			Port: "metrics",
+			Path: client.MetricsPath(),
 		}
-		r.specStatefulset(node, sts, img, homedir, args, volumes, mounts, affinity)
-		return nil
-	})
+	}
+
+	endpoints := []shared.IngressEndpoint{}
+	var wsPort uint
+
+	if node.Spec.RPC {
+		endpoints = append(endpoints, shared.IngressEndpoint{Name: "json-rpc", Port: node.Spec.RPCPort})
+	}
+
+	if node.Spec.WS {
+		endpoints = append(endpoints, shared.IngressEndpoint{Name: "ws", Port: node.Spec.WSPort})
+		wsPort = node.Spec.WSPort
+	}
+
+	if node.Spec.GraphQL {
+		endpoints = append(endpoints, shared.IngressEndpoint{Name: "graphql", Port: node.Spec.GraphQLPort})
+	}
+
+	descriptor := &shared.NodeDescriptor{
+		Client:           client,
+		Resources:        &node.Spec.Resources,
+		Scheduling:       &node.Spec.Scheduling,
+		ExtraConfig:      &node.Spec.ExtraConfig,
+		Disruption:       node.Spec.Disruption,
+		NetworkPolicy:    node.Spec.NetworkPolicy,
+		Critical:         isSigner(node),
+		Probes:           node.Spec.Probes,
+		Ports:            r.servicePorts(node),
+		Expose:           node.Spec.Expose,
+		P2PPorts:         []string{"discovery", "p2p"},
+		ConfigFiles:      files,
+		Volumes:          r.createNodeVolumes(node),
+		VolumeMounts:     r.createNodeVolumeMounts(node, homedir),
+		RPCGateway:       node.Spec.RPCGateway,
+		RPCPort:          node.Spec.RPCPort,
+		WSPort:           wsPort,
+		Replicas:         node.Spec.Replicas,
+		UpdateStrategy:   node.Spec.UpdateStrategy,
+		Affinity:         r.getNodeAffinity(node),
+		Metrics:          metrics,
+		Ingress:          node.Spec.Ingress,
+		IngressEndpoints: endpoints,
+	}
 
-	return err
+	descriptor.InitContainers = r.createInitContainers(node, client.Image(), homedir, shared.NodeVolumeMounts(descriptor))
+	descriptor.Hooks.SpecConfigmap = func(configmap *corev1.ConfigMap) {
+		r.specConfigmap(node, configmap, files)
+	}
+
+	return descriptor, nil
 }
 
 // specSecret creates keystore from account private key for nethermind client
@@ -754,24 +715,27 @@ func (r *NodeReconciler) reconcileSecret(ctx context.Context, node *ethereumv1al
 		}
 	}
 
-	_, err = ctrl.CreateOrUpdate(ctx, r.Client, secret, func() error {
+	op, err := ctrl.CreateOrUpdate(ctx, r.Client, secret, func() error {
 		if err := ctrl.SetControllerReference(node, secret, r.Scheme); err != nil {
 			return err
 		}
 
 		return r.specSecret(ctx, node, secret)
 	})
+	if err != nil {
+		return
+	}
+
+	shared.RecordOperation(r.Recorder, node, "Secret", secret.Name, op)
 
 	return
 }
 
-// specService updates node service spec
-func (r *NodeReconciler) specService(node *ethereumv1alpha1.Node, svc *corev1.Service) {
-	labels := node.GetLabels()
+// servicePorts returns node service ports
+func (r *NodeReconciler) servicePorts(node *ethereumv1alpha1.Node) []corev1.ServicePort {
 	client := node.Spec.Client
 
-	svc.ObjectMeta.Labels = labels
-	svc.Spec.Ports = []corev1.ServicePort{
+	ports := []corev1.ServicePort{
 		{
 			Name:       "discovery",
 			Port:       int32(node.Spec.P2PPort),
@@ -787,19 +751,19 @@ func (r *NodeReconciler) specService(node *ethereumv1alpha1.Node, svc *corev1.Se
 	}
 
 	if node.Spec.RPCPort != 0 {
-		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+		ports = append(ports, corev1.ServicePort{
 			Name:       "json-rpc",
 			Port:       int32(node.Spec.RPCPort),
-			TargetPort: intstr.FromInt(int(node.Spec.RPCPort)),
+			TargetPort: shared.RPCGatewayTargetPort(node.Spec.RPCGateway, node.Spec.RPCPort),
 			Protocol:   corev1.ProtocolTCP,
 		})
 	}
 
 	if node.Spec.WSPort != 0 {
-		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+		ports = append(ports, corev1.ServicePort{
 			Name:       "ws",
 			Port:       int32(node.Spec.WSPort),
-			TargetPort: intstr.FromInt(int(node.Spec.WSPort)),
+			TargetPort: shared.RPCGatewayTargetPort(node.Spec.RPCGateway, node.Spec.WSPort),
 			Protocol:   corev1.ProtocolTCP,
 		})
 	}
@@ -809,7 +773,7 @@ func (r *NodeReconciler) specService(node *ethereumv1alpha1.Node, svc *corev1.Se
 		if client == ethereumv1alpha1.GethClient {
 			targetPort = node.Spec.RPCPort
 		}
-		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+		ports = append(ports, corev1.ServicePort{
 			Name:       "graphql",
 			Port:       int32(node.Spec.GraphQLPort),
 			TargetPort: intstr.FromInt(int(targetPort)),
@@ -817,46 +781,26 @@ func (r *NodeReconciler) specService(node *ethereumv1alpha1.Node, svc *corev1.Se
 		})
 	}
 
-	svc.Spec.Selector = labels
-}
-
-// reconcileService reconciles node service
-func (r *NodeReconciler) reconcileService(ctx context.Context, node *ethereumv1alpha1.Node) (ip string, err error) {
-
-	svc := &corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	_, err = ctrl.CreateOrUpdate(ctx, r.Client, svc, func() error {
-		if err = ctrl.SetControllerReference(node, svc, r.Scheme); err != nil {
-			return err
-		}
-
-		r.specService(node, svc)
-
-		return nil
-	})
-
-	if err != nil {
-		return
+	if node.Spec.Metrics.Enabled {
+		ports = append(ports, corev1.ServicePort{
+			Name:       "metrics",
+			Port:       int32(node.Spec.Metrics.Port),
+			TargetPort: intstr.FromInt(int(node.Spec.Metrics.Port)),
+			Protocol:   corev1.ProtocolTCP,
+		})
 	}
 
-	ip = svc.Spec.ClusterIP
-
-	return
+	return ports
 }
 
 // SetupWithManager adds reconciler to the manager
 func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&ethereumv1alpha1.Node{}).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.Service{}).
+	b, err := shared.NodeControllerManagedBy(mgr, &ethereumv1alpha1.Node{})
+	if err != nil {
+		return err
+	}
+
+	return b.
 		Owns(&corev1.Secret{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Owns(&corev1.ConfigMap{}).
 		Complete(r)
 }
@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+)
+
+// Render returns Ethereum node child resources without contacting the api server
+// objects holds resources referenced by the node spec like imported account secrets and static nodes
+func Render(node *ethereumv1alpha1.Node, objects ...client.Object) ([]client.Object, error) {
+	ctx := context.Background()
+
+	node = node.DeepCopy()
+	node.Default()
+	shared.UpdateLabels(node, string(node.Spec.Client))
+
+	c, scheme := shared.NewRenderClient(ethereumv1alpha1.AddToScheme, objects...)
+	r := &NodeReconciler{Client: c, Scheme: scheme}
+
+	r.updateStaticNodes(ctx, node)
+	r.updateBootnodes(ctx, node)
+
+	descriptor, err := r.Descriptor(node)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      node.Name,
+			Namespace: node.Namespace,
+		},
+	}
+	if err := r.specSecret(ctx, node, secret); err != nil {
+		return nil, err
+	}
+
+	rendered, err := shared.RenderNode(ctx, c, node, descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(rendered, secret), nil
+}
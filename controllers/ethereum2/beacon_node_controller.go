@@ -4,14 +4,13 @@ import (
 	"context"
 	"fmt"
 
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
 	ethereum2Clients "github.com/kotalco/kotal/clients/ethereum2"
@@ -21,13 +20,25 @@ import (
 // BeaconNodeReconciler reconciles a Node object
 type BeaconNodeReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=ethereum2.kotal.io,resources=beaconnodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ethereum2.kotal.io,resources=beaconnodes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ethereum2.kotal.io,resources=beaconnodes/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
-// +kubebuilder:rbac:groups=core,resources=services;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=services;configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=watch;get;list
+// +kubebuilder:rbac:groups=core,resources=nodes/proxy,verbs=get
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=watch;get;list
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // Reconcile reconciles Ethereum 2.0 beacon node
 func (r *BeaconNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
@@ -38,6 +49,22 @@ func (r *BeaconNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return
 	}
 
+	defer func() { shared.RecordError(r.Recorder, &node, err) }()
+	defer func() { shared.ObserveReconcile(r.Scheme, &node, node.Spec.Network, err) }()
+
+	if !node.DeletionTimestamp.IsZero() {
+		result, err = shared.FinalizeStorage(ctx, r.Client, &node, node.Spec.RetentionPolicy, 1)
+		return
+	}
+
+	if err = shared.EnsureRetentionFinalizer(ctx, r.Client, &node); err != nil {
+		return
+	}
+
+	if result.RequeueAfter, err = shared.AutoExpandStorage(ctx, r.Client, r.Recorder, &node, &node.Spec.Resources); err != nil {
+		return
+	}
+
 	// default the beacon node if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		node.Default()
@@ -45,48 +72,46 @@ func (r *BeaconNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	shared.UpdateLabels(&node, string(node.Spec.Client))
 
-	if err = r.reconcilePVC(ctx, &node); err != nil {
+	descriptor, err := r.Descriptor(&node)
+	if err != nil {
 		return
 	}
 
-	if err = r.reconcileService(ctx, &node); err != nil {
+	if err = shared.ReconcileNode(ctx, r.Client, r.Scheme, r.Recorder, &node, descriptor); err != nil {
 		return
 	}
 
-	if err = r.reconcileStatefulset(ctx, &node); err != nil {
+	if err = r.updateStatus(ctx, &node); err != nil {
 		return
 	}
 
 	return
 }
 
-// reconcileService reconciles beacon node service
-func (r *BeaconNodeReconciler) reconcileService(ctx context.Context, node *ethereum2v1alpha1.BeaconNode) error {
-	svc := corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
+// updateStatus updates beacon node status
+func (r *BeaconNodeReconciler) updateStatus(ctx context.Context, node *ethereum2v1alpha1.BeaconNode) error {
+	if err := shared.UpdateStatus(ctx, r.Client, node, &node.Status.Status); err != nil {
+		return err
 	}
 
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, &svc, func() error {
-		if err := ctrl.SetControllerReference(node, &svc, r.Scheme); err != nil {
-			return err
-		}
-
-		r.specService(node, &svc)
-
-		return nil
-	})
+	if err := r.Status().Update(ctx, node); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update beacon node status")
+		return err
+	}
 
-	return err
+	return nil
 }
 
-func (r *BeaconNodeReconciler) specService(node *ethereum2v1alpha1.BeaconNode, svc *corev1.Service) {
-	labels := node.GetLabels()
+// Descriptor returns beacon node resources that are reconciled by the shared node reconciler
+func (r *BeaconNodeReconciler) Descriptor(node *ethereum2v1alpha1.BeaconNode) (*shared.NodeDescriptor, error) {
+	client, err := ethereum2Clients.NewClient(node)
+	if err != nil {
+		return nil, err
+	}
+
+	homeDir := client.HomeDir()
 
-	svc.ObjectMeta.Labels = labels
-	svc.Spec.Ports = []corev1.ServicePort{
+	ports := []corev1.ServicePort{
 		{
 			Name:       "discovery",
 			Port:       int32(node.Spec.P2PPort),
@@ -102,7 +127,7 @@ func (r *BeaconNodeReconciler) specService(node *ethereum2v1alpha1.BeaconNode, s
 	}
 
 	if node.Spec.RPCPort != 0 {
-		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+		ports = append(ports, corev1.ServicePort{
 			Name:       "json-rpc",
 			Port:       int32(node.Spec.RPCPort),
 			TargetPort: intstr.FromInt(int(node.Spec.RPCPort)),
@@ -111,7 +136,7 @@ func (r *BeaconNodeReconciler) specService(node *ethereum2v1alpha1.BeaconNode, s
 	}
 
 	if node.Spec.GRPCPort != 0 {
-		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+		ports = append(ports, corev1.ServicePort{
 			Name:       "grpc",
 			Port:       int32(node.Spec.GRPCPort),
 			TargetPort: intstr.FromInt(int(node.Spec.GRPCPort)),
@@ -120,7 +145,7 @@ func (r *BeaconNodeReconciler) specService(node *ethereum2v1alpha1.BeaconNode, s
 	}
 
 	if node.Spec.RESTPort != 0 {
-		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+		ports = append(ports, corev1.ServicePort{
 			Name:       "rest",
 			Port:       int32(node.Spec.RESTPort),
 			TargetPort: intstr.FromInt(int(node.Spec.RESTPort)),
@@ -128,124 +153,49 @@ func (r *BeaconNodeReconciler) specService(node *ethereum2v1alpha1.BeaconNode, s
 		})
 	}
 
-	svc.Spec.Selector = labels
-}
-
-// reconcilePVC reconciles beacon node persistent volume claim
-func (r *BeaconNodeReconciler) reconcilePVC(ctx context.Context, node *ethereum2v1alpha1.BeaconNode) error {
-	pvc := corev1.PersistentVolumeClaim{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
+	var metrics *shared.MetricsEndpoint
 
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, &pvc, func() error {
-		if err := ctrl.SetControllerReference(node, &pvc, r.Scheme); err != nil {
-			return err
+	if node.Spec.Metrics.Enabled {
+		ports = append(ports, corev1.ServicePort{
+			Name:       "metrics",
+			Port:       int32(node.Spec.Metrics.Port),
+			TargetPort: intstr.FromInt(int(node.Spec.Metrics.Port)),
+			Protocol:   corev1.ProtocolTCP,
+		})
+		metrics = &shared.MetricsEndpoint{
+			Port: "metrics",
+			Path: client.MetricsPath(),
 		}
-
-		r.specPVC(node, &pvc)
-
-		return nil
-	})
-
-	return err
-}
-
-// specPVC updates beacon node persistent volume claim spec
-func (r *BeaconNodeReconciler) specPVC(node *ethereum2v1alpha1.BeaconNode, pvc *corev1.PersistentVolumeClaim) {
-
-	request := corev1.ResourceList{
-		corev1.ResourceStorage: resource.MustParse(node.Spec.Resources.Storage),
-	}
-
-	// spec is immutable after creation except resources.requests for bound claims
-	if !pvc.CreationTimestamp.IsZero() {
-		pvc.Spec.Resources.Requests = request
-		return
-	}
-
-	pvc.Labels = node.GetLabels()
-
-	pvc.Spec = corev1.PersistentVolumeClaimSpec{
-		AccessModes: []corev1.PersistentVolumeAccessMode{
-			corev1.ReadWriteOnce,
-		},
-		Resources: corev1.ResourceRequirements{
-			Requests: request,
-		},
-		StorageClassName: node.Spec.Resources.StorageClass,
-	}
-}
-
-// reconcileStatefulset reconcile Ethereum 2.0 beacon node
-func (r *BeaconNodeReconciler) reconcileStatefulset(ctx context.Context, node *ethereum2v1alpha1.BeaconNode) error {
-	sts := appsv1.StatefulSet{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
 	}
 
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, &sts, func() error {
-		if err := ctrl.SetControllerReference(node, &sts, r.Scheme); err != nil {
-			return err
-		}
-
-		client, err := ethereum2Clients.NewClient(node)
-		if err != nil {
-			return err
-		}
-
-		args := client.Args()
-		img := client.Image()
-		command := client.Command()
-		homeDir := client.HomeDir()
+	endpoints := []shared.IngressEndpoint{}
 
-		r.specStatefulset(node, &sts, args, command, img, homeDir)
-
-		return nil
-	})
-
-	return err
-}
-
-// specStatefulset updates beacon node statefulset spec
-func (r *BeaconNodeReconciler) specStatefulset(node *ethereum2v1alpha1.BeaconNode, sts *appsv1.StatefulSet, args, command []string, img, homeDir string) {
-
-	sts.Labels = node.GetLabels()
-
-	mountPath := shared.PathData(homeDir)
-
-	// Nimbus required changing permission of the data dir to be
-	// read and write by owner only
-	// that's why we mount volume at $HOME
-	// but data dir is atatched at $HOME/kota-data
-	if node.Spec.Client == ethereum2v1alpha1.NimbusClient {
-		mountPath = homeDir
+	if node.Spec.REST {
+		endpoints = append(endpoints, shared.IngressEndpoint{Name: "rest", Port: node.Spec.RESTPort})
 	}
 
-	volumes := []corev1.Volume{
-		{
-			Name: "data",
-			VolumeSource: corev1.VolumeSource{
-				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-					ClaimName: node.Name,
-				},
-			},
-		},
+	if node.Spec.RPC {
+		endpoints = append(endpoints, shared.IngressEndpoint{Name: "json-rpc", Port: node.Spec.RPCPort})
 	}
 
-	mounts := []corev1.VolumeMount{
-		{
-			Name:      "data",
-			MountPath: mountPath,
-		},
+	descriptor := &shared.NodeDescriptor{
+		Client:           client,
+		Resources:        &node.Spec.Resources,
+		Scheduling:       &node.Spec.Scheduling,
+		ExtraConfig:      &node.Spec.ExtraConfig,
+		Disruption:       node.Spec.Disruption,
+		NetworkPolicy:    node.Spec.NetworkPolicy,
+		Probes:           node.Spec.Probes,
+		Ports:            ports,
+		Expose:           node.Spec.Expose,
+		P2PPorts:         []string{"discovery", "p2p"},
+		Metrics:          metrics,
+		Ingress:          node.Spec.Ingress,
+		IngressEndpoints: endpoints,
 	}
 
 	if node.Spec.CertSecretName != "" {
-		volumes = append(volumes, corev1.Volume{
+		descriptor.Volumes = append(descriptor.Volumes, corev1.Volume{
 			Name: "cert",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
@@ -253,19 +203,21 @@ func (r *BeaconNodeReconciler) specStatefulset(node *ethereum2v1alpha1.BeaconNod
 				},
 			},
 		})
-		mounts = append(mounts, corev1.VolumeMount{
+		descriptor.VolumeMounts = append(descriptor.VolumeMounts, corev1.VolumeMount{
 			Name:      "cert",
 			MountPath: shared.PathSecrets(homeDir),
 		})
 	}
 
-	initContainers := []corev1.Container{}
-
 	// Nimbus client requires data dir path to be read and write only by the owner 0700
 	if node.Spec.Client == ethereum2v1alpha1.NimbusClient {
-		fixPermissionContainer := corev1.Container{
+		// that's why we mount volume at $HOME
+		// but data dir is atatched at $HOME/kota-data
+		descriptor.DataMountPath = homeDir
+
+		descriptor.InitContainers = append(descriptor.InitContainers, corev1.Container{
 			Name:  "fix-datadir-permission",
-			Image: img,
+			Image: client.Image(),
 			Command: []string{
 				"/bin/sh",
 				"-c",
@@ -278,53 +230,19 @@ func (r *BeaconNodeReconciler) specStatefulset(node *ethereum2v1alpha1.BeaconNod
 					shared.PathData(homeDir),
 				),
 			},
-			VolumeMounts: mounts,
-		}
-		initContainers = append(initContainers, fixPermissionContainer)
+			VolumeMounts: shared.NodeVolumeMounts(descriptor),
+		})
 	}
 
-	sts.Spec = appsv1.StatefulSetSpec{
-		Selector: &metav1.LabelSelector{
-			MatchLabels: node.GetLabels(),
-		},
-		Template: corev1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: node.GetLabels(),
-			},
-			Spec: corev1.PodSpec{
-				SecurityContext: shared.SecurityContext(),
-				InitContainers:  initContainers,
-				Containers: []corev1.Container{
-					{
-						Name:         "node",
-						Command:      command,
-						Args:         args,
-						Image:        img,
-						VolumeMounts: mounts,
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Spec.Resources.CPU),
-								corev1.ResourceMemory: resource.MustParse(node.Spec.Resources.Memory),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Spec.Resources.CPULimit),
-								corev1.ResourceMemory: resource.MustParse(node.Spec.Resources.MemoryLimit),
-							},
-						},
-					},
-				},
-				Volumes: volumes,
-			},
-		},
-	}
+	return descriptor, nil
 }
 
 // SetupWithManager adds reconciler to the manager
 func (r *BeaconNodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&ethereum2v1alpha1.BeaconNode{}).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.Service{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Complete(r)
+	b, err := shared.NodeControllerManagedBy(mgr, &ethereum2v1alpha1.BeaconNode{})
+	if err != nil {
+		return err
+	}
+
+	return b.Complete(r)
 }
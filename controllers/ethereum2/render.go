@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+)
+
+// RenderBeaconNode returns Ethereum 2.0 beacon node child resources without contacting the api server
+// objects holds resources referenced by the node spec
+func RenderBeaconNode(node *ethereum2v1alpha1.BeaconNode, objects ...client.Object) ([]client.Object, error) {
+	node = node.DeepCopy()
+	node.Default()
+	shared.UpdateLabels(node, string(node.Spec.Client))
+
+	c, scheme := shared.NewRenderClient(ethereum2v1alpha1.AddToScheme, objects...)
+	r := &BeaconNodeReconciler{Client: c, Scheme: scheme}
+
+	descriptor, err := r.Descriptor(node)
+	if err != nil {
+		return nil, err
+	}
+
+	return shared.RenderNode(context.Background(), c, node, descriptor)
+}
+
+// RenderValidator returns Ethereum 2.0 validator client child resources without contacting the api server
+// objects holds resources referenced by the validator spec
+func RenderValidator(validator *ethereum2v1alpha1.Validator, objects ...client.Object) ([]client.Object, error) {
+	validator = validator.DeepCopy()
+	validator.Default()
+	shared.UpdateLabels(validator, string(validator.Spec.Client))
+
+	c, scheme := shared.NewRenderClient(ethereum2v1alpha1.AddToScheme, objects...)
+	r := &ValidatorReconciler{Client: c, Scheme: scheme}
+
+	descriptor, err := r.Descriptor(validator)
+	if err != nil {
+		return nil, err
+	}
+
+	return shared.RenderNode(context.Background(), c, validator, descriptor)
+}
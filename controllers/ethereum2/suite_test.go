@@ -80,16 +80,18 @@ var _ = BeforeSuite(func() {
 
 	// start beacon node reconciler
 	beaconNodeReconciler := &BeaconNodeReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: scheme.Scheme,
+		Client:   k8sManager.GetClient(),
+		Scheme:   scheme.Scheme,
+		Recorder: k8sManager.GetEventRecorderFor("ethereum2-beacon-node-controller"),
 	}
 	beaconNodeReconciler.SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	// start validator reconciler
 	validatorReconciler := &ValidatorReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: scheme.Scheme,
+		Client:   k8sManager.GetClient(),
+		Scheme:   scheme.Scheme,
+		Recorder: k8sManager.GetEventRecorderFor("ethereum2-validator-controller"),
 	}
 	validatorReconciler.SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
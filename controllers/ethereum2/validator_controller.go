@@ -5,11 +5,10 @@ import (
 	_ "embed"
 	"fmt"
 
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -22,7 +21,8 @@ import (
 // ValidatorReconciler reconciles a Validator object
 type ValidatorReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 var (
@@ -36,8 +36,19 @@ var (
 
 // +kubebuilder:rbac:groups=ethereum2.kotal.io,resources=validators,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ethereum2.kotal.io,resources=validators/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ethereum2.kotal.io,resources=validators/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
-// +kubebuilder:rbac:groups=core,resources=configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=services;configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=watch;get;list
+// +kubebuilder:rbac:groups=core,resources=nodes/proxy,verbs=get
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=watch;get;list
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // Reconcile reconciles Ethereum 2.0 validator client
 func (r *ValidatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
@@ -48,6 +59,22 @@ func (r *ValidatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return
 	}
 
+	defer func() { shared.RecordError(r.Recorder, &validator, err) }()
+	defer func() { shared.ObserveReconcile(r.Scheme, &validator, validator.Spec.Network, err) }()
+
+	if !validator.DeletionTimestamp.IsZero() {
+		result, err = shared.FinalizeStorage(ctx, r.Client, &validator, validator.Spec.RetentionPolicy, 1)
+		return
+	}
+
+	if err = shared.EnsureRetentionFinalizer(ctx, r.Client, &validator); err != nil {
+		return
+	}
+
+	if result.RequeueAfter, err = shared.AutoExpandStorage(ctx, r.Client, r.Recorder, &validator, &validator.Spec.Resources); err != nil {
+		return
+	}
+
 	// default the peer if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		validator.Default()
@@ -55,96 +82,41 @@ func (r *ValidatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	shared.UpdateLabels(&validator, string(validator.Spec.Client))
 
-	if err = r.reconcileConfigmap(ctx, &validator); err != nil {
+	descriptor, err := r.Descriptor(&validator)
+	if err != nil {
 		return
 	}
 
-	if err = r.reconcilePVC(ctx, &validator); err != nil {
+	if err = shared.ReconcileNode(ctx, r.Client, r.Scheme, r.Recorder, &validator, descriptor); err != nil {
 		return
 	}
 
-	if err = r.reconcileStatefulset(ctx, &validator); err != nil {
+	if err = r.updateStatus(ctx, &validator); err != nil {
 		return
 	}
 
 	return
 }
 
-// reconcilePVC reconciles validator persistent volume claim
-func (r *ValidatorReconciler) reconcilePVC(ctx context.Context, validator *ethereum2v1alpha1.Validator) error {
-	pvc := corev1.PersistentVolumeClaim{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      validator.Name,
-			Namespace: validator.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, &pvc, func() error {
-		if err := ctrl.SetControllerReference(validator, &pvc, r.Scheme); err != nil {
-			return err
-		}
-
-		r.specPVC(validator, &pvc)
-
-		return nil
-	})
-
-	return err
-}
-
-// specPVC updates validator persistent volume claim spec
-func (r *ValidatorReconciler) specPVC(validator *ethereum2v1alpha1.Validator, pvc *corev1.PersistentVolumeClaim) {
-
-	request := corev1.ResourceList{
-		corev1.ResourceStorage: resource.MustParse(validator.Spec.Resources.Storage),
+// updateStatus updates validator status
+func (r *ValidatorReconciler) updateStatus(ctx context.Context, validator *ethereum2v1alpha1.Validator) error {
+	if err := shared.UpdateStatus(ctx, r.Client, validator, &validator.Status.Status); err != nil {
+		return err
 	}
 
-	// spec is immutable after creation except resources.requests for bound claims
-	if !pvc.CreationTimestamp.IsZero() {
-		pvc.Spec.Resources.Requests = request
-		return
+	if err := r.Status().Update(ctx, validator); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update validator status")
+		return err
 	}
 
-	pvc.Labels = validator.GetLabels()
-
-	pvc.Spec = corev1.PersistentVolumeClaimSpec{
-		AccessModes: []corev1.PersistentVolumeAccessMode{
-			corev1.ReadWriteOnce,
-		},
-		Resources: corev1.ResourceRequirements{
-			Requests: request,
-		},
-		StorageClassName: validator.Spec.Resources.StorageClass,
-	}
+	return nil
 }
 
-// createValidatorVolumes creates validator volumes
+// createValidatorVolumes creates validator keystores and secrets volumes
 func (r *ValidatorReconciler) createValidatorVolumes(validator *ethereum2v1alpha1.Validator) (volumes []corev1.Volume) {
 
 	var volumeProjections []corev1.VolumeProjection
 
-	dataVolume := corev1.Volume{
-		Name: "data",
-		VolumeSource: corev1.VolumeSource{
-			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: validator.Name,
-			},
-		},
-	}
-	volumes = append(volumes, dataVolume)
-
-	configVolume := corev1.Volume{
-		Name: "config",
-		VolumeSource: corev1.VolumeSource{
-			ConfigMap: &corev1.ConfigMapVolumeSource{
-				LocalObjectReference: corev1.LocalObjectReference{
-					Name: validator.Name,
-				},
-			},
-		},
-	}
-	volumes = append(volumes, configVolume)
-
 	// validator key/secret volumes
 	for i, keystore := range validator.Spec.Keystores {
 
@@ -258,7 +230,7 @@ func (r *ValidatorReconciler) createValidatorVolumes(validator *ethereum2v1alpha
 	return
 }
 
-// createValidatorVolumeMounts creates validator volume mounts
+// createValidatorVolumeMounts creates validator keystores and secrets volume mounts
 // secrets-dir/
 // |___validator-keys/
 // |		|__key-name
@@ -268,20 +240,9 @@ func (r *ValidatorReconciler) createValidatorVolumes(validator *ethereum2v1alpha
 // |		|_ key-name-1.txt
 // |		|_ key-name-n.txt
 // |___prysm-wallet
-// 			|_prysm-wallet-pasword.txt
+//
+//	|_prysm-wallet-pasword.txt
 func (r *ValidatorReconciler) createValidatorVolumeMounts(validator *ethereum2v1alpha1.Validator, homeDir string) (mounts []corev1.VolumeMount) {
-	dataMount := corev1.VolumeMount{
-		Name:      "data",
-		MountPath: shared.PathData(homeDir),
-	}
-	mounts = append(mounts, dataMount)
-
-	configMount := corev1.VolumeMount{
-		Name:      "config",
-		MountPath: shared.PathConfig(homeDir),
-	}
-	mounts = append(mounts, configMount)
-
 	for _, keystore := range validator.Spec.Keystores {
 
 		keystoreMount := corev1.VolumeMount{
@@ -322,15 +283,10 @@ func (r *ValidatorReconciler) createValidatorVolumeMounts(validator *ethereum2v1
 	return
 }
 
-// specStatefulset updates vvalidator statefulset spec
-func (r *ValidatorReconciler) specStatefulset(validator *ethereum2v1alpha1.Validator, sts *appsv1.StatefulSet, img string, command, args []string, homeDir string) {
-
-	sts.Labels = validator.GetLabels()
-
+// createInitContainers creates init containers importing validator keystores
+func (r *ValidatorReconciler) createInitContainers(validator *ethereum2v1alpha1.Validator, img, homeDir string, mounts []corev1.VolumeMount) []corev1.Container {
 	initContainers := []corev1.Container{}
 
-	mounts := r.createValidatorVolumeMounts(validator, homeDir)
-
 	// prysm: import validator keys from secrets dir
 	// keystores are imported into wallet after being decrypted with keystore secret
 	// then encrypted with wallet password
@@ -427,120 +383,74 @@ func (r *ValidatorReconciler) specStatefulset(validator *ethereum2v1alpha1.Valid
 		initContainers = append(initContainers, copyValidators)
 	}
 
-	sts.Spec = appsv1.StatefulSetSpec{
-		Selector: &metav1.LabelSelector{
-			MatchLabels: validator.GetLabels(),
-		},
-		Template: corev1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: validator.GetLabels(),
-			},
-			Spec: corev1.PodSpec{
-				SecurityContext: shared.SecurityContext(),
-				Containers: []corev1.Container{
-					{
-						Name:         "validator",
-						Image:        img,
-						Command:      command,
-						Args:         args,
-						VolumeMounts: mounts,
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(validator.Spec.Resources.CPU),
-								corev1.ResourceMemory: resource.MustParse(validator.Spec.Resources.Memory),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(validator.Spec.Resources.CPULimit),
-								corev1.ResourceMemory: resource.MustParse(validator.Spec.Resources.MemoryLimit),
-							},
-						},
-					},
-				},
-				InitContainers: initContainers,
-				Volumes:        r.createValidatorVolumes(validator),
-			},
-		},
-	}
+	return initContainers
 }
 
-// reconcileStatefulset reconciles validator statefulset
-func (r *ValidatorReconciler) reconcileStatefulset(ctx context.Context, validator *ethereum2v1alpha1.Validator) error {
-	sts := appsv1.StatefulSet{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      validator.Name,
-			Namespace: validator.Namespace,
-		},
-	}
-
+// Descriptor returns validator resources that are reconciled by the shared node reconciler
+func (r *ValidatorReconciler) Descriptor(validator *ethereum2v1alpha1.Validator) (*shared.NodeDescriptor, error) {
 	client, err := ethereum2Clients.NewClient(validator)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	img := client.Image()
-	command := client.Command()
-	args := client.Args()
-	homeDir := client.HomeDir()
 
-	_, err = ctrl.CreateOrUpdate(ctx, r.Client, &sts, func() error {
-		if err := ctrl.SetControllerReference(validator, &sts, r.Scheme); err != nil {
-			return err
-		}
-
-		r.specStatefulset(validator, &sts, img, command, args, homeDir)
-
-		return nil
-	})
-
-	return err
-}
+	homeDir := client.HomeDir()
 
-// specConfigmap updates validator configmap spec
-func (r *ValidatorReconciler) specConfigmap(validator *ethereum2v1alpha1.Validator, configmap *corev1.ConfigMap) {
-	if configmap.Data == nil {
-		configmap.Data = map[string]string{}
-	}
+	files := map[string]string{}
 
 	switch validator.Spec.Client {
 	case ethereum2v1alpha1.PrysmClient:
-		configmap.Data["prysm_import_keystore.sh"] = PrysmImportKeyStore
+		files["prysm_import_keystore.sh"] = PrysmImportKeyStore
 	case ethereum2v1alpha1.LighthouseClient:
-		configmap.Data["lighthouse_import_keystore.sh"] = LighthouseImportKeyStore
+		files["lighthouse_import_keystore.sh"] = LighthouseImportKeyStore
 	case ethereum2v1alpha1.NimbusClient:
-		configmap.Data["nimbus_copy_validators.sh"] = NimbusCopyValidators
+		files["nimbus_copy_validators.sh"] = NimbusCopyValidators
 	}
 
-}
-
-// reconcileConfigmap reconciles validator config map
-func (r *ValidatorReconciler) reconcileConfigmap(ctx context.Context, validator *ethereum2v1alpha1.Validator) error {
+	// validator client has no service unless metrics are enabled
+	var metrics *shared.MetricsEndpoint
+	var ports []corev1.ServicePort
 
-	configmap := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      validator.Name,
-			Namespace: validator.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, configmap, func() error {
-		if err := ctrl.SetControllerReference(validator, configmap, r.Scheme); err != nil {
-			log.FromContext(ctx).Error(err, "Unable to set controller reference on validator configmap")
-			return err
+	if validator.Spec.Metrics.Enabled {
+		metrics = &shared.MetricsEndpoint{
+			Port: "metrics",
+			Path: client.MetricsPath(),
 		}
+		ports = append(ports, corev1.ServicePort{
+			Name:       "metrics",
+			Port:       int32(validator.Spec.Metrics.Port),
+			TargetPort: intstr.FromInt(int(validator.Spec.Metrics.Port)),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
 
-		r.specConfigmap(validator, configmap)
+	descriptor := &shared.NodeDescriptor{
+		Client:        client,
+		ContainerName: "validator",
+		Resources:     &validator.Spec.Resources,
+		Scheduling:    &validator.Spec.Scheduling,
+		ExtraConfig:   &validator.Spec.ExtraConfig,
+		Disruption:    validator.Spec.Disruption,
+		NetworkPolicy: validator.Spec.NetworkPolicy,
+		Critical:      true,
+		Probes:        validator.Spec.Probes,
+		Ports:         ports,
+		ConfigFiles:   files,
+		Volumes:       r.createValidatorVolumes(validator),
+		VolumeMounts:  r.createValidatorVolumeMounts(validator, homeDir),
+		Metrics:       metrics,
+	}
 
-		return nil
-	})
+	descriptor.InitContainers = r.createInitContainers(validator, client.Image(), homeDir, shared.NodeVolumeMounts(descriptor))
 
-	return err
+	return descriptor, nil
 }
 
 // SetupWithManager adds reconciler to the manager
 func (r *ValidatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&ethereum2v1alpha1.Validator{}).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Complete(r)
+	b, err := shared.NodeControllerManagedBy(mgr, &ethereum2v1alpha1.Validator{})
+	if err != nil {
+		return err
+	}
+
+	return b.Complete(r)
 }
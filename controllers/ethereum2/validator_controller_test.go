@@ -8,12 +8,15 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 
 	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	ethereum2Clients "github.com/kotalco/kotal/clients/ethereum2"
 	"github.com/kotalco/kotal/controllers/shared"
 
@@ -46,6 +49,7 @@ var _ = Describe("Ethereum 2.0 validator client", func() {
 					SecretName: "my-validator",
 				},
 			},
+			NetworkPolicy: &sharedAPI.NetworkPolicy{},
 		}
 
 		toCreate := &ethereum2v1alpha1.Validator{
@@ -191,6 +195,23 @@ var _ = Describe("Ethereum 2.0 validator client", func() {
 			Expect(validatorPVC.Spec.Resources).To(Equal(expectedResources))
 		})
 
+		It("Should create pod disruption budget protecting validator pods", func() {
+			pdb := &policyv1.PodDisruptionBudget{}
+			Expect(k8sClient.Get(context.Background(), key, pdb)).To(Succeed())
+			Expect(pdb.GetOwnerReferences()).To(ContainElement(validatorOwnerReference))
+			Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(0))
+			Expect(pdb.Spec.Selector.MatchLabels).To(HaveKeyWithValue("app.kubernetes.io/instance", key.Name))
+		})
+
+		It("Should create network policy closing validator pods", func() {
+			policy := &networkingv1.NetworkPolicy{}
+			Expect(k8sClient.Get(context.Background(), key, policy)).To(Succeed())
+			Expect(policy.GetOwnerReferences()).To(ContainElement(validatorOwnerReference))
+			Expect(policy.Spec.PodSelector.MatchLabels).To(HaveKeyWithValue("app.kubernetes.io/instance", key.Name))
+			// validator client has no ports unless metrics are enabled
+			Expect(policy.Spec.Ingress).To(BeEmpty())
+		})
+
 		It(fmt.Sprintf("Should delete %s namespace", ns.Name), func() {
 			Expect(k8sClient.Delete(context.Background(), ns)).To(Succeed())
 		})
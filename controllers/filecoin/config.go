@@ -6,6 +6,7 @@ import (
 
 	"github.com/BurntSushi/toml"
 	filecoinv1alpha1 "github.com/kotalco/kotal/apis/filecoin/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
 )
 
 type API struct {
@@ -18,7 +19,8 @@ type Backup struct {
 }
 
 type LibP2P struct {
-	ListenAddresses []string
+	ListenAddresses   []string
+	AnnounceAddresses []string `toml:",omitempty"`
 }
 
 type Client struct {
@@ -50,6 +52,10 @@ func ConfigFromSpec(node *filecoinv1alpha1.Node) (config string, err error) {
 
 	c.LibP2P.ListenAddresses = []string{fmt.Sprintf("/ip4/%s/tcp/%d", node.Spec.P2PHost, node.Spec.P2PPort)}
 
+	if externalIP := node.Spec.Expose.GetExternalIP(); externalIP != "" {
+		c.LibP2P.AnnounceAddresses = []string{fmt.Sprintf("/ip4/%s/tcp/%d", externalIP, node.Spec.Expose.AnnouncedPort(node.Spec.P2PPort))}
+	}
+
 	if node.Spec.IPFSPeerEndpoint != "" {
 		c.Client = &Client{
 			UseIpfs:             true,
@@ -66,7 +72,7 @@ func ConfigFromSpec(node *filecoinv1alpha1.Node) (config string, err error) {
 		return
 	}
 
-	config = buff.String()
+	config, err = shared.MergeTOML(buff.String(), node.Spec.ConfigOverrides)
 
 	return
 }
@@ -8,12 +8,10 @@ import (
 	filecoinv1alpha1 "github.com/kotalco/kotal/apis/filecoin/v1alpha1"
 	filecoinClients "github.com/kotalco/kotal/clients/filecoin"
 	"github.com/kotalco/kotal/controllers/shared"
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -22,7 +20,8 @@ import (
 // NodeReconciler reconciles a Node object
 type NodeReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 var (
@@ -32,8 +31,19 @@ var (
 
 // +kubebuilder:rbac:groups=filecoin.kotal.io,resources=nodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=filecoin.kotal.io,resources=nodes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=filecoin.kotal.io,resources=nodes/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
 // +kubebuilder:rbac:groups=core,resources=configmaps;services;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=watch;get;list
+// +kubebuilder:rbac:groups=core,resources=nodes/proxy,verbs=get
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=watch;get;list
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // Reconcile reconciles Filecoin network node
 func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
@@ -44,26 +54,35 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
-	// default the node if webhooks are disabled
-	if !shared.IsWebhookEnabled() {
-		node.Default()
-	}
+	defer func() { shared.RecordError(r.Recorder, &node, err) }()
+	defer func() { shared.ObserveReconcile(r.Scheme, &node, string(node.Spec.Network), err) }()
 
-	shared.UpdateLabels(&node, "lotus")
+	if !node.DeletionTimestamp.IsZero() {
+		result, err = shared.FinalizeStorage(ctx, r.Client, &node, node.Spec.RetentionPolicy, 1)
+		return
+	}
 
-	if err = r.reconcileService(ctx, &node); err != nil {
+	if err = shared.EnsureRetentionFinalizer(ctx, r.Client, &node); err != nil {
 		return
 	}
 
-	if err = r.reconcileConfigmap(ctx, &node); err != nil {
+	if result.RequeueAfter, err = shared.AutoExpandStorage(ctx, r.Client, r.Recorder, &node, &node.Spec.Resources); err != nil {
 		return
 	}
 
-	if err = r.reconcilePVC(ctx, &node); err != nil {
+	// default the node if webhooks are disabled
+	if !shared.IsWebhookEnabled() {
+		node.Default()
+	}
+
+	shared.UpdateLabels(&node, "lotus")
+
+	descriptor, err := r.Descriptor(&node)
+	if err != nil {
 		return
 	}
 
-	if err = r.reconcileStatefulSet(ctx, &node); err != nil {
+	if err = shared.ReconcileNode(ctx, r.Client, r.Scheme, r.Recorder, &node, descriptor); err != nil {
 		return
 	}
 
@@ -78,6 +97,10 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 func (r *NodeReconciler) updateStatus(ctx context.Context, node *filecoinv1alpha1.Node) error {
 	node.Status.Client = "lotus"
 
+	if err := shared.UpdateStatus(ctx, r.Client, node, &node.Status.Status); err != nil {
+		return err
+	}
+
 	if err := r.Status().Update(ctx, node); err != nil {
 		log.FromContext(ctx).Error(err, "unable to update filecoin node status")
 		return err
@@ -86,147 +109,18 @@ func (r *NodeReconciler) updateStatus(ctx context.Context, node *filecoinv1alpha
 	return nil
 }
 
-// reconcilePVC reconciles node pvc
-func (r *NodeReconciler) reconcilePVC(ctx context.Context, node *filecoinv1alpha1.Node) error {
-	pvc := &corev1.PersistentVolumeClaim{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, pvc, func() error {
-		if err := ctrl.SetControllerReference(node, pvc, r.Scheme); err != nil {
-			return err
-		}
-		r.specPVC(node, pvc)
-		return nil
-	})
-
-	return err
-}
-
-// specPVC updates node PVC spec
-func (r *NodeReconciler) specPVC(node *filecoinv1alpha1.Node, pvc *corev1.PersistentVolumeClaim) {
-	request := corev1.ResourceList{
-		corev1.ResourceStorage: resource.MustParse(node.Spec.Resources.Storage),
-	}
-
-	// spec is immutable after creation except resources.requests for bound claims
-	if !pvc.CreationTimestamp.IsZero() {
-		pvc.Spec.Resources.Requests = request
-		return
-	}
-
-	pvc.ObjectMeta.Labels = node.Labels
-
-	pvc.Spec = corev1.PersistentVolumeClaimSpec{
-		AccessModes: []corev1.PersistentVolumeAccessMode{
-			corev1.ReadWriteOnce,
-		},
-		Resources: corev1.ResourceRequirements{
-			Requests: request,
-		},
-		StorageClassName: node.Spec.Resources.StorageClass,
-	}
-}
-
-// reconcileService reconciles node service
-func (r *NodeReconciler) reconcileService(ctx context.Context, node *filecoinv1alpha1.Node) error {
-
-	svc := &corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, svc, func() error {
-		if err := ctrl.SetControllerReference(node, svc, r.Scheme); err != nil {
-			return err
-		}
-		r.specService(node, svc)
-		return nil
-	})
-
-	return err
-}
-
-// specConfigmap updates node statefulset spec
-func (r *NodeReconciler) specConfigmap(node *filecoinv1alpha1.Node, configmap *corev1.ConfigMap, configToml string) {
-	configmap.ObjectMeta.Labels = node.Labels
-
-	if configmap.Data == nil {
-		configmap.Data = map[string]string{}
-	}
-
-	configmap.Data["config.toml"] = configToml
-	configmap.Data["copy_config_toml.sh"] = CopyConfigToml
-
-}
-
-// reconcileConfigmap reconciles node configmap
-func (r *NodeReconciler) reconcileConfigmap(ctx context.Context, node *filecoinv1alpha1.Node) error {
-
-	configmap := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
+// Descriptor returns filecoin node resources that are reconciled by the shared node reconciler
+func (r *NodeReconciler) Descriptor(node *filecoinv1alpha1.Node) (*shared.NodeDescriptor, error) {
+	client := filecoinClients.NewClient(node)
+	homeDir := client.HomeDir()
 
 	// filecoin generates config.toml file from node spec
 	configToml, err := ConfigFromSpec(node)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = ctrl.CreateOrUpdate(ctx, r.Client, configmap, func() error {
-		if err := ctrl.SetControllerReference(node, configmap, r.Scheme); err != nil {
-			return err
-		}
-		r.specConfigmap(node, configmap, configToml)
-		return nil
-	})
-
-	return err
-}
-
-// reconcileStatefulSet reconciles node stateful set
-func (r *NodeReconciler) reconcileStatefulSet(ctx context.Context, node *filecoinv1alpha1.Node) error {
-	sts := &appsv1.StatefulSet{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	client := filecoinClients.NewClient(node)
-	img := client.Image()
-	args := client.Args()
-	env := client.Env()
-	homeDir := client.HomeDir()
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, sts, func() error {
-		if err := ctrl.SetControllerReference(node, sts, r.Scheme); err != nil {
-			return err
-		}
-		if err := r.specStatefulSet(node, sts, img, homeDir, args, env); err != nil {
-			return err
-		}
-		return nil
-	})
-
-	return err
-}
-
-// specService updates node statefulset spec
-func (r *NodeReconciler) specService(node *filecoinv1alpha1.Node, svc *corev1.Service) {
-	labels := node.Labels
-
-	svc.ObjectMeta.Labels = labels
-
-	svc.Spec.Ports = []corev1.ServicePort{
+	ports := []corev1.ServicePort{
 		{
 			Name:       "p2p",
 			Port:       int32(node.Spec.P2PPort),
@@ -235,121 +129,75 @@ func (r *NodeReconciler) specService(node *filecoinv1alpha1.Node, svc *corev1.Se
 		},
 	}
 
+	endpoints := []shared.IngressEndpoint{}
+
 	if node.Spec.API {
-		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+		ports = append(ports, corev1.ServicePort{
 			Name:       "api",
 			Port:       int32(node.Spec.APIPort),
 			TargetPort: intstr.FromInt(int(node.Spec.APIPort)),
 			Protocol:   corev1.ProtocolTCP,
 		})
+		endpoints = append(endpoints, shared.IngressEndpoint{Name: "api", Port: node.Spec.APIPort})
 	}
 
-	svc.Spec.Selector = labels
-}
-
-// specStatefulSet updates node statefulset spec
-func (r *NodeReconciler) specStatefulSet(node *filecoinv1alpha1.Node, sts *appsv1.StatefulSet, img, homeDir string, args []string, env []corev1.EnvVar) error {
-	labels := node.Labels
+	var metrics *shared.MetricsEndpoint
 
-	sts.ObjectMeta.Labels = labels
+	if node.Spec.Metrics.Enabled {
+		metrics = &shared.MetricsEndpoint{
+			Port: "api",
+			Path: client.MetricsPath(),
+		}
+	}
 
-	sts.Spec = appsv1.StatefulSetSpec{
-		Selector: &metav1.LabelSelector{
-			MatchLabels: labels,
+	descriptor := &shared.NodeDescriptor{
+		Client:        client,
+		Resources:     &node.Spec.Resources,
+		Scheduling:    &node.Spec.Scheduling,
+		ExtraConfig:   &node.Spec.ExtraConfig,
+		Disruption:    node.Spec.Disruption,
+		NetworkPolicy: node.Spec.NetworkPolicy,
+		Probes:        node.Spec.Probes,
+		Bootstrap:     node.Spec.Bootstrap,
+		Ports:         ports,
+		Expose:        node.Spec.Expose,
+		P2PPorts:      []string{"p2p"},
+		ConfigFiles: map[string]string{
+			"config.toml":         configToml,
+			"copy_config_toml.sh": CopyConfigToml,
 		},
-		ServiceName: node.Name,
-		Template: corev1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: labels,
+		Metrics:          metrics,
+		Ingress:          node.Spec.Ingress,
+		IngressEndpoints: endpoints,
+	}
+
+	descriptor.InitContainers = append(descriptor.InitContainers, corev1.Container{
+		Name:  "copy-config-toml",
+		Image: shared.BusyboxImage,
+		Env: []corev1.EnvVar{
+			{
+				Name:  EnvDataPath,
+				Value: shared.PathData(homeDir),
 			},
-			Spec: corev1.PodSpec{
-				SecurityContext: shared.SecurityContext(),
-				InitContainers: []corev1.Container{
-					{
-						Name:  "copy-config-toml",
-						Image: shared.BusyboxImage,
-						Env: []corev1.EnvVar{
-							{
-								Name:  EnvDataPath,
-								Value: shared.PathData(homeDir),
-							},
-							{
-								Name:  EnvConfigPath,
-								Value: shared.PathConfig(homeDir),
-							},
-						},
-						Command: []string{"/bin/sh"},
-						Args:    []string{fmt.Sprintf("%s/copy_config_toml.sh", shared.PathConfig(homeDir))},
-						VolumeMounts: []corev1.VolumeMount{
-							{
-								Name:      "data",
-								MountPath: shared.PathData(homeDir),
-							},
-							{
-								Name:      "config",
-								MountPath: shared.PathConfig(homeDir),
-							},
-						},
-					},
-				},
-				Containers: []corev1.Container{
-					{
-						Name:  "node",
-						Image: img,
-						Args:  args,
-						Env:   env,
-						VolumeMounts: []corev1.VolumeMount{
-							{
-								Name:      "data",
-								MountPath: shared.PathData(homeDir),
-							},
-						},
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Spec.Resources.CPU),
-								corev1.ResourceMemory: resource.MustParse(node.Spec.Resources.Memory),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Spec.Resources.CPULimit),
-								corev1.ResourceMemory: resource.MustParse(node.Spec.Resources.MemoryLimit),
-							},
-						},
-					},
-				},
-				Volumes: []corev1.Volume{
-					{
-						Name: "data",
-						VolumeSource: corev1.VolumeSource{
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-								ClaimName: node.Name,
-							},
-						},
-					},
-					{
-						Name: "config",
-						VolumeSource: corev1.VolumeSource{
-							ConfigMap: &corev1.ConfigMapVolumeSource{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: node.Name,
-								},
-							},
-						},
-					},
-				},
+			{
+				Name:  EnvConfigPath,
+				Value: shared.PathConfig(homeDir),
 			},
 		},
-	}
+		Command:      []string{"/bin/sh"},
+		Args:         []string{fmt.Sprintf("%s/copy_config_toml.sh", shared.PathConfig(homeDir))},
+		VolumeMounts: shared.NodeVolumeMounts(descriptor),
+	})
 
-	return nil
+	return descriptor, nil
 }
 
 // SetupWithManager adds reconciler to the manager
 func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&filecoinv1alpha1.Node{}).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&corev1.Service{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Complete(r)
+	b, err := shared.NodeControllerManagedBy(mgr, &filecoinv1alpha1.Node{})
+	if err != nil {
+		return err
+	}
+
+	return b.Complete(r)
 }
@@ -80,8 +80,9 @@ var _ = BeforeSuite(func() {
 
 	// start node reconciler
 	nodeReconciler := &NodeReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: scheme.Scheme,
+		Client:   k8sManager.GetClient(),
+		Scheme:   scheme.Scheme,
+		Recorder: k8sManager.GetEventRecorderFor("filecoin-node-controller"),
 	}
 	nodeReconciler.SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
@@ -6,12 +6,10 @@ import (
 	"fmt"
 	"strings"
 
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -24,7 +22,8 @@ import (
 // ClusterPeerReconciler reconciles a ClusterPeer object
 type ClusterPeerReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 var (
@@ -34,8 +33,19 @@ var (
 
 // +kubebuilder:rbac:groups=ipfs.kotal.io,resources=clusterpeers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ipfs.kotal.io,resources=clusterpeers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ipfs.kotal.io,resources=clusterpeers/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
 // +kubebuilder:rbac:groups=core,resources=configmaps;services;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=watch;get;list
+// +kubebuilder:rbac:groups=core,resources=nodes/proxy,verbs=get
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=watch;get;list
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 func (r *ClusterPeerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 
@@ -46,26 +56,35 @@ func (r *ClusterPeerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return
 	}
 
-	// default the cluster peer if webhooks are disabled
-	if !shared.IsWebhookEnabled() {
-		peer.Default()
-	}
+	defer func() { shared.RecordError(r.Recorder, &peer, err) }()
+	defer func() { shared.ObserveReconcile(r.Scheme, &peer, "", err) }()
 
-	shared.UpdateLabels(&peer, "ipfs-cluster-service")
+	if !peer.DeletionTimestamp.IsZero() {
+		result, err = shared.FinalizeStorage(ctx, r.Client, &peer, peer.Spec.RetentionPolicy, 1)
+		return
+	}
 
-	if err = r.reconcileService(ctx, &peer); err != nil {
+	if err = shared.EnsureRetentionFinalizer(ctx, r.Client, &peer); err != nil {
 		return
 	}
 
-	if err = r.reconcilePVC(ctx, &peer); err != nil {
+	if result.RequeueAfter, err = shared.AutoExpandStorage(ctx, r.Client, r.Recorder, &peer, &peer.Spec.Resources); err != nil {
 		return
 	}
 
-	if err = r.reconcileConfigmap(ctx, &peer); err != nil {
+	// default the cluster peer if webhooks are disabled
+	if !shared.IsWebhookEnabled() {
+		peer.Default()
+	}
+
+	shared.UpdateLabels(&peer, "ipfs-cluster-service")
+
+	descriptor, err := r.Descriptor(&peer)
+	if err != nil {
 		return
 	}
 
-	if err = r.reconcileStatefulset(ctx, &peer); err != nil {
+	if err = shared.ReconcileNode(ctx, r.Client, r.Scheme, r.Recorder, &peer, descriptor); err != nil {
 		return
 	}
 
@@ -81,6 +100,10 @@ func (r *ClusterPeerReconciler) updateStatus(ctx context.Context, peer *ipfsv1al
 	// TODO: update after multi-client support
 	peer.Status.Client = "ipfs-cluster-service"
 
+	if err := shared.UpdateStatus(ctx, r.Client, peer, &peer.Status.Status); err != nil {
+		return err
+	}
+
 	if err := r.Status().Update(ctx, peer); err != nil {
 		log.FromContext(ctx).Error(err, "unable to update cluster peer status")
 		return err
@@ -89,43 +112,26 @@ func (r *ClusterPeerReconciler) updateStatus(ctx context.Context, peer *ipfsv1al
 	return nil
 }
 
-// reconcileService reconciles ipfs peer service
-func (r *ClusterPeerReconciler) reconcileService(ctx context.Context, peer *ipfsv1alpha1.ClusterPeer) error {
-	svc := &corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      peer.Name,
-			Namespace: peer.Namespace,
-		},
+// Descriptor returns IPFS cluster peer resources that are reconciled by the shared node reconciler
+func (r *ClusterPeerReconciler) Descriptor(peer *ipfsv1alpha1.ClusterPeer) (*shared.NodeDescriptor, error) {
+	client, err := ipfsClients.NewClient(peer)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, svc, func() error {
-		if err := ctrl.SetControllerReference(peer, svc, r.Scheme); err != nil {
-			return err
-		}
-		r.specService(peer, svc)
-		return nil
-	})
-
-	return err
-}
-
-// specService updates ipfs peer service spec
-func (r *ClusterPeerReconciler) specService(peer *ipfsv1alpha1.ClusterPeer, svc *corev1.Service) {
-	labels := peer.Labels
-
-	svc.ObjectMeta.Labels = labels
+	homeDir := client.HomeDir()
 
-	svc.Spec.Ports = []corev1.ServicePort{
+	ports := []corev1.ServicePort{
 		{
 			Name:       "swarm",
-			Port:       9096,
-			TargetPort: intstr.FromInt(9096),
+			Port:       ipfsClients.GoIPFSClusterSwarmPort,
+			TargetPort: intstr.FromInt(ipfsClients.GoIPFSClusterSwarmPort),
 			Protocol:   corev1.ProtocolTCP,
 		},
 		{
 			Name:       "swarm-udp",
-			Port:       9096,
-			TargetPort: intstr.FromInt(9096),
+			Port:       ipfsClients.GoIPFSClusterSwarmPort,
+			TargetPort: intstr.FromInt(ipfsClients.GoIPFSClusterSwarmPort),
 			Protocol:   corev1.ProtocolUDP,
 		},
 		{
@@ -160,125 +166,21 @@ func (r *ClusterPeerReconciler) specService(peer *ipfsv1alpha1.ClusterPeer, svc
 		},
 	}
 
-	svc.Spec.Selector = labels
-}
-
-// reconcileConfigmap reconciles IPFS cluster peer configmap
-func (r *ClusterPeerReconciler) reconcileConfigmap(ctx context.Context, peer *ipfsv1alpha1.ClusterPeer) error {
-	config := corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      peer.Name,
-			Namespace: peer.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, &config, func() error {
-		if err := ctrl.SetControllerReference(peer, &config, r.Scheme); err != nil {
-			return err
-		}
-
-		r.specConfigmap(peer, &config)
-		return nil
-	})
-
-	return err
-}
-
-// specConfigmap updates IPFS cluster peer configmap spec
-func (r *ClusterPeerReconciler) specConfigmap(peer *ipfsv1alpha1.ClusterPeer, config *corev1.ConfigMap) {
-	config.ObjectMeta.Labels = peer.Labels
-
-	if config.Data == nil {
-		config.Data = make(map[string]string)
-	}
-
-	config.Data["init_ipfs_cluster_config.sh"] = initIPFSClusterConfig
-}
-
-// reconcilePVC reconciles IPFS cluster peer persistent volume claim
-func (r *ClusterPeerReconciler) reconcilePVC(ctx context.Context, peer *ipfsv1alpha1.ClusterPeer) error {
-	pvc := corev1.PersistentVolumeClaim{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      peer.Name,
-			Namespace: peer.Namespace,
-		},
-	}
+	var metrics *shared.MetricsEndpoint
 
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, &pvc, func() error {
-		if err := ctrl.SetControllerReference(peer, &pvc, r.Scheme); err != nil {
-			return err
+	if peer.Spec.Metrics.Enabled {
+		ports = append(ports, corev1.ServicePort{
+			Name:       "metrics",
+			Port:       int32(peer.Spec.Metrics.Port),
+			TargetPort: intstr.FromInt(int(peer.Spec.Metrics.Port)),
+			Protocol:   corev1.ProtocolTCP,
+		})
+		metrics = &shared.MetricsEndpoint{
+			Port: "metrics",
+			Path: client.MetricsPath(),
 		}
-
-		r.specPVC(peer, &pvc)
-		return nil
-	})
-
-	return err
-}
-
-// specPVC updates IPFS cluster peer persistent volume claim
-func (r *ClusterPeerReconciler) specPVC(peer *ipfsv1alpha1.ClusterPeer, pvc *corev1.PersistentVolumeClaim) {
-	request := corev1.ResourceList{
-		corev1.ResourceStorage: resource.MustParse(peer.Spec.Resources.Storage),
 	}
 
-	// spec is immutable after creation except resources.requests for bound claims
-	if !pvc.CreationTimestamp.IsZero() {
-		pvc.Spec.Resources.Requests = request
-		return
-	}
-
-	pvc.ObjectMeta.Labels = peer.Labels
-	pvc.Spec = corev1.PersistentVolumeClaimSpec{
-		AccessModes: []corev1.PersistentVolumeAccessMode{
-			corev1.ReadWriteOnce,
-		},
-		Resources: corev1.ResourceRequirements{
-			Requests: request,
-		},
-		StorageClassName: peer.Spec.Resources.StorageClass,
-	}
-}
-
-// reconcileStatefulset reconciles IPFS cluster peer statefulset
-func (r *ClusterPeerReconciler) reconcileStatefulset(ctx context.Context, peer *ipfsv1alpha1.ClusterPeer) error {
-	sts := appsv1.StatefulSet{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      peer.Name,
-			Namespace: peer.Namespace,
-		},
-	}
-
-	client, err := ipfsClients.NewClient(peer)
-	if err != nil {
-		return err
-	}
-
-	img := client.Image()
-	command := client.Command()
-	args := client.Args()
-	env := client.Env()
-	homeDir := client.HomeDir()
-
-	_, err = ctrl.CreateOrUpdate(ctx, r.Client, &sts, func() error {
-		if err := ctrl.SetControllerReference(peer, &sts, r.Scheme); err != nil {
-			return err
-		}
-
-		r.specStatefulset(peer, &sts, img, homeDir, env, command, args)
-
-		return nil
-	})
-
-	return err
-}
-
-// specStatefulset updates IPFS cluster peer statefulset
-func (r *ClusterPeerReconciler) specStatefulset(peer *ipfsv1alpha1.ClusterPeer, sts *appsv1.StatefulSet, img, homeDir string, env []corev1.EnvVar, command, args []string) {
-	labels := peer.Labels
-
-	sts.Labels = labels
-
 	// environment variables required by `ipfs-cluster-service init`
 	initClusterPeerENV := []corev1.EnvVar{
 		{
@@ -332,93 +234,47 @@ func (r *ClusterPeerReconciler) specStatefulset(peer *ipfsv1alpha1.ClusterPeer,
 		})
 	}
 
-	sts.Spec = appsv1.StatefulSetSpec{
-		Selector: &metav1.LabelSelector{
-			MatchLabels: labels,
+	descriptor := &shared.NodeDescriptor{
+		Client:        client,
+		ContainerName: "cluster-peer",
+		Resources:     &peer.Spec.Resources,
+		Scheduling:    &peer.Spec.Scheduling,
+		ExtraConfig:   &peer.Spec.ExtraConfig,
+		Disruption:    peer.Spec.Disruption,
+		NetworkPolicy: peer.Spec.NetworkPolicy,
+		Probes:        peer.Spec.Probes,
+		Ports:         ports,
+		Expose:        peer.Spec.Expose,
+		P2PPorts:      []string{"swarm", "swarm-udp"},
+		ConfigFiles: map[string]string{
+			"init_ipfs_cluster_config.sh": initIPFSClusterConfig,
 		},
-		Template: corev1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: labels,
-			},
-			Spec: corev1.PodSpec{
-				SecurityContext: shared.SecurityContext(),
-				InitContainers: []corev1.Container{
-					{
-						Name:    "init-cluster-peer",
-						Image:   img,
-						Command: []string{"/bin/sh"},
-						Env:     initClusterPeerENV,
-						Args: []string{
-							fmt.Sprintf("%s/init_ipfs_cluster_config.sh", shared.PathConfig(homeDir)),
-						},
-						VolumeMounts: []corev1.VolumeMount{
-							{
-								Name:      "data",
-								MountPath: shared.PathData(homeDir),
-							},
-							{
-								Name:      "config",
-								MountPath: shared.PathConfig(homeDir),
-							},
-						},
-					},
-				},
-				Containers: []corev1.Container{
-					{
-						Name:    "cluster-peer",
-						Image:   img,
-						Command: command,
-						Env:     env,
-						Args:    args,
-						VolumeMounts: []corev1.VolumeMount{
-							{
-								Name:      "data",
-								MountPath: shared.PathData(homeDir),
-							},
-						},
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(peer.Spec.CPU),
-								corev1.ResourceMemory: resource.MustParse(peer.Spec.Memory),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(peer.Spec.CPULimit),
-								corev1.ResourceMemory: resource.MustParse(peer.Spec.MemoryLimit),
-							},
-						},
-					},
-				},
-				Volumes: []corev1.Volume{
-					{
-						Name: "data",
-						VolumeSource: corev1.VolumeSource{
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-								ClaimName: peer.Name,
-							},
-						},
-					},
-					{
-						Name: "config",
-						VolumeSource: corev1.VolumeSource{
-							ConfigMap: &corev1.ConfigMapVolumeSource{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: peer.Name,
-								},
-							},
-						},
-					},
-				},
-			},
+		Metrics: metrics,
+		Ingress: peer.Spec.Ingress,
+		IngressEndpoints: []shared.IngressEndpoint{
+			{Name: "rest-api", Port: 9094},
 		},
 	}
+
+	descriptor.InitContainers = append(descriptor.InitContainers, corev1.Container{
+		Name:    "init-cluster-peer",
+		Image:   client.Image(),
+		Command: []string{"/bin/sh"},
+		Env:     initClusterPeerENV,
+		Args: []string{
+			fmt.Sprintf("%s/init_ipfs_cluster_config.sh", shared.PathConfig(homeDir)),
+		},
+		VolumeMounts: shared.NodeVolumeMounts(descriptor),
+	})
+
+	return descriptor, nil
 }
 
 func (r *ClusterPeerReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&ipfsv1alpha1.ClusterPeer{}).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Owns(&corev1.Service{}).
-		Owns(&corev1.ConfigMap{}).
-		Complete(r)
+	b, err := shared.NodeControllerManagedBy(mgr, &ipfsv1alpha1.ClusterPeer{})
+	if err != nil {
+		return err
+	}
+
+	return b.Complete(r)
 }
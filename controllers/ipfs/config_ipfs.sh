@@ -4,6 +4,7 @@ set -e
 
 ipfs config Addresses.API /ip4/$IPFS_API_HOST/tcp/$IPFS_API_PORT
 ipfs config Addresses.Gateway /ip4/$IPFS_GATEWAY_HOST/tcp/$IPFS_GATEWAY_PORT
+ipfs config --json Addresses.Announce "$IPFS_ANNOUNCE_ADDRESSES"
 
 export IFS=";"
 for profile in $IPFS_PROFILES; do
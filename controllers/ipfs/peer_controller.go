@@ -3,15 +3,14 @@ package controllers
 import (
 	"context"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"strings"
 
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -24,7 +23,8 @@ import (
 // PeerReconciler reconciles a Peer object
 type PeerReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 var (
@@ -38,8 +38,19 @@ var (
 
 // +kubebuilder:rbac:groups=ipfs.kotal.io,resources=peers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ipfs.kotal.io,resources=peers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ipfs.kotal.io,resources=peers/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
 // +kubebuilder:rbac:groups=core,resources=services;configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=watch;get;list
+// +kubebuilder:rbac:groups=core,resources=nodes/proxy,verbs=get
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=watch;get;list
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 func (r *PeerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	var peer ipfsv1alpha1.Peer
@@ -49,26 +60,35 @@ func (r *PeerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
-	// default the peer if webhooks are disabled
-	if !shared.IsWebhookEnabled() {
-		peer.Default()
-	}
+	defer func() { shared.RecordError(r.Recorder, &peer, err) }()
+	defer func() { shared.ObserveReconcile(r.Scheme, &peer, "", err) }()
 
-	shared.UpdateLabels(&peer, "go-ipfs")
+	if !peer.DeletionTimestamp.IsZero() {
+		result, err = shared.FinalizeStorage(ctx, r.Client, &peer, peer.Spec.RetentionPolicy, 1)
+		return
+	}
 
-	if err = r.reconcileConfigmap(ctx, &peer); err != nil {
+	if err = shared.EnsureRetentionFinalizer(ctx, r.Client, &peer); err != nil {
 		return
 	}
 
-	if err = r.reconcileService(ctx, &peer); err != nil {
+	if result.RequeueAfter, err = shared.AutoExpandStorage(ctx, r.Client, r.Recorder, &peer, &peer.Spec.Resources); err != nil {
 		return
 	}
 
-	if err = r.reconcilePVC(ctx, &peer); err != nil {
+	// default the peer if webhooks are disabled
+	if !shared.IsWebhookEnabled() {
+		peer.Default()
+	}
+
+	shared.UpdateLabels(&peer, "go-ipfs")
+
+	descriptor, err := r.Descriptor(&peer)
+	if err != nil {
 		return
 	}
 
-	if err = r.reconcileStatefulSet(ctx, &peer); err != nil {
+	if err = shared.ReconcileNode(ctx, r.Client, r.Scheme, r.Recorder, &peer, descriptor); err != nil {
 		return
 	}
 
@@ -84,6 +104,10 @@ func (r *PeerReconciler) updateStatus(ctx context.Context, peer *ipfsv1alpha1.Pe
 	// TODO: update after multi-client support
 	peer.Status.Client = "go-ipfs"
 
+	if err := shared.UpdateStatus(ctx, r.Client, peer, &peer.Status.Status); err != nil {
+		return err
+	}
+
 	if err := r.Status().Update(ctx, peer); err != nil {
 		log.FromContext(ctx).Error(err, "unable to update peer status")
 		return err
@@ -92,217 +116,78 @@ func (r *PeerReconciler) updateStatus(ctx context.Context, peer *ipfsv1alpha1.Pe
 	return nil
 }
 
-// reconcileService reconciles ipfs peer service
-func (r *PeerReconciler) reconcileService(ctx context.Context, peer *ipfsv1alpha1.Peer) error {
-	svc := &corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      peer.Name,
-			Namespace: peer.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, svc, func() error {
-		if err := ctrl.SetControllerReference(peer, svc, r.Scheme); err != nil {
-			return err
-		}
-		r.specService(peer, svc)
-		return nil
-	})
-
-	return err
-}
-
-// specService updates ipfs peer service spec
-func (r *PeerReconciler) specService(peer *ipfsv1alpha1.Peer, svc *corev1.Service) {
-	labels := peer.Labels
-
-	svc.ObjectMeta.Labels = labels
-
-	svc.Spec.Ports = []corev1.ServicePort{
-		{
-			Name:       "swarm",
-			Port:       4001,
-			TargetPort: intstr.FromInt(4001),
-			Protocol:   corev1.ProtocolTCP,
-		},
-		{
-			Name:       "swarm-udp",
-			Port:       4001,
-			TargetPort: intstr.FromInt(4001),
-			Protocol:   corev1.ProtocolUDP,
-		},
-		{
-			Name:       "api",
-			Port:       int32(peer.Spec.APIPort),
-			TargetPort: intstr.FromInt(int(peer.Spec.APIPort)),
-			Protocol:   corev1.ProtocolTCP,
-		},
-		{
-			Name:       "gateway",
-			Port:       int32(peer.Spec.GatewayPort),
-			TargetPort: intstr.FromInt(int(peer.Spec.GatewayPort)),
-			Protocol:   corev1.ProtocolTCP,
-		},
-	}
-
-	svc.Spec.Selector = labels
-}
-
-// reconcileConfigmap reconciles ipfs peer config map
-func (r *PeerReconciler) reconcileConfigmap(ctx context.Context, peer *ipfsv1alpha1.Peer) error {
-	config := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      peer.Name,
-			Namespace: peer.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, config, func() error {
-		if err := ctrl.SetControllerReference(peer, config, r.Scheme); err != nil {
-			return err
-		}
-
-		r.specConfigmap(peer, config)
-
-		return nil
-	})
-
-	return err
-
-}
-
-// specConfigmap updates ipfs peer config spec
-func (r *PeerReconciler) specConfigmap(peer *ipfsv1alpha1.Peer, config *corev1.ConfigMap) {
-	config.ObjectMeta.Labels = peer.Labels
-	if config.Data == nil {
-		config.Data = make(map[string]string)
-	}
-	config.Data["init_ipfs_config.sh"] = initIPFSConfigScript
-	config.Data["copy_swarm_key.sh"] = copySwarmKeyScript
-	config.Data["config_ipfs.sh"] = configIPFSScript
-}
-
-// reconcilePVC reconciles ipfs peer persistent volume claim
-func (r *PeerReconciler) reconcilePVC(ctx context.Context, peer *ipfsv1alpha1.Peer) error {
-	pvc := &corev1.PersistentVolumeClaim{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      peer.Name,
-			Namespace: peer.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, pvc, func() error {
-		if err := ctrl.SetControllerReference(peer, pvc, r.Scheme); err != nil {
-			return err
-		}
-
-		r.specPVC(peer, pvc)
-
-		return nil
-	})
-
-	return err
-}
-
-// specPVC updates ipfs peer persistent volume claim
-func (r *PeerReconciler) specPVC(peer *ipfsv1alpha1.Peer, pvc *corev1.PersistentVolumeClaim) {
-	request := corev1.ResourceList{
-		corev1.ResourceStorage: resource.MustParse(peer.Spec.Resources.Storage),
-	}
-
-	// spec is immutable after creation except resources.requests for bound claims
-	if !pvc.CreationTimestamp.IsZero() {
-		pvc.Spec.Resources.Requests = request
-		return
-	}
-
-	pvc.ObjectMeta.Labels = peer.Labels
-	pvc.Spec = corev1.PersistentVolumeClaimSpec{
-		AccessModes: []corev1.PersistentVolumeAccessMode{
-			corev1.ReadWriteOnce,
-		},
-		Resources: corev1.ResourceRequirements{
-			Requests: request,
-		},
-		StorageClassName: peer.Spec.Resources.StorageClass,
-	}
-}
-
-// reconcileStatefulSet reconciles ipfs peer statefulset
-func (r *PeerReconciler) reconcileStatefulSet(ctx context.Context, peer *ipfsv1alpha1.Peer) error {
-
-	sts := &appsv1.StatefulSet{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      peer.Name,
-			Namespace: peer.Namespace,
-		},
-	}
-
+// Descriptor returns ipfs peer resources that are reconciled by the shared node reconciler
+func (r *PeerReconciler) Descriptor(peer *ipfsv1alpha1.Peer) (*shared.NodeDescriptor, error) {
 	client, err := ipfsClients.NewClient(peer)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	img := client.Image()
-	command := client.Command()
-	env := client.Env()
-	args := client.Args()
 	homeDir := client.HomeDir()
 
-	_, err = ctrl.CreateOrUpdate(ctx, r.Client, sts, func() error {
-		if err := ctrl.SetControllerReference(peer, sts, r.Scheme); err != nil {
-			return err
-		}
-		r.specStatefulSet(peer, sts, img, homeDir, env, command, args)
-		return nil
-	})
-
-	return err
-}
-
-// specStatefulSet updates ipfs peer statefulset spec
-func (r *PeerReconciler) specStatefulSet(peer *ipfsv1alpha1.Peer, sts *appsv1.StatefulSet, img, homeDir string, env []corev1.EnvVar, command, args []string) {
-	labels := peer.Labels
+	var metrics *shared.MetricsEndpoint
 
-	sts.ObjectMeta.Labels = labels
+	if peer.Spec.Metrics.Enabled {
+		metrics = &shared.MetricsEndpoint{
+			Port: "api",
+			Path: client.MetricsPath(),
+		}
+	}
 
-	volumes := []corev1.Volume{
-		{
-			Name: "data",
-			VolumeSource: corev1.VolumeSource{
-				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-					ClaimName: peer.Name,
-				},
+	descriptor := &shared.NodeDescriptor{
+		Client:        client,
+		ContainerName: "peer",
+		Resources:     &peer.Spec.Resources,
+		Scheduling:    &peer.Spec.Scheduling,
+		ExtraConfig:   &peer.Spec.ExtraConfig,
+		Disruption:    peer.Spec.Disruption,
+		NetworkPolicy: peer.Spec.NetworkPolicy,
+		Probes:        peer.Spec.Probes,
+		Expose:        peer.Spec.Expose,
+		Ports: []corev1.ServicePort{
+			{
+				Name:       "swarm",
+				Port:       ipfsClients.GoIPFSSwarmPort,
+				TargetPort: intstr.FromInt(ipfsClients.GoIPFSSwarmPort),
+				Protocol:   corev1.ProtocolTCP,
 			},
-		},
-		{
-			Name: "config",
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: peer.Name,
-					},
-				},
+			{
+				Name:       "swarm-udp",
+				Port:       ipfsClients.GoIPFSSwarmPort,
+				TargetPort: intstr.FromInt(ipfsClients.GoIPFSSwarmPort),
+				Protocol:   corev1.ProtocolUDP,
+			},
+			{
+				Name:       "api",
+				Port:       int32(peer.Spec.APIPort),
+				TargetPort: intstr.FromInt(int(peer.Spec.APIPort)),
+				Protocol:   corev1.ProtocolTCP,
+			},
+			{
+				Name:       "gateway",
+				Port:       int32(peer.Spec.GatewayPort),
+				TargetPort: intstr.FromInt(int(peer.Spec.GatewayPort)),
+				Protocol:   corev1.ProtocolTCP,
 			},
 		},
-	}
-
-	volumeMounts := []corev1.VolumeMount{
-		{
-			Name:      "data",
-			MountPath: shared.PathData(homeDir),
+		P2PPorts: []string{"swarm", "swarm-udp"},
+		ConfigFiles: map[string]string{
+			"init_ipfs_config.sh": initIPFSConfigScript,
+			"copy_swarm_key.sh":   copySwarmKeyScript,
+			"config_ipfs.sh":      configIPFSScript,
 		},
-		{
-			Name:      "config",
-			MountPath: shared.PathConfig(homeDir),
+		Metrics: metrics,
+		Ingress: peer.Spec.Ingress,
+		IngressEndpoints: []shared.IngressEndpoint{
+			{Name: "gateway", Port: peer.Spec.GatewayPort},
+			{Name: "api", Port: peer.Spec.APIPort},
 		},
 	}
 
-	initContainers := []corev1.Container{}
-
 	// copy swarm key before init ipfs
 	if peer.Spec.SwarmKeySecretName != "" {
-		volumes = append(volumes, corev1.Volume{
+		descriptor.Volumes = append(descriptor.Volumes, corev1.Volume{
 			Name: "swarm-key",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
@@ -311,12 +196,12 @@ func (r *PeerReconciler) specStatefulSet(peer *ipfsv1alpha1.Peer, sts *appsv1.St
 			},
 		})
 
-		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+		descriptor.VolumeMounts = append(descriptor.VolumeMounts, corev1.VolumeMount{
 			Name:      "swarm-key",
 			MountPath: shared.PathSecrets(homeDir),
 		})
 
-		initContainers = append(initContainers, corev1.Container{
+		descriptor.InitContainers = append(descriptor.InitContainers, corev1.Container{
 			Name:  "copy-swarm-key",
 			Image: shared.BusyboxImage,
 			Env: []corev1.EnvVar{
@@ -333,7 +218,7 @@ func (r *PeerReconciler) specStatefulSet(peer *ipfsv1alpha1.Peer, sts *appsv1.St
 			Args: []string{
 				fmt.Sprintf("%s/copy_swarm_key.sh", shared.PathConfig(homeDir)),
 			},
-			VolumeMounts: volumeMounts,
+			VolumeMounts: shared.NodeVolumeMounts(descriptor),
 		})
 
 	}
@@ -343,7 +228,7 @@ func (r *PeerReconciler) specStatefulSet(peer *ipfsv1alpha1.Peer, sts *appsv1.St
 	for _, profile := range peer.Spec.InitProfiles {
 		initProfiles = append(initProfiles, string(profile))
 	}
-	initContainers = append(initContainers, corev1.Container{
+	descriptor.InitContainers = append(descriptor.InitContainers, corev1.Container{
 		Name:  "init-ipfs",
 		Image: img,
 		Env: []corev1.EnvVar{
@@ -360,7 +245,7 @@ func (r *PeerReconciler) specStatefulSet(peer *ipfsv1alpha1.Peer, sts *appsv1.St
 		Args: []string{
 			fmt.Sprintf("%s/init_ipfs_config.sh", shared.PathConfig(homeDir)),
 		},
-		VolumeMounts: volumeMounts,
+		VolumeMounts: shared.NodeVolumeMounts(descriptor),
 	})
 
 	// init ipfs config
@@ -368,8 +253,14 @@ func (r *PeerReconciler) specStatefulSet(peer *ipfsv1alpha1.Peer, sts *appsv1.St
 	for _, profile := range peer.Spec.Profiles {
 		profiles = append(profiles, string(profile))
 	}
+	// swarm addresses announced to peers are reset if external IP is removed
+	announce, err := json.Marshal(ipfsClients.AnnounceAddresses(peer.Spec.Expose, ipfsClients.GoIPFSSwarmPort))
+	if err != nil {
+		return nil, err
+	}
+
 	// config ipfs
-	initContainers = append(initContainers, corev1.Container{
+	descriptor.InitContainers = append(descriptor.InitContainers, corev1.Container{
 		Name:  "config-ipfs",
 		Image: img,
 		Env: []corev1.EnvVar{
@@ -397,58 +288,27 @@ func (r *PeerReconciler) specStatefulSet(peer *ipfsv1alpha1.Peer, sts *appsv1.St
 				Name:  ipfsClients.EnvIPFSProfiles,
 				Value: strings.Join(profiles, ";"),
 			},
+			{
+				Name:  ipfsClients.EnvIPFSAnnounceAddresses,
+				Value: string(announce),
+			},
 		},
 		Command: []string{"/bin/sh"},
 		Args: []string{
 			fmt.Sprintf("%s/config_ipfs.sh", shared.PathConfig(homeDir)),
 		},
-		VolumeMounts: volumeMounts,
+		VolumeMounts: shared.NodeVolumeMounts(descriptor),
 	})
 
-	sts.Spec = appsv1.StatefulSetSpec{
-		Selector: &metav1.LabelSelector{
-			MatchLabels: labels,
-		},
-		Template: corev1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: labels,
-			},
-			Spec: corev1.PodSpec{
-				SecurityContext: shared.SecurityContext(),
-				InitContainers:  initContainers,
-				Containers: []corev1.Container{
-					{
-						Name:         "peer",
-						Image:        img,
-						Env:          env,
-						Command:      command,
-						Args:         args,
-						VolumeMounts: volumeMounts,
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(peer.Spec.Resources.CPU),
-								corev1.ResourceMemory: resource.MustParse(peer.Spec.Resources.Memory),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(peer.Spec.Resources.CPULimit),
-								corev1.ResourceMemory: resource.MustParse(peer.Spec.Resources.MemoryLimit),
-							},
-						},
-					},
-				},
-				Volumes: volumes,
-			},
-		},
-	}
+	return descriptor, nil
 }
 
 // SetupWithManager registers the controller to be started with the given manager
 func (r *PeerReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&ipfsv1alpha1.Peer{}).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&corev1.Service{}).
-		Complete(r)
+	b, err := shared.NodeControllerManagedBy(mgr, &ipfsv1alpha1.Peer{})
+	if err != nil {
+		return err
+	}
+
+	return b.Complete(r)
 }
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+)
+
+// RenderPeer returns IPFS peer child resources without contacting the api server
+// objects holds resources referenced by the peer spec
+func RenderPeer(peer *ipfsv1alpha1.Peer, objects ...client.Object) ([]client.Object, error) {
+	peer = peer.DeepCopy()
+	peer.Default()
+	shared.UpdateLabels(peer, "go-ipfs")
+
+	c, scheme := shared.NewRenderClient(ipfsv1alpha1.AddToScheme, objects...)
+	r := &PeerReconciler{Client: c, Scheme: scheme}
+
+	descriptor, err := r.Descriptor(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	return shared.RenderNode(context.Background(), c, peer, descriptor)
+}
+
+// RenderClusterPeer returns IPFS cluster peer child resources without contacting the api server
+// objects holds resources referenced by the cluster peer spec
+func RenderClusterPeer(peer *ipfsv1alpha1.ClusterPeer, objects ...client.Object) ([]client.Object, error) {
+	peer = peer.DeepCopy()
+	peer.Default()
+	shared.UpdateLabels(peer, "ipfs-cluster-service")
+
+	c, scheme := shared.NewRenderClient(ipfsv1alpha1.AddToScheme, objects...)
+	r := &ClusterPeerReconciler{Client: c, Scheme: scheme}
+
+	descriptor, err := r.Descriptor(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	return shared.RenderNode(context.Background(), c, peer, descriptor)
+}
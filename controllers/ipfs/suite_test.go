@@ -81,16 +81,18 @@ var _ = BeforeSuite(func() {
 
 	// start peer reconciler
 	peerReconciler := &PeerReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: scheme.Scheme,
+		Client:   k8sManager.GetClient(),
+		Scheme:   scheme.Scheme,
+		Recorder: k8sManager.GetEventRecorderFor("ipfs-peer-controller"),
 	}
 	peerReconciler.SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	// start cluster peer reconciler
 	clusterPeerReconciler := &ClusterPeerReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: scheme.Scheme,
+		Client:   k8sManager.GetClient(),
+		Scheme:   scheme.Scheme,
+		Recorder: k8sManager.GetEventRecorderFor("ipfs-cluster-peer-controller"),
 	}
 	clusterPeerReconciler.SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
@@ -9,4 +9,7 @@ then
 	neard --home $KOTAL_DATA_PATH init --chain-id $KOTAL_NEAR_NETWORK --download-genesis --download-config --account-id validator
 else
 	echo "NEAR node has already been initialized before!"
-fi
\ No newline at end of file
+fi
+
+# announce external address of exposed node to peers, or reset it if node isn't exposed anymore
+sed -i "s|\"external_address\": \"[^\"]*\"|\"external_address\": \"$KOTAL_NEAR_EXTERNAL_ADDRESS\"|" $KOTAL_DATA_PATH/config.json
\ No newline at end of file
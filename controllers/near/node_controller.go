@@ -8,12 +8,10 @@ import (
 	nearv1alpha1 "github.com/kotalco/kotal/apis/near/v1alpha1"
 	nearClients "github.com/kotalco/kotal/clients/near"
 	"github.com/kotalco/kotal/controllers/shared"
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -22,7 +20,8 @@ import (
 // NodeReconciler reconciles a Node object
 type NodeReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 var (
@@ -36,8 +35,19 @@ var (
 
 // +kubebuilder:rbac:groups=near.kotal.io,resources=nodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=near.kotal.io,resources=nodes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=near.kotal.io,resources=nodes/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
 // +kubebuilder:rbac:groups=core,resources=configmaps;persistentvolumeclaims;services,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=watch;get;list
+// +kubebuilder:rbac:groups=core,resources=nodes/proxy,verbs=get
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=watch;get;list
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	var node nearv1alpha1.Node
@@ -47,6 +57,22 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
+	defer func() { shared.RecordError(r.Recorder, &node, err) }()
+	defer func() { shared.ObserveReconcile(r.Scheme, &node, node.Spec.Network, err) }()
+
+	if !node.DeletionTimestamp.IsZero() {
+		result, err = shared.FinalizeStorage(ctx, r.Client, &node, node.Spec.RetentionPolicy, node.Spec.Replicas)
+		return
+	}
+
+	if err = shared.EnsureRetentionFinalizer(ctx, r.Client, &node); err != nil {
+		return
+	}
+
+	if result.RequeueAfter, err = shared.AutoExpandStorage(ctx, r.Client, r.Recorder, &node, &node.Spec.Resources); err != nil {
+		return
+	}
+
 	// default the node if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		node.Default()
@@ -54,19 +80,22 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 
 	shared.UpdateLabels(&node, "nearcore")
 
-	if err = r.reconcilePVC(ctx, &node); err != nil {
+	descriptor, err := r.Descriptor(&node)
+	if err != nil {
 		return
 	}
 
-	if err = r.reconcileConfigmap(ctx, &node); err != nil {
+	requeueAfter, err := shared.PlanRollout(ctx, r.Client, r.Recorder, &node, &node.Status.Status, descriptor)
+	if err != nil {
 		return
 	}
 
-	if err = r.reconcileService(ctx, &node); err != nil {
-		return
+	// canary pod is checked more often than data volume usage
+	if requeueAfter != 0 {
+		result.RequeueAfter = requeueAfter
 	}
 
-	if err = r.reconcileStatefulset(ctx, &node); err != nil {
+	if err = shared.ReconcileNode(ctx, r.Client, r.Scheme, r.Recorder, &node, descriptor); err != nil {
 		return
 	}
 
@@ -81,6 +110,10 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 func (r *NodeReconciler) updateStatus(ctx context.Context, peer *nearv1alpha1.Node) error {
 	peer.Status.Client = "nearcore"
 
+	if err := shared.UpdateStatus(ctx, r.Client, peer, &peer.Status.Status); err != nil {
+		return err
+	}
+
 	if err := r.Status().Update(ctx, peer); err != nil {
 		log.FromContext(ctx).Error(err, "unable to update node status")
 		return err
@@ -89,33 +122,12 @@ func (r *NodeReconciler) updateStatus(ctx context.Context, peer *nearv1alpha1.No
 	return nil
 }
 
-// reconcileService reconciles NEAR node service
-func (r *NodeReconciler) reconcileService(ctx context.Context, node *nearv1alpha1.Node) error {
-	svc := &corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, svc, func() error {
-		if err := ctrl.SetControllerReference(node, svc, r.Scheme); err != nil {
-			return err
-		}
-		r.specService(node, svc)
-		return nil
-	})
-
-	return err
-}
-
-// specService updates NEAR node service spec
-func (r *NodeReconciler) specService(node *nearv1alpha1.Node, svc *corev1.Service) {
-	labels := node.Labels
-
-	svc.ObjectMeta.Labels = labels
+// Descriptor returns NEAR node resources that are reconciled by the shared node reconciler
+func (r *NodeReconciler) Descriptor(node *nearv1alpha1.Node) (*shared.NodeDescriptor, error) {
+	client := nearClients.NewClient(node)
+	homeDir := client.HomeDir()
 
-	svc.Spec.Ports = []corev1.ServicePort{
+	ports := []corev1.ServicePort{
 		{
 			Name:       "p2p",
 			Port:       int32(node.Spec.P2PPort),
@@ -130,130 +142,59 @@ func (r *NodeReconciler) specService(node *nearv1alpha1.Node, svc *corev1.Servic
 		},
 	}
 
+	endpoints := []shared.IngressEndpoint{}
+
+	var metrics *shared.MetricsEndpoint
+
 	if node.Spec.RPC {
-		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+		ports = append(ports, corev1.ServicePort{
 			Name:       "rpc",
 			Port:       int32(node.Spec.RPCPort),
-			TargetPort: intstr.FromInt(int(node.Spec.RPCPort)),
+			TargetPort: shared.RPCGatewayTargetPort(node.Spec.RPCGateway, node.Spec.RPCPort),
 			Protocol:   corev1.ProtocolTCP,
 		})
-		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+		ports = append(ports, corev1.ServicePort{
 			Name:       "prometheus",
 			Port:       int32(node.Spec.PrometheusPort),
 			TargetPort: intstr.FromInt(int(node.Spec.PrometheusPort)),
 			Protocol:   corev1.ProtocolTCP,
 		})
-	}
-
-	svc.Spec.Selector = labels
-}
-
-// reconcilePVC reconciles NEAR node persistent volume claim
-func (n *NodeReconciler) reconcilePVC(ctx context.Context, node *nearv1alpha1.Node) error {
-	pvc := &corev1.PersistentVolumeClaim{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, n.Client, pvc, func() error {
-		if err := ctrl.SetControllerReference(node, pvc, n.Scheme); err != nil {
-			return err
+		endpoints = append(endpoints, shared.IngressEndpoint{Name: "rpc", Port: node.Spec.RPCPort})
+		metrics = &shared.MetricsEndpoint{
+			Port: "prometheus",
+			Path: client.MetricsPath(),
 		}
-
-		n.specPVC(node, pvc)
-
-		return nil
-	})
-
-	return err
-}
-
-// specPVC updates NEAR node persistent volume claim
-func (n *NodeReconciler) specPVC(peer *nearv1alpha1.Node, pvc *corev1.PersistentVolumeClaim) {
-	request := corev1.ResourceList{
-		corev1.ResourceStorage: resource.MustParse(peer.Spec.Resources.Storage),
 	}
 
-	// spec is immutable after creation except resources.requests for bound claims
-	if !pvc.CreationTimestamp.IsZero() {
-		pvc.Spec.Resources.Requests = request
-		return
-	}
-
-	pvc.ObjectMeta.Labels = peer.Labels
-	pvc.Spec = corev1.PersistentVolumeClaimSpec{
-		AccessModes: []corev1.PersistentVolumeAccessMode{
-			corev1.ReadWriteOnce,
-		},
-		Resources: corev1.ResourceRequirements{
-			Requests: request,
+	descriptor := &shared.NodeDescriptor{
+		Client:        client,
+		Resources:     &node.Spec.Resources,
+		Scheduling:    &node.Spec.Scheduling,
+		ExtraConfig:   &node.Spec.ExtraConfig,
+		Disruption:    node.Spec.Disruption,
+		NetworkPolicy: node.Spec.NetworkPolicy,
+		Critical:      node.Spec.ValidatorSecretName != "",
+		Probes:        node.Spec.Probes,
+		Bootstrap:     node.Spec.Bootstrap,
+		Ports:         ports,
+		Expose:        node.Spec.Expose,
+		P2PPorts:      []string{"p2p", "discovery"},
+		ConfigFiles: map[string]string{
+			"init_near_node.sh":     InitNearNode,
+			"copy_node_key.sh":      CopyNodeKey,
+			"copy_validator_key.sh": CopyValidatorKey,
 		},
-		StorageClassName: peer.Spec.Resources.StorageClass,
-	}
-}
-
-// specConfigmap updates node configmap
-func (n *NodeReconciler) specConfigmap(node *nearv1alpha1.Node, configmap *corev1.ConfigMap) {
-	configmap.ObjectMeta.Labels = node.Labels
-
-	if configmap.Data == nil {
-		configmap.Data = map[string]string{}
+		RPCGateway:       node.Spec.RPCGateway,
+		RPCPort:          node.Spec.RPCPort,
+		Replicas:         node.Spec.Replicas,
+		UpdateStrategy:   node.Spec.UpdateStrategy,
+		Metrics:          metrics,
+		Ingress:          node.Spec.Ingress,
+		IngressEndpoints: endpoints,
 	}
 
-	configmap.Data["init_near_node.sh"] = InitNearNode
-	configmap.Data["copy_node_key.sh"] = CopyNodeKey
-	configmap.Data["copy_validator_key.sh"] = CopyValidatorKey
-
-}
-
-// reconcileConfigmap reconciles node configmap
-func (r *NodeReconciler) reconcileConfigmap(ctx context.Context, node *nearv1alpha1.Node) error {
-
-	configmap := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, configmap, func() error {
-		if err := ctrl.SetControllerReference(node, configmap, r.Scheme); err != nil {
-			return err
-		}
-		r.specConfigmap(node, configmap)
-		return nil
-	})
-
-	return err
-}
-
-func (r *NodeReconciler) createVolumes(node *nearv1alpha1.Node) []corev1.Volume {
-
 	var volumeProjections []corev1.VolumeProjection
 
-	volumes := []corev1.Volume{
-		{
-			Name: "data",
-			VolumeSource: corev1.VolumeSource{
-				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-					ClaimName: node.Name,
-				},
-			},
-		},
-		{
-			Name: "config",
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: node.Name,
-					},
-				},
-			},
-		},
-	}
-
 	if node.Spec.NodePrivateKeySecretName != "" {
 		volumeProjections = append(volumeProjections, corev1.VolumeProjection{
 			Secret: &corev1.SecretProjection{
@@ -286,93 +227,52 @@ func (r *NodeReconciler) createVolumes(node *nearv1alpha1.Node) []corev1.Volume
 		})
 	}
 
-	secretsVolume := corev1.Volume{
-		Name: "secrets",
-		VolumeSource: corev1.VolumeSource{
-			Projected: &corev1.ProjectedVolumeSource{
-				Sources: volumeProjections,
+	if len(volumeProjections) != 0 {
+		descriptor.Volumes = append(descriptor.Volumes, corev1.Volume{
+			Name: "secrets",
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: volumeProjections,
+				},
 			},
-		},
-	}
-	volumes = append(volumes, secretsVolume)
-
-	return volumes
-}
-
-func (r *NodeReconciler) createVolumeMounts(node *nearv1alpha1.Node, homeDir string) []corev1.VolumeMount {
-	mounts := []corev1.VolumeMount{
-		{
-			Name:      "data",
-			MountPath: shared.PathData(homeDir),
-		},
-		{
-			Name:      "config",
-			MountPath: shared.PathConfig(homeDir),
-		},
-	}
-
-	if node.Spec.NodePrivateKeySecretName != "" || node.Spec.ValidatorSecretName != "" {
-		mounts = append(mounts, corev1.VolumeMount{
+		})
+		descriptor.VolumeMounts = append(descriptor.VolumeMounts, corev1.VolumeMount{
 			Name:      "secrets",
 			MountPath: shared.PathSecrets(homeDir),
 		})
 	}
 
-	return mounts
-}
+	mounts := shared.NodeVolumeMounts(descriptor)
 
-func (r *NodeReconciler) reconcileStatefulset(ctx context.Context, node *nearv1alpha1.Node) error {
-	sts := &appsv1.StatefulSet{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
+	var externalAddress string
+	if externalIP := node.Spec.Expose.GetExternalIP(); externalIP != "" {
+		externalAddress = fmt.Sprintf("%s:%d", externalIP, node.Spec.Expose.AnnouncedPort(node.Spec.P2PPort))
 	}
 
-	client := nearClients.NewClient(node)
-
-	img := client.Image()
-	homeDir := client.HomeDir()
-	args := client.Args()
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, sts, func() error {
-		if err := ctrl.SetControllerReference(node, sts, r.Scheme); err != nil {
-			return err
-		}
-		r.specStatefulSet(node, sts, img, homeDir, args)
-		return nil
-	})
-
-	return err
-}
-
-// specStatefulSet updates node statefulset spec
-func (r *NodeReconciler) specStatefulSet(node *nearv1alpha1.Node, sts *appsv1.StatefulSet, img, homeDir string, args []string) {
-
-	sts.ObjectMeta.Labels = node.Labels
-
-	initContainers := []corev1.Container{
-		{
-			Name:  "init-near-node",
-			Image: img,
-			Env: []corev1.EnvVar{
-				{
-					Name:  EnvDataPath,
-					Value: shared.PathData(homeDir),
-				},
-				{
-					Name:  EnvNetwork,
-					Value: node.Spec.Network,
-				},
+	descriptor.InitContainers = append(descriptor.InitContainers, corev1.Container{
+		Name:  "init-near-node",
+		Image: client.Image(),
+		Env: []corev1.EnvVar{
+			{
+				Name:  EnvDataPath,
+				Value: shared.PathData(homeDir),
+			},
+			{
+				Name:  EnvNetwork,
+				Value: node.Spec.Network,
+			},
+			{
+				Name:  EnvExternalAddress,
+				Value: externalAddress,
 			},
-			Command:      []string{"/bin/sh"},
-			Args:         []string{fmt.Sprintf("%s/init_near_node.sh", shared.PathConfig(homeDir))},
-			VolumeMounts: r.createVolumeMounts(node, homeDir),
 		},
-	}
+		Command:      []string{"/bin/sh"},
+		Args:         []string{fmt.Sprintf("%s/init_near_node.sh", shared.PathConfig(homeDir))},
+		VolumeMounts: mounts,
+	})
 
 	if node.Spec.NodePrivateKeySecretName != "" {
-		initContainers = append(initContainers, corev1.Container{
+		descriptor.InitContainers = append(descriptor.InitContainers, corev1.Container{
 			Name:    "copy-node-key",
 			Image:   shared.BusyboxImage,
 			Command: []string{"/bin/sh"},
@@ -387,12 +287,12 @@ func (r *NodeReconciler) specStatefulSet(node *nearv1alpha1.Node, sts *appsv1.St
 				},
 			},
 			Args:         []string{fmt.Sprintf("%s/copy_node_key.sh", shared.PathConfig(homeDir))},
-			VolumeMounts: r.createVolumeMounts(node, homeDir),
+			VolumeMounts: mounts,
 		})
 	}
 
 	if node.Spec.ValidatorSecretName != "" {
-		initContainers = append(initContainers, corev1.Container{
+		descriptor.InitContainers = append(descriptor.InitContainers, corev1.Container{
 			Name:    "copy-validator-key",
 			Image:   shared.BusyboxImage,
 			Command: []string{"/bin/sh"},
@@ -407,53 +307,18 @@ func (r *NodeReconciler) specStatefulSet(node *nearv1alpha1.Node, sts *appsv1.St
 				},
 			},
 			Args:         []string{fmt.Sprintf("%s/copy_validator_key.sh", shared.PathConfig(homeDir))},
-			VolumeMounts: r.createVolumeMounts(node, homeDir),
+			VolumeMounts: mounts,
 		})
 	}
 
-	sts.Spec = appsv1.StatefulSetSpec{
-		Selector: &metav1.LabelSelector{
-			MatchLabels: node.Labels,
-		},
-		ServiceName: node.Name,
-		Template: corev1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: node.Labels,
-			},
-			Spec: corev1.PodSpec{
-				SecurityContext: shared.SecurityContext(),
-				InitContainers:  initContainers,
-				Containers: []corev1.Container{
-					{
-						Name:         "node",
-						Image:        img,
-						Args:         args,
-						VolumeMounts: r.createVolumeMounts(node, homeDir),
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Spec.CPU),
-								corev1.ResourceMemory: resource.MustParse(node.Spec.Memory),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Spec.CPULimit),
-								corev1.ResourceMemory: resource.MustParse(node.Spec.MemoryLimit),
-							},
-						},
-					},
-				},
-				Volumes: r.createVolumes(node),
-			},
-		},
-	}
-
+	return descriptor, nil
 }
 
 func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&nearv1alpha1.Node{}).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Owns(&corev1.Service{}).
-		Complete(r)
+	b, err := shared.NodeControllerManagedBy(mgr, &nearv1alpha1.Node{})
+	if err != nil {
+		return err
+	}
+
+	return b.Complete(r)
 }
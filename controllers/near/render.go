@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nearv1alpha1 "github.com/kotalco/kotal/apis/near/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+)
+
+// Render returns NEAR node child resources without contacting the api server
+// objects holds resources referenced by the node spec
+func Render(node *nearv1alpha1.Node, objects ...client.Object) ([]client.Object, error) {
+	node = node.DeepCopy()
+	node.Default()
+	shared.UpdateLabels(node, "nearcore")
+
+	c, scheme := shared.NewRenderClient(nearv1alpha1.AddToScheme, objects...)
+	r := &NodeReconciler{Client: c, Scheme: scheme}
+
+	descriptor, err := r.Descriptor(node)
+	if err != nil {
+		return nil, err
+	}
+
+	return shared.RenderNode(context.Background(), c, node, descriptor)
+}
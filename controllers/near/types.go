@@ -9,4 +9,6 @@ const (
 	EnvSecretsPath = "KOTAL_SECRETS_PATH"
 	// EnvNetwork is the environment variable to set NEAR network
 	EnvNetwork = "KOTAL_NEAR_NETWORK"
+	// EnvExternalAddress is the environment variable to set address announced to peers
+	EnvExternalAddress = "KOTAL_NEAR_EXTERNAL_ADDRESS"
 )
@@ -80,8 +80,9 @@ var _ = BeforeSuite(func() {
 
 	// start node reconciler
 	peerReconciler := &NodeReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: scheme.Scheme,
+		Client:   k8sManager.GetClient(),
+		Scheme:   scheme.Scheme,
+		Recorder: k8sManager.GetEventRecorderFor("polkadot-node-controller"),
 	}
 	peerReconciler.SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
@@ -0,0 +1,210 @@
+// Package render renders Kotal nodes child resources without contacting the api server
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/yaml"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	bitcoinv1beta1 "github.com/kotalco/kotal/apis/bitcoin/v1beta1"
+	chainlinkv1alpha1 "github.com/kotalco/kotal/apis/chainlink/v1alpha1"
+	chainlinkv1beta1 "github.com/kotalco/kotal/apis/chainlink/v1beta1"
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+	ethereum2v1beta1 "github.com/kotalco/kotal/apis/ethereum2/v1beta1"
+	filecoinv1alpha1 "github.com/kotalco/kotal/apis/filecoin/v1alpha1"
+	filecoinv1beta1 "github.com/kotalco/kotal/apis/filecoin/v1beta1"
+	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	ipfsv1beta1 "github.com/kotalco/kotal/apis/ipfs/v1beta1"
+	nearv1alpha1 "github.com/kotalco/kotal/apis/near/v1alpha1"
+	nearv1beta1 "github.com/kotalco/kotal/apis/near/v1beta1"
+	polkadotv1alpha1 "github.com/kotalco/kotal/apis/polkadot/v1alpha1"
+	polkadotv1beta1 "github.com/kotalco/kotal/apis/polkadot/v1beta1"
+	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+	stacksv1beta1 "github.com/kotalco/kotal/apis/stacks/v1beta1"
+	bitcoincontroller "github.com/kotalco/kotal/controllers/bitcoin"
+	chainlinkcontroller "github.com/kotalco/kotal/controllers/chainlink"
+	ethereumcontroller "github.com/kotalco/kotal/controllers/ethereum"
+	ethereum2controller "github.com/kotalco/kotal/controllers/ethereum2"
+	filecoincontroller "github.com/kotalco/kotal/controllers/filecoin"
+	ipfscontroller "github.com/kotalco/kotal/controllers/ipfs"
+	nearcontroller "github.com/kotalco/kotal/controllers/near"
+	polkadotcontroller "github.com/kotalco/kotal/controllers/polkadot"
+	stackscontroller "github.com/kotalco/kotal/controllers/stacks"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	_ = ethereumv1alpha1.AddToScheme(scheme)
+	_ = ethereumv1beta1.AddToScheme(scheme)
+	_ = ethereum2v1alpha1.AddToScheme(scheme)
+	_ = ethereum2v1beta1.AddToScheme(scheme)
+	_ = ipfsv1alpha1.AddToScheme(scheme)
+	_ = ipfsv1beta1.AddToScheme(scheme)
+	_ = filecoinv1alpha1.AddToScheme(scheme)
+	_ = filecoinv1beta1.AddToScheme(scheme)
+	_ = polkadotv1alpha1.AddToScheme(scheme)
+	_ = polkadotv1beta1.AddToScheme(scheme)
+	_ = chainlinkv1alpha1.AddToScheme(scheme)
+	_ = chainlinkv1beta1.AddToScheme(scheme)
+	_ = nearv1alpha1.AddToScheme(scheme)
+	_ = nearv1beta1.AddToScheme(scheme)
+	_ = bitcoinv1alpha1.AddToScheme(scheme)
+	_ = bitcoinv1beta1.AddToScheme(scheme)
+	_ = stacksv1alpha1.AddToScheme(scheme)
+	_ = stacksv1beta1.AddToScheme(scheme)
+}
+
+// Render returns node child resources using the node controller render function
+// objects holds resources referenced by the node spec like secrets
+func Render(node client.Object, objects ...client.Object) ([]client.Object, error) {
+	switch node := node.(type) {
+	case *ethereumv1alpha1.Node:
+		return ethereumcontroller.Render(node, objects...)
+	case *ethereum2v1alpha1.BeaconNode:
+		return ethereum2controller.RenderBeaconNode(node, objects...)
+	case *ethereum2v1alpha1.Validator:
+		return ethereum2controller.RenderValidator(node, objects...)
+	case *ipfsv1alpha1.Peer:
+		return ipfscontroller.RenderPeer(node, objects...)
+	case *ipfsv1alpha1.ClusterPeer:
+		return ipfscontroller.RenderClusterPeer(node, objects...)
+	case *filecoinv1alpha1.Node:
+		return filecoincontroller.Render(node, objects...)
+	case *polkadotv1alpha1.Node:
+		return polkadotcontroller.Render(node, objects...)
+	case *chainlinkv1alpha1.Node:
+		return chainlinkcontroller.Render(node, objects...)
+	case *nearv1alpha1.Node:
+		return nearcontroller.Render(node, objects...)
+	case *bitcoinv1alpha1.Node:
+		return bitcoincontroller.Render(node, objects...)
+	case *stacksv1alpha1.Node:
+		return stackscontroller.Render(node, objects...)
+	}
+	return nil, fmt.Errorf("unsupported node %T", node)
+}
+
+// IsNode returns true if object is Kotal node
+func IsNode(obj client.Object) bool {
+	switch obj.(type) {
+	case *ethereumv1alpha1.Node, *ethereum2v1alpha1.BeaconNode, *ethereum2v1alpha1.Validator,
+		*ipfsv1alpha1.Peer, *ipfsv1alpha1.ClusterPeer, *filecoinv1alpha1.Node, *polkadotv1alpha1.Node,
+		*chainlinkv1alpha1.Node, *nearv1alpha1.Node, *bitcoinv1alpha1.Node, *stacksv1alpha1.Node:
+		return true
+	}
+	return false
+}
+
+// RenderObjects renders child resources of all nodes in objects
+// other objects (like secrets) are made available to the node controllers
+func RenderObjects(objects []client.Object) ([]client.Object, error) {
+	nodes, others := []client.Object{}, []client.Object{}
+	for _, obj := range objects {
+		if IsNode(obj) {
+			nodes = append(nodes, obj)
+		} else {
+			others = append(others, obj)
+		}
+	}
+
+	if len(nodes) == 0 {
+		return nil, errors.New("no Kotal nodes found")
+	}
+
+	rendered := []client.Object{}
+	for _, node := range nodes {
+		children, err := Render(node, others...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", node.GetName(), err)
+		}
+		rendered = append(rendered, children...)
+	}
+
+	return rendered, nil
+}
+
+// Decode decodes all objects of YAML or JSON documents
+func Decode(in io.Reader) ([]client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(in))
+
+	objects := []client.Object{}
+
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, gvk, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// controllers reconcile the hub version, convert nodes written against other versions
+		if convertible, ok := obj.(conversion.Convertible); ok {
+			hub, err := scheme.New(gvk.GroupKind().WithVersion("v1alpha1"))
+			if err != nil {
+				return nil, err
+			}
+			if err := convertible.ConvertTo(hub.(conversion.Hub)); err != nil {
+				return nil, err
+			}
+			obj = hub
+		}
+
+		object, ok := obj.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("unsupported object %T", obj)
+		}
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+// Marshal encodes objects into YAML documents
+func Marshal(objects []client.Object) ([]byte, error) {
+	var buf bytes.Buffer
+
+	for _, obj := range objects {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return nil, err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.WriteString("---\n")
+		buf.Write(data)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,197 @@
+package render
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// update regenerates golden files after intended changes to rendered resources
+// go test ./controllers/render -update
+var update = flag.Bool("update", false, "update golden files")
+
+// rpcAuth matches bitcoin JSON-RPC users salted password hashes, salt is random for every operator run
+var rpcAuth = regexp.MustCompile(`(-rpcauth=[^:]+:)[0-9a-f]+\$[0-9a-f]+`)
+
+// renderInput decodes and renders nodes in the input file
+func renderInput(input string) []byte {
+	data, err := os.ReadFile(input)
+	Expect(err).NotTo(HaveOccurred())
+
+	objects, err := Decode(bytes.NewReader(data))
+	Expect(err).NotTo(HaveOccurred())
+	for _, obj := range objects {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace("default")
+		}
+	}
+
+	rendered, err := RenderObjects(objects)
+	Expect(err).NotTo(HaveOccurred())
+
+	out, err := Marshal(rendered)
+	Expect(err).NotTo(HaveOccurred())
+
+	return out
+}
+
+var _ = Describe("Rendering", func() {
+
+	// every node in testdata/<protocol>/<case>.yaml is rendered
+	// and compared with testdata/<protocol>/<case>.golden
+	inputs, _ := filepath.Glob(filepath.Join("testdata", "*", "*.yaml"))
+
+	It("Should find test cases in testdata", func() {
+		Expect(inputs).NotTo(BeEmpty())
+	})
+
+	for _, input := range inputs {
+		func() {
+			input := input
+			name := strings.TrimSuffix(strings.TrimPrefix(input, "testdata"+string(filepath.Separator)), ".yaml")
+
+			It(fmt.Sprintf("Should match %s golden file", name), func() {
+				got := rpcAuth.ReplaceAll(renderInput(input), []byte("${1}<salt>$$<hash>"))
+
+				golden := strings.TrimSuffix(input, ".yaml") + ".golden"
+
+				if *update {
+					Expect(os.WriteFile(golden, got, 0644)).To(Succeed())
+					return
+				}
+
+				want, err := os.ReadFile(golden)
+				Expect(err).NotTo(HaveOccurred(), "run go test ./controllers/render -update to create it")
+
+				Expect(bytes.Equal(got, want)).To(BeTrue(), "rendered resources don't match %s, run go test ./controllers/render -update if the change is intended\n%s", golden, diff(string(want), string(got)))
+			})
+
+			// golden files would be flaky otherwise
+			It(fmt.Sprintf("Should render %s deterministically", name), func() {
+				first, second := renderInput(input), renderInput(input)
+				Expect(bytes.Equal(first, second)).To(BeTrue(), "rendering is not deterministic\n%s", diff(string(first), string(second)))
+			})
+
+			// flags set by Kotal must be rejected in extra args by the validation webhooks
+			It(fmt.Sprintf("Should list %s flags set by Kotal in managed flags", name), func() {
+				data, err := os.ReadFile(input)
+				Expect(err).NotTo(HaveOccurred())
+				objects, err := Decode(bytes.NewReader(data))
+				Expect(err).NotTo(HaveOccurred())
+				rendered, err := RenderObjects(objects)
+				Expect(err).NotTo(HaveOccurred())
+
+				managed := map[string]bool{}
+				for _, obj := range objects {
+					if node, ok := obj.(interface{ ManagedFlags() []string }); ok {
+						for _, flag := range node.ManagedFlags() {
+							managed[sharedAPI.FlagName(flag)] = true
+						}
+					}
+				}
+
+				for _, obj := range rendered {
+					sts, ok := obj.(*appsv1.StatefulSet)
+					if !ok {
+						continue
+					}
+					for _, arg := range sts.Spec.Template.Spec.Containers[0].Args {
+						if name := sharedAPI.FlagName(arg); name != "" {
+							Expect(managed[name]).To(BeTrue(), "flag %s is set by Kotal but missing from managed flags", name)
+						}
+					}
+				}
+			})
+		}()
+	}
+
+	// nodes written against v1beta1 are rendered by the controllers
+	It("Should convert decoded nodes to hub version", func() {
+		input := `
+apiVersion: ethereum.kotal.io/v1beta1
+kind: Node
+metadata:
+  name: geth-goerli
+spec:
+  network: goerli
+  client: geth
+  highlyAvailable: true
+  topologyKey: kubernetes.io/hostname
+`
+		objects, err := Decode(strings.NewReader(input))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(1))
+
+		node, ok := objects[0].(*ethereumv1alpha1.Node)
+		Expect(ok).To(BeTrue())
+		Expect(node.Name).To(Equal("geth-goerli"))
+		Expect(node.Spec.Network).To(Equal("goerli"))
+		Expect(node.Spec.TopologyKey).To(Equal("kubernetes.io/hostname"))
+	})
+
+	It("Should spread highly available node pods from pods of the same node", func() {
+		input := `
+apiVersion: ethereum.kotal.io/v1alpha1
+kind: Node
+metadata:
+  name: geth-goerli
+  namespace: default
+spec:
+  network: goerli
+  client: geth
+  highlyAvailable: true
+`
+		objects, err := Decode(strings.NewReader(input))
+		Expect(err).NotTo(HaveOccurred())
+		rendered, err := RenderObjects(objects)
+		Expect(err).NotTo(HaveOccurred())
+
+		var sts *appsv1.StatefulSet
+		for _, obj := range rendered {
+			if s, ok := obj.(*appsv1.StatefulSet); ok {
+				sts = s
+			}
+		}
+		Expect(sts).NotTo(BeNil())
+
+		terms := sts.Spec.Template.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+		Expect(terms).To(HaveLen(1))
+		Expect(terms[0].TopologyKey).To(Equal(ethereumv1alpha1.DefaultTopologyKey))
+		Expect(terms[0].LabelSelector.MatchLabels).To(Equal(map[string]string{
+			"app.kubernetes.io/component": "ethereum-node",
+			"app.kubernetes.io/instance":  "geth-goerli",
+		}))
+	})
+
+})
+
+// diff returns the first lines that differ between want and got
+func diff(want, got string) string {
+	wantLines, gotLines := strings.Split(want, "\n"), strings.Split(got, "\n")
+
+	var b strings.Builder
+	for i, n := 0, 0; (i < len(wantLines) || i < len(gotLines)) && n < 20; i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			fmt.Fprintf(&b, "line %d:\n- %s\n+ %s\n", i+1, w, g)
+			n++
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,214 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+// autoExpandInterval is how often node data volume usage is checked
+const autoExpandInterval = 5 * time.Minute
+
+// VolumeStats is pod volume disk usage
+type VolumeStats struct {
+	// Name is pod volume name
+	Name string `json:"name"`
+	// UsedBytes is disk space used by the volume
+	UsedBytes uint64 `json:"usedBytes"`
+	// CapacityBytes is volume filesystem capacity
+	CapacityBytes uint64 `json:"capacityBytes"`
+}
+
+// VolumeStatsReader reads pod volume disk usage
+type VolumeStatsReader interface {
+	// VolumeStats returns pod volume disk usage, nil is returned if volume stats aren't available
+	VolumeStats(ctx context.Context, pod *corev1.Pod, volume string) (*VolumeStats, error)
+}
+
+// KubeletVolumeStats reads pod volume disk usage from kubelet summary API through api server node proxy
+type KubeletVolumeStats struct {
+	// Client is core api rest client
+	Client rest.Interface
+}
+
+// kubeletSummary is the subset of kubelet stats summary containing pods volume stats
+type kubeletSummary struct {
+	Pods []struct {
+		PodRef struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"podRef"`
+		Volumes []VolumeStats `json:"volume"`
+	} `json:"pods"`
+}
+
+// VolumeStats implements VolumeStatsReader
+func (k *KubeletVolumeStats) VolumeStats(ctx context.Context, pod *corev1.Pod, volume string) (*VolumeStats, error) {
+	raw, err := k.Client.Get().
+		Resource("nodes").
+		Name(pod.Spec.NodeName).
+		SubResource("proxy").
+		Suffix("stats/summary").
+		DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return PodVolumeStats(raw, pod, volume)
+}
+
+// PodVolumeStats returns pod volume disk usage from kubelet stats summary
+func PodVolumeStats(raw []byte, pod *corev1.Pod, volume string) (*VolumeStats, error) {
+	summary := kubeletSummary{}
+	if err := json.Unmarshal(raw, &summary); err != nil {
+		return nil, err
+	}
+
+	for _, p := range summary.Pods {
+		if p.PodRef.Name != pod.Name || p.PodRef.Namespace != pod.Namespace {
+			continue
+		}
+		for i := range p.Volumes {
+			if p.Volumes[i].Name == volume {
+				return &p.Volumes[i], nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// volumeStats is used to read node data volume usage, storage isn't expanded automatically if nil
+var volumeStats VolumeStatsReader
+
+// SetVolumeStatsReader sets reader used to read node data volume usage
+func SetVolumeStatsReader(reader VolumeStatsReader) {
+	volumeStats = reader
+}
+
+// exceedsThreshold checks if data volume usage exceeds auto expand threshold
+// threshold isn't defaulted yet if webhooks are disabled
+func exceedsThreshold(autoExpand *sharedAPI.AutoExpand, stats *VolumeStats) bool {
+	if stats == nil || stats.CapacityBytes == 0 {
+		return false
+	}
+
+	threshold := autoExpand.Threshold
+	if threshold == 0 {
+		threshold = sharedAPI.DefaultAutoExpandThreshold
+	}
+
+	return stats.UsedBytes*100 >= uint64(threshold)*stats.CapacityBytes
+}
+
+// ExpandedStorage returns storage expanded by auto expand increment and capped at auto expand maximum
+// storage is returned as is if data volume usage is below auto expand threshold
+func ExpandedStorage(storage string, autoExpand *sharedAPI.AutoExpand, stats *VolumeStats) string {
+	if !exceedsThreshold(autoExpand, stats) {
+		return storage
+	}
+
+	expanded := resource.MustParse(storage)
+	expanded.Add(resource.MustParse(autoExpand.Increment))
+
+	if maximum := resource.MustParse(autoExpand.Maximum); expanded.Cmp(maximum) == 1 {
+		expanded = maximum
+	}
+
+	if expanded.Cmp(resource.MustParse(storage)) != 1 {
+		return storage
+	}
+
+	return expanded.String()
+}
+
+// AutoExpandStorage expands node storage if data volume usage exceeds auto expand threshold
+// expanded storage is patched into node spec and used by node persistent volume claim reconciliation
+// node is requeued to check data volume usage again, errors reading volume usage are logged and ignored
+// it's called before the node is defaulted because patching node resets it to the api server version
+func AutoExpandStorage(ctx context.Context, c client.Client, recorder record.EventRecorder, node client.Object, resources *sharedAPI.Resources) (requeueAfter time.Duration, err error) {
+	if resources.AutoExpand == nil || volumeStats == nil {
+		return
+	}
+
+	requeueAfter = autoExpandInterval
+	logger := log.FromContext(ctx)
+
+	// first replica data volume usage is used for all replicas, they store the same chain data
+	pod := &corev1.Pod{}
+	podName := types.NamespacedName{Name: fmt.Sprintf("%s-0", node.GetName()), Namespace: node.GetNamespace()}
+	if err = c.Get(ctx, podName, pod); err != nil {
+		err = client.IgnoreNotFound(err)
+		return
+	}
+	if pod.Spec.NodeName == "" {
+		return
+	}
+
+	// expansion is in progress until claim capacity reaches requested storage
+	claimName := dataClaimName(pod)
+	if claimName == "" {
+		return
+	}
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err = c.Get(ctx, types.NamespacedName{Name: claimName, Namespace: node.GetNamespace()}, pvc); err != nil {
+		err = client.IgnoreNotFound(err)
+		return
+	}
+	requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	capacity := pvc.Status.Capacity[corev1.ResourceStorage]
+	if capacity.Cmp(requested) == -1 {
+		return
+	}
+
+	stats, statsErr := volumeStats.VolumeStats(ctx, pod, "data")
+	if statsErr != nil {
+		logger.Error(statsErr, "unable to read data volume usage", "pod", podName)
+		return
+	}
+
+	storage := ExpandedStorage(resources.Storage, resources.AutoExpand, stats)
+	if storage == resources.Storage {
+		if exceedsThreshold(resources.AutoExpand, stats) {
+			Eventf(recorder, node, corev1.EventTypeWarning, ReasonStorageMaximumReached, "Data volume usage exceeds threshold but storage reached maximum %s", resources.AutoExpand.Maximum)
+		}
+		return
+	}
+
+	// patched node is decoded into resources, storage is kept to record the expansion
+	current := resources.Storage
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"resources":{"storage":%q}}}`, storage))
+	err = keepGVK(c, node, func() error {
+		return c.Patch(ctx, node, client.RawPatch(types.MergePatchType, patch))
+	})
+	if err != nil {
+		err = &PhaseError{Phase: PhasePVC, Err: err}
+		return
+	}
+
+	Eventf(recorder, node, corev1.EventTypeNormal, ReasonStorageExpanded, "Expanding storage from %s to %s", current, storage)
+
+	return
+}
+
+// dataClaimName returns persistent volume claim name of pod data volume
+func dataClaimName(pod *corev1.Pod) string {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == "data" && volume.PersistentVolumeClaim != nil {
+			return volume.PersistentVolumeClaim.ClaimName
+		}
+	}
+	return ""
+}
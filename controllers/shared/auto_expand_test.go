@@ -0,0 +1,146 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+// staticVolumeStats reads the same disk usage for all pod volumes
+type staticVolumeStats VolumeStats
+
+// VolumeStats implements VolumeStatsReader
+func (s *staticVolumeStats) VolumeStats(ctx context.Context, pod *corev1.Pod, volume string) (*VolumeStats, error) {
+	stats := VolumeStats(*s)
+	return &stats, nil
+}
+
+// patchClient resets patched objects group, version and kind like the api server client does
+type patchClient struct {
+	client.Client
+}
+
+// Patch implements client.Writer
+func (c patchClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	defer obj.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+var _ = Describe("Storage auto expansion", func() {
+
+	autoExpand := &sharedAPI.AutoExpand{
+		Threshold: 80,
+		Increment: "100Gi",
+		Maximum:   "1Ti",
+	}
+
+	tests := []struct {
+		title    string
+		storage  string
+		stats    *VolumeStats
+		expected string
+	}{
+		{"missing stats", "500Gi", nil, "500Gi"},
+		{"usage below threshold", "500Gi", &VolumeStats{UsedBytes: 79, CapacityBytes: 100}, "500Gi"},
+		{"usage at threshold", "500Gi", &VolumeStats{UsedBytes: 80, CapacityBytes: 100}, "600Gi"},
+		{"expansion capped at maximum", "1000Gi", &VolumeStats{UsedBytes: 95, CapacityBytes: 100}, "1Ti"},
+		{"storage at maximum", "1Ti", &VolumeStats{UsedBytes: 95, CapacityBytes: 100}, "1Ti"},
+	}
+
+	for _, test := range tests {
+		func() {
+			tt := test
+			It(fmt.Sprintf("Should expand storage with %s", tt.title), func() {
+				Expect(ExpandedStorage(tt.storage, autoExpand, tt.stats)).To(Equal(tt.expected))
+			})
+		}()
+	}
+
+	// threshold isn't defaulted if webhooks are disabled
+	It("Should use default threshold", func() {
+		undefaulted := &sharedAPI.AutoExpand{Increment: "100Gi", Maximum: "1Ti"}
+		Expect(ExpandedStorage("500Gi", undefaulted, &VolumeStats{UsedBytes: 50, CapacityBytes: 100})).To(Equal("500Gi"))
+	})
+
+	It("Should parse pod volume stats", func() {
+		summary := []byte(`{
+		"node": {"nodeName": "worker-1"},
+		"pods": [
+			{
+				"podRef": {"name": "other-node-0", "namespace": "default"},
+				"volume": [{"name": "data", "usedBytes": 1, "capacityBytes": 10}]
+			},
+			{
+				"podRef": {"name": "my-node-0", "namespace": "default"},
+				"volume": [
+					{"name": "config", "usedBytes": 2, "capacityBytes": 20},
+					{"name": "data", "usedBytes": 300, "capacityBytes": 400}
+				]
+			}
+		]
+	}`)
+
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-node-0", Namespace: "default"}}
+
+		stats, err := PodVolumeStats(summary, pod, "data")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats).To(Equal(&VolumeStats{Name: "data", UsedBytes: 300, CapacityBytes: 400}))
+
+		// missing volume
+		stats, _ = PodVolumeStats(summary, pod, "secrets")
+		Expect(stats).To(BeNil())
+	})
+
+	It("Should expand node storage without resetting node kind", func() {
+		node := testNode()
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-node-0", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName: "worker-1",
+				Volumes: []corev1.Volume{
+					{
+						Name: "data",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "my-node"},
+						},
+					},
+				},
+			},
+		}
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-node", Namespace: "default"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("500Gi")},
+				},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("500Gi")},
+			},
+		}
+
+		c := patchClient{fake.NewClientBuilder().WithObjects(node.DeepCopy(), pod, pvc).Build()}
+
+		SetVolumeStatsReader(&staticVolumeStats{UsedBytes: 90, CapacityBytes: 100})
+		defer SetVolumeStatsReader(nil)
+
+		resources := &sharedAPI.Resources{Storage: "500Gi", AutoExpand: autoExpand}
+		requeueAfter, err := AutoExpandStorage(context.Background(), c, nil, node, resources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requeueAfter).NotTo(BeZero())
+
+		// labels and owner references of node resources are computed from node kind
+		Expect(node.GroupVersionKind()).To(Equal(corev1.SchemeGroupVersion.WithKind("Service")))
+	})
+
+})
@@ -0,0 +1,58 @@
+package shared
+
+import (
+	_ "embed"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+const (
+	// EnvBootstrapDataPath is the environment variable to locate data path
+	EnvBootstrapDataPath = "KOTAL_DATA_PATH"
+	// EnvSnapshotURL is the environment variable holding snapshot archive URL
+	EnvSnapshotURL = "KOTAL_SNAPSHOT_URL"
+	// EnvSnapshotChecksum is the environment variable holding snapshot archive SHA-256 checksum
+	EnvSnapshotChecksum = "KOTAL_SNAPSHOT_CHECKSUM"
+)
+
+//go:embed bootstrap_snapshot.sh
+var bootstrapSnapshotScript string
+
+// BootstrapContainer returns init container that downloads and extracts snapshot archive into empty node data directory
+// it returns nil if node has no bootstrap snapshot
+// node data volume must be called data
+func BootstrapContainer(bootstrap *sharedAPI.Bootstrap, homeDir string) *corev1.Container {
+	if bootstrap == nil || bootstrap.SnapshotURL == "" {
+		return nil
+	}
+
+	return &corev1.Container{
+		Name:  "bootstrap-snapshot",
+		Image: BusyboxImage,
+		Env: []corev1.EnvVar{
+			{
+				Name:  EnvBootstrapDataPath,
+				Value: PathData(homeDir),
+			},
+			{
+				Name:  EnvSnapshotURL,
+				Value: bootstrap.SnapshotURL,
+			},
+			{
+				Name:  EnvSnapshotChecksum,
+				Value: strings.ToLower(bootstrap.Checksum),
+			},
+		},
+		Command: []string{"/bin/sh"},
+		Args:    []string{"-c", bootstrapSnapshotScript},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "data",
+				MountPath: PathData(homeDir),
+			},
+		},
+	}
+}
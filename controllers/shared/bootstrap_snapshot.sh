@@ -0,0 +1,33 @@
+#!/bin/sh
+
+set -e
+
+ARCHIVE="$KOTAL_DATA_PATH/.kotal-snapshot"
+MARKER="$KOTAL_DATA_PATH/.kotal-bootstrap"
+
+if [ -f "$MARKER" ]
+then
+	echo "Cleaning up interrupted snapshot bootstrap"
+	find $KOTAL_DATA_PATH -mindepth 1 -maxdepth 1 ! -name lost+found -exec rm -rf {} \;
+elif [ -n "$(ls -A $KOTAL_DATA_PATH | grep -v '^lost+found$')" ]
+then
+	echo "Node data directory is not empty, skipping snapshot bootstrap"
+	exit 0
+fi
+
+touch $MARKER
+
+echo "Downloading snapshot from $KOTAL_SNAPSHOT_URL"
+wget -O $ARCHIVE "$KOTAL_SNAPSHOT_URL"
+
+if [ -n "$KOTAL_SNAPSHOT_CHECKSUM" ]
+then
+	echo "Verifying snapshot checksum"
+	echo "$KOTAL_SNAPSHOT_CHECKSUM  $ARCHIVE" | sha256sum -c -
+fi
+
+echo "Extracting snapshot into $KOTAL_DATA_PATH"
+tar -xf $ARCHIVE -C $KOTAL_DATA_PATH
+
+rm -f $ARCHIVE $MARKER
+echo "Node data has been bootstrapped from snapshot"
@@ -0,0 +1,36 @@
+package shared
+
+import (
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Bootstrap container", func() {
+
+	It("Should not create bootstrap container without bootstrap snapshot", func() {
+		Expect(BootstrapContainer(nil, "/home/kotal")).To(BeNil())
+	})
+
+	It("Should create bootstrap container downloading node data snapshot", func() {
+		bootstrap := &sharedAPI.Bootstrap{
+			SnapshotURL: "https://snapshots.example.com/mainnet/data.tar.gz",
+			Checksum:    "9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08",
+		}
+
+		container := BootstrapContainer(bootstrap, "/home/kotal")
+		Expect(container).NotTo(BeNil())
+		Expect(container.Image).To(Equal(BusyboxImage))
+		Expect(container.Env).To(ContainElements(
+			corev1.EnvVar{Name: EnvBootstrapDataPath, Value: "/home/kotal/kotal-data"},
+			corev1.EnvVar{Name: EnvSnapshotURL, Value: "https://snapshots.example.com/mainnet/data.tar.gz"},
+			corev1.EnvVar{Name: EnvSnapshotChecksum, Value: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"},
+		))
+		// only data volume is mounted
+		Expect(container.VolumeMounts).To(Equal([]corev1.VolumeMount{
+			{Name: "data", MountPath: "/home/kotal/kotal-data"},
+		}))
+	})
+
+})
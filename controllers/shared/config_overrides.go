@@ -0,0 +1,76 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// MergeTOML merges config overrides into TOML config generated from node spec
+// tables are merged recursively, any other value is replaced by the override
+func MergeTOML(config string, overrides *runtime.RawExtension) (string, error) {
+	if overrides == nil || len(overrides.Raw) == 0 {
+		return config, nil
+	}
+
+	base := map[string]interface{}{}
+	if _, err := toml.Decode(config, &base); err != nil {
+		return "", fmt.Errorf("failed to decode generated config: %w", err)
+	}
+
+	// decode numbers as json.Number to keep integers from turning into floats
+	patch := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(overrides.Raw))
+	dec.UseNumber()
+	if err := dec.Decode(&patch); err != nil {
+		return "", fmt.Errorf("failed to decode config overrides: %w", err)
+	}
+
+	mergeTables(base, fromJSON(patch).(map[string]interface{}))
+
+	var buff bytes.Buffer
+	if err := toml.NewEncoder(&buff).Encode(base); err != nil {
+		return "", err
+	}
+
+	return buff.String(), nil
+}
+
+// mergeTables merges src table into dst table
+func mergeTables(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcTable, srcIsTable := value.(map[string]interface{})
+		dstTable, dstIsTable := dst[key].(map[string]interface{})
+		if srcIsTable && dstIsTable {
+			mergeTables(dstTable, srcTable)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// fromJSON converts json numbers into integers or floats that can be encoded as TOML
+func fromJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = fromJSON(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = fromJSON(item)
+		}
+		return v
+	}
+	return value
+}
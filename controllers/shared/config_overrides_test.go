@@ -0,0 +1,71 @@
+package shared
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Config overrides", func() {
+	config := `[API]
+  ListenAddress = "/ip4/0.0.0.0/tcp/1234/http"
+  RequestTimeout = "10s"
+
+[Libp2p]
+  ListenAddresses = ["/ip4/0.0.0.0/tcp/4444"]
+`
+
+	tests := []struct {
+		title     string
+		overrides *runtime.RawExtension
+		expected  string
+	}{
+		{
+			title:     "no overrides",
+			overrides: nil,
+			expected:  config,
+		},
+		{
+			title:     "override and add keys",
+			overrides: &runtime.RawExtension{Raw: []byte(`{"API":{"RequestTimeout":"30s"},"Chainstore":{"EnableSplitstore":true,"Splitstore":{"HotStoreFullGCFrequency":20}}}`)},
+			expected: `[API]
+  ListenAddress = "/ip4/0.0.0.0/tcp/1234/http"
+  RequestTimeout = "30s"
+
+[Chainstore]
+  EnableSplitstore = true
+  [Chainstore.Splitstore]
+    HotStoreFullGCFrequency = 20
+
+[Libp2p]
+  ListenAddresses = ["/ip4/0.0.0.0/tcp/4444"]
+`,
+		},
+		{
+			title:     "replace arrays",
+			overrides: &runtime.RawExtension{Raw: []byte(`{"Libp2p":{"ListenAddresses":["/ip4/0.0.0.0/tcp/5555"],"ConnMgrLow":0.5}}`)},
+			expected: `[API]
+  ListenAddress = "/ip4/0.0.0.0/tcp/1234/http"
+  RequestTimeout = "10s"
+
+[Libp2p]
+  ConnMgrLow = 0.5
+  ListenAddresses = ["/ip4/0.0.0.0/tcp/5555"]
+`,
+		},
+	}
+
+	for _, test := range tests {
+		func() {
+			tt := test
+			It(fmt.Sprintf("Should merge TOML config with %s", tt.title), func() {
+				got, err := MergeTOML(config, tt.overrides)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(got).To(Equal(tt.expected))
+			})
+		}()
+	}
+
+})
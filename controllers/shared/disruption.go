@@ -0,0 +1,62 @@
+package shared
+
+import (
+	"context"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IsProtected checks if node pods are protected from voluntary disruptions by pod disruption budget
+func IsProtected(descriptor *NodeDescriptor) bool {
+	return descriptor.Disruption.Protects(descriptor.Critical)
+}
+
+// ReconcilePodDisruptionBudget creates node pod disruption budget if node pods are protected, otherwise deletes it
+// pod disruption budget has the same name as the node
+func ReconcilePodDisruptionBudget(ctx context.Context, c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, node client.Object, descriptor *NodeDescriptor) error {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      node.GetName(),
+			Namespace: node.GetNamespace(),
+		},
+	}
+
+	if !IsProtected(descriptor) {
+		return client.IgnoreNotFound(c.Delete(ctx, pdb))
+	}
+
+	op, err := ctrl.CreateOrUpdate(ctx, c, pdb, func() error {
+		if err := ctrl.SetControllerReference(node, pdb, scheme); err != nil {
+			return err
+		}
+		SpecPodDisruptionBudget(node, pdb)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	RecordOperation(recorder, node, "PodDisruptionBudget", pdb.Name, op)
+
+	return nil
+}
+
+// SpecPodDisruptionBudget updates node pod disruption budget spec
+// no pod can be evicted, node drains wait until the node is deleted or its disruption policy allows disruptions
+func SpecPodDisruptionBudget(node client.Object, pdb *policyv1.PodDisruptionBudget) {
+	maxUnavailable := intstr.FromInt(0)
+
+	pdb.ObjectMeta.Labels = node.GetLabels()
+	pdb.Spec = policyv1.PodDisruptionBudgetSpec{
+		MaxUnavailable: &maxUnavailable,
+		Selector: &metav1.LabelSelector{
+			MatchLabels: node.GetLabels(),
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package shared
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	policyv1 "k8s.io/api/policy/v1"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+var _ = Describe("Pod disruption budget", func() {
+
+	tests := []struct {
+		disruption sharedAPI.DisruptionPolicy
+		critical   bool
+		protected  bool
+	}{
+		{sharedAPI.AutoDisruptionPolicy, true, true},
+		{sharedAPI.AutoDisruptionPolicy, false, false},
+		{sharedAPI.ProtectDisruptionPolicy, false, true},
+		{sharedAPI.AllowDisruptionPolicy, true, false},
+	}
+
+	for _, test := range tests {
+		func() {
+			tt := test
+			It(fmt.Sprintf("Should protect=%t node with %s policy and critical=%t", tt.protected, tt.disruption, tt.critical), func() {
+				descriptor := testDescriptor()
+				descriptor.Disruption = tt.disruption
+				descriptor.Critical = tt.critical
+
+				Expect(IsProtected(descriptor)).To(Equal(tt.protected))
+			})
+		}()
+	}
+
+	It("Should spec pod disruption budget", func() {
+		node := testNode()
+		pdb := &policyv1.PodDisruptionBudget{}
+
+		SpecPodDisruptionBudget(node, pdb)
+
+		Expect(pdb.Spec.MaxUnavailable).NotTo(BeNil())
+		Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(0))
+		Expect(pdb.Spec.Selector.MatchLabels["app.kubernetes.io/instance"]).To(Equal("my-node"))
+	})
+
+})
@@ -0,0 +1,72 @@
+package shared
+
+import (
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// node event reasons
+const (
+	// ReasonCreated is recorded when node child resource is created
+	ReasonCreated = "Created"
+	// ReasonConfigChanged is recorded when node config files are updated
+	ReasonConfigChanged = "ConfigChanged"
+	// ReasonRestarting is recorded when node pod template is updated and pods are rolled
+	ReasonRestarting = "Restarting"
+	// ReasonSecretNotFound is recorded when spec references missing secret
+	ReasonSecretNotFound = "SecretNotFound"
+	// ReasonStaticNodeUnresolved is recorded when node reference can't be resolved into enode url
+	ReasonStaticNodeUnresolved = "StaticNodeUnresolved"
+	// ReasonReconcileError is recorded when reconciliation fails
+	ReasonReconcileError = "ReconcileError"
+	// ReasonStorageExpanded is recorded when node storage is expanded automatically
+	ReasonStorageExpanded = "StorageExpanded"
+	// ReasonStorageMaximumReached is recorded when data volume usage exceeds threshold but storage can't be expanded
+	ReasonStorageMaximumReached = "StorageMaximumReached"
+	// ReasonCanaryPromoted is recorded when canary pod kept ready for soak period and remaining pods are updated
+	ReasonCanaryPromoted = "CanaryPromoted"
+	// ReasonRolledBack is recorded when canary pod fails and client image is rolled back
+	ReasonRolledBack = "RolledBack"
+)
+
+// Eventf records event on the object
+// recorder is nil if node resources are rendered offline
+func Eventf(recorder record.EventRecorder, obj client.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	if recorder == nil {
+		return
+	}
+	recorder.Eventf(obj, eventtype, reason, messageFmt, args...)
+}
+
+// RecordOperation records creation of node child resources and node config changes
+func RecordOperation(recorder record.EventRecorder, node client.Object, kind, name string, op controllerutil.OperationResult) {
+	switch op {
+	case controllerutil.OperationResultCreated:
+		Eventf(recorder, node, corev1.EventTypeNormal, ReasonCreated, "Created %s %s", kind, name)
+	case controllerutil.OperationResultUpdated:
+		if kind == "ConfigMap" {
+			Eventf(recorder, node, corev1.EventTypeNormal, ReasonConfigChanged, "Updated node config in ConfigMap %s", name)
+		}
+	}
+}
+
+// RecordError records reconciliation error as warning event on the reconciled object
+func RecordError(recorder record.EventRecorder, obj client.Object, err error) {
+	// conflicts are retried using the latest object version, there's nothing to act on
+	if err == nil || apierrors.IsConflict(err) {
+		return
+	}
+
+	var secretErr *SecretNotFoundError
+	if errors.As(err, &secretErr) {
+		Eventf(recorder, obj, corev1.EventTypeWarning, ReasonSecretNotFound, "Secret %s referenced in spec is not found", secretErr.Name.Name)
+		return
+	}
+
+	Eventf(recorder, obj, corev1.EventTypeWarning, ReasonReconcileError, "Reconciliation failed: %s", err)
+}
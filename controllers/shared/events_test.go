@@ -0,0 +1,148 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+// events returns events recorded by the fake recorder so far
+func events(recorder *record.FakeRecorder) []string {
+	recorded := []string{}
+	for {
+		select {
+		case event := <-recorder.Events:
+			recorded = append(recorded, event)
+		default:
+			return recorded
+		}
+	}
+}
+
+var _ = Describe("Events", func() {
+
+	It("Should record resource operations", func() {
+		node := testNode()
+
+		tests := []struct {
+			kind   string
+			op     controllerutil.OperationResult
+			events []string
+		}{
+			{"StatefulSet", controllerutil.OperationResultCreated, []string{"Normal Created Created StatefulSet my-node"}},
+			{"StatefulSet", controllerutil.OperationResultUpdated, []string{}},
+			{"ConfigMap", controllerutil.OperationResultUpdated, []string{"Normal ConfigChanged Updated node config in ConfigMap my-node"}},
+			{"ConfigMap", controllerutil.OperationResultNone, []string{}},
+		}
+
+		for _, test := range tests {
+			recorder := record.NewFakeRecorder(10)
+			RecordOperation(recorder, node, test.kind, node.Name, test.op)
+			Expect(events(recorder)).To(Equal(test.events), "%s %s", test.kind, test.op)
+		}
+
+		// resources rendered offline don't record events
+		RecordOperation(nil, node, "StatefulSet", node.Name, controllerutil.OperationResultCreated)
+	})
+
+	It("Should record reconciliation errors", func() {
+		node := testNode()
+		name := types.NamespacedName{Name: "rpc-password", Namespace: "default"}
+		notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, name.Name)
+
+		tests := []struct {
+			err    error
+			events []string
+		}{
+			{nil, []string{}},
+			{errors.New("invalid private key"), []string{"Warning ReconcileError Reconciliation failed: invalid private key"}},
+			{fmt.Errorf("config: %w", &SecretNotFoundError{Name: name, Err: notFound}), []string{"Warning SecretNotFound Secret rpc-password referenced in spec is not found"}},
+			{apierrors.NewConflict(schema.GroupResource{Resource: "nodes"}, node.Name, errors.New("modified")), []string{}},
+		}
+
+		for _, test := range tests {
+			recorder := record.NewFakeRecorder(10)
+			RecordError(recorder, node, test.err)
+			Expect(events(recorder)).To(Equal(test.events), "%v", test.err)
+		}
+	})
+
+	It("Should return secret not found error", func() {
+		c, _ := NewRenderClient(func(*runtime.Scheme) error { return nil })
+		name := types.NamespacedName{Name: "rpc-password", Namespace: "default"}
+
+		_, err := GetSecret(context.Background(), c, name, "password")
+
+		var secretErr *SecretNotFoundError
+		Expect(errors.As(err, &secretErr)).To(BeTrue())
+		Expect(secretErr.Name).To(Equal(name))
+		// api server not found error is wrapped
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should record statefulset events", func() {
+		node := testNode()
+		descriptor := testDescriptor()
+		c, scheme := NewRenderClient(func(*runtime.Scheme) error { return nil }, node)
+		recorder := record.NewFakeRecorder(10)
+		ctx := context.Background()
+
+		Expect(ReconcileStatefulSet(ctx, c, scheme, recorder, node, descriptor)).To(Succeed())
+		Expect(events(recorder)).To(Equal([]string{"Normal Created Created StatefulSet my-node"}))
+
+		// unchanged statefulset
+		Expect(ReconcileStatefulSet(ctx, c, scheme, recorder, node, descriptor)).To(Succeed())
+		Expect(events(recorder)).To(BeEmpty())
+
+		descriptor.InitContainers = []corev1.Container{{Name: "init", Image: BusyboxImage}}
+		Expect(ReconcileStatefulSet(ctx, c, scheme, recorder, node, descriptor)).To(Succeed())
+		Expect(events(recorder)).To(Equal([]string{"Normal Restarting Restarting node pods to apply changes"}))
+	})
+
+	It("Should record service monitor, ingress and HTTP route events", func() {
+		node := testNode()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(ServiceMonitorGVK, meta.RESTScopeNamespace)
+		mapper.Add(HTTPRouteGVK, meta.RESTScopeNamespace)
+		c := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).Build()
+		recorder := record.NewFakeRecorder(10)
+		ctx := context.Background()
+
+		endpoint := &MetricsEndpoint{Port: "metrics", Path: "/metrics"}
+		Expect(ReconcileServiceMonitor(ctx, c, scheme, recorder, node, endpoint)).To(Succeed())
+		Expect(events(recorder)).To(Equal([]string{"Normal Created Created ServiceMonitor my-node"}))
+
+		ingress := &sharedAPI.Ingress{
+			Hosts: []sharedAPI.IngressHost{{Hostname: "rpc.example.com"}},
+		}
+		Expect(ReconcileIngress(ctx, c, scheme, recorder, node, ingress, ingressEndpoints)).To(Succeed())
+		Expect(events(recorder)).To(Equal([]string{"Normal Created Created Ingress my-node"}))
+
+		// unchanged ingress
+		Expect(ReconcileIngress(ctx, c, scheme, recorder, node, ingress, ingressEndpoints)).To(Succeed())
+		Expect(events(recorder)).To(BeEmpty())
+
+		ingress.Kind = sharedAPI.IngressKindHTTPRoute
+		ingress.Gateway = "gateways/public"
+		Expect(ReconcileIngress(ctx, c, scheme, recorder, node, ingress, ingressEndpoints)).To(Succeed())
+		Expect(events(recorder)).To(Equal([]string{"Normal Created Created HTTPRoute my-node-rpc"}))
+	})
+
+})
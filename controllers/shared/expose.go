@@ -0,0 +1,109 @@
+package shared
+
+import (
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+// ExposeAnnotationsAnnotation is node service annotation holding comma separated keys of annotations set from node expose
+// it's used to remove annotations that have been removed from node expose
+const ExposeAnnotationsAnnotation = "kotal.io/expose-annotations"
+
+// ExposeService updates node service with exposure outside the cluster
+// node ports of NodePort services are pinned to the ports named in p2pPorts
+// they're pinned to expose node port if set, otherwise to p2p port
+// so the node can announce its external address to peers
+func ExposeService(svc *corev1.Service, expose *sharedAPI.Expose, p2pPorts ...string) {
+	var annotations map[string]string
+	if expose != nil {
+		annotations = expose.Annotations
+	}
+	exposeAnnotations(svc, annotations)
+
+	if expose == nil {
+		svc.Spec.Type = corev1.ServiceTypeClusterIP
+		svc.Spec.LoadBalancerIP = ""
+		// only allowed for NodePort and LoadBalancer services
+		svc.Spec.ExternalTrafficPolicy = ""
+		svc.Spec.AllocateLoadBalancerNodePorts = nil
+		return
+	}
+
+	svc.Spec.Type = expose.ServiceType
+
+	if expose.ServiceType == corev1.ServiceTypeLoadBalancer {
+		svc.Spec.LoadBalancerIP = expose.ExternalIP
+	} else {
+		svc.Spec.LoadBalancerIP = ""
+		svc.Spec.AllocateLoadBalancerNodePorts = nil
+	}
+
+	if expose.ServiceType == corev1.ServiceTypeNodePort {
+		for i := range svc.Spec.Ports {
+			port := &svc.Spec.Ports[i]
+			for _, name := range p2pPorts {
+				if port.Name == name {
+					port.NodePort = int32(expose.AnnouncedPort(uint(port.Port)))
+				}
+			}
+		}
+	}
+}
+
+// exposeAnnotations sets expose annotations on node service, and removes annotations previously set from expose
+// annotations set by others like cloud controllers are kept
+func exposeAnnotations(svc *corev1.Service, exposed map[string]string) {
+	if len(exposed) == 0 && svc.Annotations[ExposeAnnotationsAnnotation] == "" {
+		return
+	}
+
+	annotations := map[string]string{}
+	for k, v := range svc.Annotations {
+		annotations[k] = v
+	}
+
+	if managed := annotations[ExposeAnnotationsAnnotation]; managed != "" {
+		for _, k := range strings.Split(managed, ",") {
+			delete(annotations, k)
+		}
+	}
+	delete(annotations, ExposeAnnotationsAnnotation)
+
+	keys := make([]string, 0, len(exposed))
+	for k, v := range exposed {
+		annotations[k] = v
+		keys = append(keys, k)
+	}
+
+	if len(keys) != 0 {
+		sort.Strings(keys)
+		annotations[ExposeAnnotationsAnnotation] = strings.Join(keys, ",")
+	}
+
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	svc.Annotations = annotations
+}
+
+// ServiceIP returns the IP address node is reachable at by peers
+// it's the static external IP, or load balancer ingress IP, falling back to cluster IP
+func ServiceIP(svc *corev1.Service, expose *sharedAPI.Expose) string {
+	if expose != nil {
+		if expose.ExternalIP != "" {
+			return expose.ExternalIP
+		}
+		if expose.ServiceType == corev1.ServiceTypeLoadBalancer {
+			for _, ingress := range svc.Status.LoadBalancer.Ingress {
+				if ingress.IP != "" {
+					return ingress.IP
+				}
+			}
+		}
+	}
+	return svc.Spec.ClusterIP
+}
@@ -0,0 +1,114 @@
+package shared
+
+import (
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Service exposure", func() {
+
+	It("Should expose node service", func() {
+		svc := corev1.Service{}
+		svc.Spec.Ports = []corev1.ServicePort{
+			{Name: "p2p", Port: 30303},
+			{Name: "rpc", Port: 8545},
+		}
+
+		ExposeService(&svc, &sharedAPI.Expose{
+			ServiceType: corev1.ServiceTypeNodePort,
+			Annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/hostname": "node.example.com",
+			},
+		}, "p2p")
+
+		Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeNodePort))
+		// p2p node port is pinned, rpc node port is allocated
+		Expect(svc.Spec.Ports[0].NodePort).To(Equal(int32(30303)))
+		Expect(svc.Spec.Ports[1].NodePort).To(BeZero())
+		Expect(svc.Annotations).To(Equal(map[string]string{
+			"external-dns.alpha.kubernetes.io/hostname": "node.example.com",
+			ExposeAnnotationsAnnotation:                 "external-dns.alpha.kubernetes.io/hostname",
+		}))
+
+		ExposeService(&svc, &sharedAPI.Expose{
+			ServiceType: corev1.ServiceTypeLoadBalancer,
+			ExternalIP:  "203.0.113.10",
+		}, "p2p")
+
+		Expect(svc.Spec.LoadBalancerIP).To(Equal("203.0.113.10"))
+
+		svc.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
+		ExposeService(&svc, nil)
+
+		Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+		Expect(svc.Spec.LoadBalancerIP).To(BeEmpty())
+		Expect(svc.Spec.ExternalTrafficPolicy).To(BeEmpty())
+	})
+
+	It("Should remove annotations removed from node expose", func() {
+		svc := corev1.Service{}
+		// set by cloud controller
+		svc.Annotations = map[string]string{"cloud.example.com/lb-id": "lb-1"}
+
+		ExposeService(&svc, &sharedAPI.Expose{
+			ServiceType: corev1.ServiceTypeLoadBalancer,
+			Annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/hostname": "node.example.com",
+				"external-dns.alpha.kubernetes.io/ttl":      "60",
+			},
+		})
+
+		ExposeService(&svc, &sharedAPI.Expose{
+			ServiceType: corev1.ServiceTypeLoadBalancer,
+			Annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/hostname": "node.example.com",
+			},
+		})
+
+		Expect(svc.Annotations).To(Equal(map[string]string{
+			"cloud.example.com/lb-id":                   "lb-1",
+			"external-dns.alpha.kubernetes.io/hostname": "node.example.com",
+			ExposeAnnotationsAnnotation:                 "external-dns.alpha.kubernetes.io/hostname",
+		}))
+
+		ExposeService(&svc, nil)
+
+		Expect(svc.Annotations).To(Equal(map[string]string{
+			"cloud.example.com/lb-id": "lb-1",
+		}))
+	})
+
+	It("Should pin p2p ports to node port", func() {
+		svc := corev1.Service{}
+		svc.Spec.Ports = []corev1.ServicePort{
+			{Name: "p2p", Port: 9000, Protocol: corev1.ProtocolTCP},
+			{Name: "discovery", Port: 9000, Protocol: corev1.ProtocolUDP},
+		}
+
+		ExposeService(&svc, &sharedAPI.Expose{
+			ServiceType: corev1.ServiceTypeNodePort,
+			NodePort:    31000,
+		}, "p2p", "discovery")
+
+		for _, port := range svc.Spec.Ports {
+			Expect(port.NodePort).To(Equal(int32(31000)), "port %s", port.Name)
+		}
+	})
+
+	It("Should get IP address node is reachable at by peers", func() {
+		svc := corev1.Service{}
+		svc.Spec.ClusterIP = "10.96.0.10"
+		svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{
+			{Hostname: "lb.example.com"},
+			{IP: "198.51.100.7"},
+		}
+
+		Expect(ServiceIP(&svc, nil)).To(Equal("10.96.0.10"))
+		Expect(ServiceIP(&svc, &sharedAPI.Expose{ServiceType: corev1.ServiceTypeNodePort})).To(Equal("10.96.0.10"))
+		Expect(ServiceIP(&svc, &sharedAPI.Expose{ServiceType: corev1.ServiceTypeLoadBalancer})).To(Equal("198.51.100.7"))
+		Expect(ServiceIP(&svc, &sharedAPI.Expose{ServiceType: corev1.ServiceTypeLoadBalancer, ExternalIP: "203.0.113.10"})).To(Equal("203.0.113.10"))
+	})
+
+})
@@ -0,0 +1,324 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+// HTTPRouteGVK is gateway API HTTP route group, version and kind
+var HTTPRouteGVK = schema.GroupVersionKind{
+	Group:   "gateway.networking.k8s.io",
+	Version: "v1beta1",
+	Kind:    "HTTPRoute",
+}
+
+const (
+	// AnnotationClusterIssuer is cert-manager annotation used to issue ingress certificate from cluster issuer
+	AnnotationClusterIssuer = "cert-manager.io/cluster-issuer"
+	// AnnotationIssuer is cert-manager annotation used to issue ingress certificate from namespaced issuer
+	AnnotationIssuer = "cert-manager.io/issuer"
+)
+
+// IngressEndpoint is node API endpoint that can be routed from outside the cluster
+type IngressEndpoint struct {
+	// Name is node service port name
+	Name string
+	// Port is node service port
+	Port uint
+}
+
+// IsHTTPRouteInstalled checks if gateway API HTTPRoute CRD exists in the cluster
+func IsHTTPRouteInstalled(c client.Client) (bool, error) {
+	_, err := c.RESTMapper().RESTMapping(HTTPRouteGVK.GroupKind(), HTTPRouteGVK.Version)
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ReconcileIngress creates node ingress or HTTP routes to node API endpoints if ingress is provided
+// generated routes of other kinds, or routing to disabled endpoints are deleted
+func ReconcileIngress(ctx context.Context, c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, node client.Object, ingress *sharedAPI.Ingress, endpoints []IngressEndpoint) error {
+	routes, err := IngressRoutes(ingress, endpoints)
+	if err != nil {
+		return err
+	}
+
+	kind := IngressKind(ingress)
+
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      node.GetName(),
+			Namespace: node.GetNamespace(),
+		},
+	}
+
+	if len(routes) == 0 || kind != sharedAPI.IngressKindIngress {
+		if err := client.IgnoreNotFound(c.Delete(ctx, ing)); err != nil {
+			return err
+		}
+	} else {
+		op, err := ctrl.CreateOrUpdate(ctx, c, ing, func() error {
+			if err := ctrl.SetControllerReference(node, ing, scheme); err != nil {
+				return err
+			}
+			SpecIngress(node, ing, ingress, routes)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		RecordOperation(recorder, node, "Ingress", ing.Name, op)
+	}
+
+	return reconcileHTTPRoutes(ctx, c, scheme, recorder, node, ingress, kind, routes)
+}
+
+// reconcileHTTPRoutes creates HTTP route for each routed node API endpoint, and deletes stale ones
+func reconcileHTTPRoutes(ctx context.Context, c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, node client.Object, ingress *sharedAPI.Ingress, kind sharedAPI.IngressKind, routes []IngressRoute) error {
+	installed, err := IsHTTPRouteInstalled(c)
+	if err != nil {
+		return err
+	}
+
+	if !installed {
+		if kind == sharedAPI.IngressKindHTTPRoute && len(routes) != 0 {
+			return fmt.Errorf("gateway API %s CRD is not installed", HTTPRouteGVK.Kind)
+		}
+		return nil
+	}
+
+	desired := map[string]bool{}
+
+	if kind == sharedAPI.IngressKindHTTPRoute {
+		endpoints, hostnames := EndpointHostnames(routes)
+
+		for _, endpoint := range endpoints {
+			endpoint := endpoint
+			route := NewHTTPRoute(node, endpoint)
+			desired[route.GetName()] = true
+
+			op, err := ctrl.CreateOrUpdate(ctx, c, route, func() error {
+				if err := ctrl.SetControllerReference(node, route, scheme); err != nil {
+					return err
+				}
+				return SpecHTTPRoute(node, route, ingress, endpoint, hostnames[endpoint])
+			})
+			if err != nil {
+				return err
+			}
+
+			RecordOperation(recorder, node, "HTTPRoute", route.GetName(), op)
+		}
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(HTTPRouteGVK.GroupVersion().WithKind(HTTPRouteGVK.Kind + "List"))
+	if err = c.List(ctx, list, client.InNamespace(node.GetNamespace()), client.MatchingLabels(node.GetLabels())); err != nil {
+		return err
+	}
+
+	for i := range list.Items {
+		route := &list.Items[i]
+		if desired[route.GetName()] || !metav1.IsControlledBy(route, node) {
+			continue
+		}
+		if err = client.IgnoreNotFound(c.Delete(ctx, route)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IngressKind returns kind of resources routing hostnames to node API endpoints, defaults to ingress
+func IngressKind(ingress *sharedAPI.Ingress) sharedAPI.IngressKind {
+	if ingress != nil && ingress.Kind != "" {
+		return ingress.Kind
+	}
+	return sharedAPI.IngressKindIngress
+}
+
+// EndpointHostnames returns routed node API endpoints in routes order and hostnames routed to each of them
+// gateway API route rules can't match hostnames, so each endpoint gets its own HTTP route
+func EndpointHostnames(routes []IngressRoute) ([]IngressEndpoint, map[IngressEndpoint][]string) {
+	endpoints := []IngressEndpoint{}
+	hostnames := map[IngressEndpoint][]string{}
+	for _, r := range routes {
+		if _, ok := hostnames[r.Endpoint]; !ok {
+			endpoints = append(endpoints, r.Endpoint)
+		}
+		hostnames[r.Endpoint] = append(hostnames[r.Endpoint], r.Hostname)
+	}
+	return endpoints, hostnames
+}
+
+// NewHTTPRoute returns empty HTTP route of node API endpoint
+func NewHTTPRoute(node client.Object, endpoint IngressEndpoint) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(HTTPRouteGVK)
+	route.SetName(fmt.Sprintf("%s-%s", node.GetName(), endpoint.Name))
+	route.SetNamespace(node.GetNamespace())
+	return route
+}
+
+// IngressRoute is hostname routed to node API endpoint
+type IngressRoute struct {
+	// Hostname is routed hostname
+	Hostname string
+	// Endpoint is node API endpoint
+	Endpoint IngressEndpoint
+}
+
+// IngressRoutes returns hostnames routed to node API endpoints in hosts order
+// hosts without endpoint are routed to the first endpoint
+func IngressRoutes(ingress *sharedAPI.Ingress, endpoints []IngressEndpoint) (routes []IngressRoute, err error) {
+	if ingress == nil || len(endpoints) == 0 {
+		return
+	}
+
+	for _, host := range ingress.Hosts {
+		endpoint, found := endpoints[0], host.Endpoint == ""
+		for _, e := range endpoints {
+			if e.Name == host.Endpoint {
+				endpoint, found = e, true
+			}
+		}
+		if !found {
+			names := []string{}
+			for _, e := range endpoints {
+				names = append(names, e.Name)
+			}
+			err = fmt.Errorf("ingress host %s endpoint %s is not one of enabled endpoints %s", host.Hostname, host.Endpoint, strings.Join(names, ", "))
+			return
+		}
+		routes = append(routes, IngressRoute{Hostname: host.Hostname, Endpoint: endpoint})
+	}
+
+	return
+}
+
+// SpecIngress updates node ingress spec to route hostnames to node service endpoints
+// TLS is terminated by the ingress controller using certificate issued by cert-manager if issuer is provided
+func SpecIngress(node client.Object, ing *networkingv1.Ingress, ingress *sharedAPI.Ingress, routes []IngressRoute) {
+	ing.SetLabels(node.GetLabels())
+
+	annotations := map[string]string{}
+	for k, v := range ingress.Annotations {
+		annotations[k] = v
+	}
+	if ingress.Issuer != "" {
+		if ingress.IssuerKind == sharedAPI.IssuerKindIssuer {
+			annotations[AnnotationIssuer] = ingress.Issuer
+		} else {
+			annotations[AnnotationClusterIssuer] = ingress.Issuer
+		}
+	}
+	ing.SetAnnotations(annotations)
+
+	if ingress.ClassName != "" {
+		className := ingress.ClassName
+		ing.Spec.IngressClassName = &className
+	} else {
+		ing.Spec.IngressClassName = nil
+	}
+
+	pathType := networkingv1.PathTypePrefix
+	rules := []networkingv1.IngressRule{}
+	hostnames := []string{}
+
+	for _, route := range routes {
+		rules = append(rules, networkingv1.IngressRule{
+			Host: route.Hostname,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{
+							Path:     "/",
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: node.GetName(),
+									Port: networkingv1.ServiceBackendPort{
+										Name: route.Endpoint.Name,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+		hostnames = append(hostnames, route.Hostname)
+	}
+
+	ing.Spec.Rules = rules
+
+	if ingress.Issuer != "" {
+		ing.Spec.TLS = []networkingv1.IngressTLS{
+			{
+				Hosts:      hostnames,
+				SecretName: fmt.Sprintf("%s-tls", node.GetName()),
+			},
+		}
+	} else {
+		ing.Spec.TLS = nil
+	}
+}
+
+// SpecHTTPRoute updates HTTP route spec to route hostnames to node service endpoint through parent gateway
+func SpecHTTPRoute(node client.Object, route *unstructured.Unstructured, ingress *sharedAPI.Ingress, endpoint IngressEndpoint, hostnames []string) error {
+	// route is empty if it hasn't been created yet
+	if route.Object == nil {
+		route.Object = map[string]interface{}{}
+	}
+
+	route.SetLabels(node.GetLabels())
+	if len(ingress.Annotations) != 0 {
+		route.SetAnnotations(ingress.Annotations)
+	}
+
+	parentRef := map[string]interface{}{}
+	if parts := strings.SplitN(ingress.Gateway, "/", 2); len(parts) == 2 {
+		parentRef["namespace"] = parts[0]
+		parentRef["name"] = parts[1]
+	} else {
+		parentRef["name"] = ingress.Gateway
+	}
+
+	hosts := []interface{}{}
+	for _, hostname := range hostnames {
+		hosts = append(hosts, hostname)
+	}
+
+	spec := map[string]interface{}{
+		"parentRefs": []interface{}{parentRef},
+		"hostnames":  hosts,
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": node.GetName(),
+						"port": int64(endpoint.Port),
+					},
+				},
+			},
+		},
+	}
+
+	return unstructured.SetNestedField(route.Object, spec, "spec")
+}
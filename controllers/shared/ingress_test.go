@@ -0,0 +1,113 @@
+package shared
+
+import (
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var ingressEndpoints = []IngressEndpoint{
+	{Name: "rpc", Port: 8545},
+	{Name: "ws", Port: 8546},
+}
+
+var _ = Describe("Ingress", func() {
+
+	It("Should route ingress hosts to node endpoints", func() {
+		ingress := &sharedAPI.Ingress{
+			Hosts: []sharedAPI.IngressHost{
+				{Hostname: "rpc.example.com"},
+				{Hostname: "ws.example.com", Endpoint: "ws"},
+			},
+		}
+
+		routes, err := IngressRoutes(ingress, ingressEndpoints)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(Equal([]IngressRoute{
+			{Hostname: "rpc.example.com", Endpoint: ingressEndpoints[0]},
+			{Hostname: "ws.example.com", Endpoint: ingressEndpoints[1]},
+		}))
+
+		// graphql endpoint is disabled
+		ingress.Hosts = append(ingress.Hosts, sharedAPI.IngressHost{Hostname: "graphql.example.com", Endpoint: "graphql"})
+		_, err = IngressRoutes(ingress, ingressEndpoints)
+		Expect(err).To(HaveOccurred())
+
+		routes, _ = IngressRoutes(nil, ingressEndpoints)
+		Expect(routes).To(BeEmpty())
+	})
+
+	It("Should spec ingress", func() {
+		node := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "my-node",
+				Labels: map[string]string{"app.kubernetes.io/instance": "my-node"},
+			},
+		}
+
+		ingress := &sharedAPI.Ingress{
+			Hosts: []sharedAPI.IngressHost{
+				{Hostname: "rpc.example.com"},
+				{Hostname: "ws.example.com", Endpoint: "ws"},
+			},
+			ClassName: "nginx",
+			Issuer:    "letsencrypt",
+		}
+
+		routes, _ := IngressRoutes(ingress, ingressEndpoints)
+		ing := &networkingv1.Ingress{}
+		SpecIngress(node, ing, ingress, routes)
+
+		Expect(ing.Annotations).To(HaveKeyWithValue(AnnotationClusterIssuer, "letsencrypt"))
+		Expect(ing.Spec.IngressClassName).NotTo(BeNil())
+		Expect(*ing.Spec.IngressClassName).To(Equal("nginx"))
+		Expect(ing.Spec.Rules).To(HaveLen(2))
+
+		rule := ing.Spec.Rules[1]
+		Expect(rule.Host).To(Equal("ws.example.com"))
+		backend := rule.HTTP.Paths[0].Backend.Service
+		Expect(backend.Name).To(Equal("my-node"))
+		Expect(backend.Port.Name).To(Equal("ws"))
+
+		Expect(ing.Spec.TLS).To(Equal([]networkingv1.IngressTLS{
+			{
+				Hosts:      []string{"rpc.example.com", "ws.example.com"},
+				SecretName: "my-node-tls",
+			},
+		}))
+	})
+
+	It("Should spec HTTP route", func() {
+		node := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-node",
+			},
+		}
+
+		ingress := &sharedAPI.Ingress{
+			Kind:    sharedAPI.IngressKindHTTPRoute,
+			Gateway: "gateways/public",
+		}
+
+		// route object is nil before the route is created
+		route := &unstructured.Unstructured{}
+		Expect(SpecHTTPRoute(node, route, ingress, ingressEndpoints[1], []string{"ws.example.com"})).To(Succeed())
+
+		parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+		Expect(parentRefs).To(Equal([]interface{}{
+			map[string]interface{}{"namespace": "gateways", "name": "public"},
+		}))
+
+		hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+		Expect(hostnames).To(Equal([]string{"ws.example.com"}))
+
+		rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+		backendRefs := rules[0].(map[string]interface{})["backendRefs"].([]interface{})
+		Expect(backendRefs[0]).To(Equal(map[string]interface{}{"name": "my-node", "port": int64(8546)}))
+	})
+
+})
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
 type CustomResource interface {
@@ -17,17 +19,35 @@ type CustomResource interface {
 func UpdateLabels(cr CustomResource, client string) {
 
 	gvk := cr.GroupVersionKind()
-	group := strings.Replace(gvk.Group, ".kotal.io", "", 1)
-	kind := strings.ToLower(gvk.Kind)
 
 	labels := map[string]string{
 		"app.kubernetes.io/name":       client,
 		"app.kubernetes.io/instance":   cr.GetName(),
-		"app.kubernetes.io/component":  fmt.Sprintf("%s-%s", group, kind),
+		"app.kubernetes.io/component":  component(gvk),
 		"app.kubernetes.io/managed-by": "kotal",
-		"app.kubernetes.io/created-by": fmt.Sprintf("%s-%s-controller", group, kind),
+		"app.kubernetes.io/created-by": fmt.Sprintf("%s-controller", component(gvk)),
 	}
 
 	cr.SetLabels(labels)
 
 }
+
+// component returns component label of resources of the given kind
+func component(gvk schema.GroupVersionKind) string {
+	group := strings.Replace(gvk.Group, ".kotal.io", "", 1)
+	kind := strings.ToLower(gvk.Kind)
+	return fmt.Sprintf("%s-%s", group, kind)
+}
+
+// keepGVK runs write and restores node group, version and kind reset by the client
+// labels are computed from node group, version and kind after the node is written
+func keepGVK(c client.Client, node client.Object, write func() error) error {
+	gvk, err := apiutil.GVKForObject(node, c.Scheme())
+	if err != nil {
+		return err
+	}
+
+	defer node.GetObjectKind().SetGroupVersionKind(gvk)
+
+	return write()
+}
@@ -0,0 +1,200 @@
+package shared
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// node reconciliation phases reported in reconcile errors metric
+const (
+	PhaseSecret              = "secret"
+	PhaseConfigmap           = "configmap"
+	PhasePVC                 = "pvc"
+	PhaseService             = "service"
+	PhaseServiceMonitor      = "servicemonitor"
+	PhaseIngress             = "ingress"
+	PhasePodDisruptionBudget = "poddisruptionbudget"
+	PhaseNetworkPolicy       = "networkpolicy"
+	PhaseStatefulSet         = "statefulset"
+	PhaseOther               = "other"
+)
+
+// PhaseError is node reconciliation error with the phase it occurred in
+type PhaseError struct {
+	// Phase is reconciliation phase
+	Phase string
+	// Err is the reconciliation error
+	Err error
+}
+
+func (e *PhaseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PhaseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorPhase returns reconciliation phase the error occurred in
+// missing secrets are reported in secret phase regardless of the resource referencing them
+func ErrorPhase(err error) string {
+	var secretErr *SecretNotFoundError
+	if errors.As(err, &secretErr) {
+		return PhaseSecret
+	}
+
+	var phaseErr *PhaseError
+	if errors.As(err, &phaseErr) {
+		return phaseErr.Phase
+	}
+
+	return PhaseOther
+}
+
+// nodeKey identifies node in operator metrics
+type nodeKey struct {
+	group, kind, namespace, name string
+}
+
+// nodeState is node state tracked by operator metrics
+type nodeState struct {
+	client, network string
+	lastSuccess     time.Time
+}
+
+// NodeMetrics is prometheus collector of operator metrics about the nodes it manages
+type NodeMetrics struct {
+	mu    sync.Mutex
+	now   func() time.Time
+	nodes map[nodeKey]*nodeState
+
+	managedResources   *prometheus.Desc
+	lastSuccessSeconds *prometheus.Desc
+	reconcileErrors    *prometheus.CounterVec
+}
+
+// NewNodeMetrics creates operator metrics collector, now is used to calculate time since last successful reconciliation
+func NewNodeMetrics(now func() time.Time) *NodeMetrics {
+	return &NodeMetrics{
+		now:   now,
+		nodes: map[nodeKey]*nodeState{},
+		managedResources: prometheus.NewDesc(
+			"kotal_managed_resources",
+			"Number of resources managed by the operator",
+			[]string{"group", "kind", "client", "network"},
+			nil,
+		),
+		lastSuccessSeconds: prometheus.NewDesc(
+			"kotal_seconds_since_last_successful_reconcile",
+			"Seconds since the resource was last reconciled successfully",
+			[]string{"group", "kind", "namespace", "name"},
+			nil,
+		),
+		reconcileErrors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "kotal_reconcile_errors_total",
+				Help: "Number of reconciliation errors by reconciliation phase",
+			},
+			[]string{"group", "kind", "phase"},
+		),
+	}
+}
+
+// Observe records node reconciliation outcome
+// deleted nodes are no longer reported as managed resources
+// node kind is looked up in the scheme, the client resets it when the node is written
+func (m *NodeMetrics) Observe(scheme *runtime.Scheme, node client.Object, network string, err error) {
+	gvk, gvkErr := apiutil.GVKForObject(node, scheme)
+	if gvkErr != nil {
+		return
+	}
+	group := strings.Replace(gvk.Group, ".kotal.io", "", 1)
+	key := nodeKey{group, gvk.Kind, node.GetNamespace(), node.GetName()}
+
+	// conflicts are retried using the latest object version
+	if err != nil && !apierrors.IsConflict(err) {
+		m.reconcileErrors.WithLabelValues(group, gvk.Kind, ErrorPhase(err)).Inc()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !node.GetDeletionTimestamp().IsZero() {
+		delete(m.nodes, key)
+		return
+	}
+
+	state, ok := m.nodes[key]
+	if !ok {
+		state = &nodeState{}
+		m.nodes[key] = state
+	}
+
+	// node labels are missing if reconciliation failed before they're updated
+	if client := node.GetLabels()["app.kubernetes.io/name"]; client != "" {
+		state.client = client
+	}
+	if network != "" {
+		state.network = network
+	}
+
+	if err == nil {
+		state.lastSuccess = m.now()
+	}
+}
+
+// Describe implements prometheus.Collector
+func (m *NodeMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.managedResources
+	ch <- m.lastSuccessSeconds
+	m.reconcileErrors.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (m *NodeMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	type resourceKey struct {
+		group, kind, client, network string
+	}
+
+	counts := map[resourceKey]int{}
+	now := m.now()
+
+	for key, state := range m.nodes {
+		counts[resourceKey{key.group, key.kind, state.client, state.network}]++
+
+		if !state.lastSuccess.IsZero() {
+			since := now.Sub(state.lastSuccess).Seconds()
+			ch <- prometheus.MustNewConstMetric(m.lastSuccessSeconds, prometheus.GaugeValue, since, key.group, key.kind, key.namespace, key.name)
+		}
+	}
+
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(m.managedResources, prometheus.GaugeValue, float64(count), key.group, key.kind, key.client, key.network)
+	}
+
+	m.reconcileErrors.Collect(ch)
+}
+
+// operatorMetrics is exposed on the manager metrics endpoint
+var operatorMetrics = NewNodeMetrics(time.Now)
+
+func init() {
+	metrics.Registry.MustRegister(operatorMetrics)
+}
+
+// ObserveReconcile records node reconciliation outcome in operator metrics
+func ObserveReconcile(scheme *runtime.Scheme, node client.Object, network string, err error) {
+	operatorMetrics.Observe(scheme, node, network, err)
+}
@@ -0,0 +1,112 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+)
+
+// metricsNode returns ethereum node used by operator metrics tests
+// node kind is empty as it's reset by the client when the node is written
+func metricsNode(name string) *ethereumv1alpha1.Node {
+	return &ethereumv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				"app.kubernetes.io/name": "geth",
+			},
+		},
+	}
+}
+
+var _ = Describe("Operator metrics", func() {
+
+	It("Should annotate node reconciliation errors with phase", func() {
+		node := &corev1.Pod{ObjectMeta: testNode().ObjectMeta}
+		descriptor := testDescriptor()
+		descriptor.Hooks.Secret = func(context.Context) error {
+			return errors.New("invalid private key")
+		}
+		c, scheme := NewRenderClient(func(*runtime.Scheme) error { return nil }, node)
+
+		err := ReconcileNode(context.Background(), c, scheme, nil, node, descriptor)
+		Expect(ErrorPhase(err)).To(Equal(PhaseSecret))
+	})
+
+	It("Should return reconciliation error phase", func() {
+		notFound := &SecretNotFoundError{Name: types.NamespacedName{Name: "nodekey", Namespace: "default"}}
+
+		tests := []struct {
+			err   error
+			phase string
+		}{
+			{&PhaseError{Phase: PhasePVC, Err: errors.New("storage class not found")}, PhasePVC},
+			{&PhaseError{Phase: PhaseConfigmap, Err: notFound}, PhaseSecret},
+			{notFound, PhaseSecret},
+			{errors.New("invalid private key"), PhaseOther},
+		}
+
+		for _, test := range tests {
+			Expect(ErrorPhase(test.err)).To(Equal(test.phase), "%v", test.err)
+		}
+	})
+
+	It("Should collect node metrics", func() {
+		now := time.Date(2022, 4, 1, 12, 0, 0, 0, time.UTC)
+		m := NewNodeMetrics(func() time.Time { return now })
+
+		scheme := runtime.NewScheme()
+		Expect(ethereumv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		healthy, failing := metricsNode("healthy"), metricsNode("failing")
+		conflict := apierrors.NewConflict(schema.GroupResource{Resource: "nodes"}, "failing", errors.New("modified"))
+
+		m.Observe(scheme, healthy, "goerli", nil)
+		m.Observe(scheme, failing, "goerli", &PhaseError{Phase: PhaseStatefulSet, Err: errors.New("invalid resources")})
+		m.Observe(scheme, failing, "goerli", &SecretNotFoundError{Name: types.NamespacedName{Name: "nodekey", Namespace: "default"}})
+		m.Observe(scheme, failing, "goerli", conflict)
+
+		now = now.Add(30 * time.Second)
+
+		expected := `
+# HELP kotal_managed_resources Number of resources managed by the operator
+# TYPE kotal_managed_resources gauge
+kotal_managed_resources{client="geth",group="ethereum",kind="Node",network="goerli"} 2
+# HELP kotal_reconcile_errors_total Number of reconciliation errors by reconciliation phase
+# TYPE kotal_reconcile_errors_total counter
+kotal_reconcile_errors_total{group="ethereum",kind="Node",phase="secret"} 1
+kotal_reconcile_errors_total{group="ethereum",kind="Node",phase="statefulset"} 1
+# HELP kotal_seconds_since_last_successful_reconcile Seconds since the resource was last reconciled successfully
+# TYPE kotal_seconds_since_last_successful_reconcile gauge
+kotal_seconds_since_last_successful_reconcile{group="ethereum",kind="Node",name="healthy",namespace="default"} 30
+`
+		Expect(testutil.CollectAndCompare(m, strings.NewReader(expected))).To(Succeed())
+
+		// deleted nodes are no longer managed
+		deletionTimestamp := metav1.NewTime(now)
+		healthy.DeletionTimestamp = &deletionTimestamp
+		m.Observe(scheme, healthy, "goerli", nil)
+
+		expected = `
+# HELP kotal_managed_resources Number of resources managed by the operator
+# TYPE kotal_managed_resources gauge
+kotal_managed_resources{client="geth",group="ethereum",kind="Node",network="goerli"} 1
+`
+		Expect(testutil.CollectAndCompare(m, strings.NewReader(expected), "kotal_managed_resources", "kotal_seconds_since_last_successful_reconcile")).To(Succeed())
+	})
+
+})
@@ -0,0 +1,150 @@
+package shared
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+// apiConsumers is components of kotal nodes consuming node API by node component
+var apiConsumers = map[string][]string{
+	"ethereum-node":        {"ethereum2-beaconnode", "chainlink-node"},
+	"ethereum2-beaconnode": {"ethereum2-validator"},
+	"bitcoin-node":         {"stacks-node"},
+	"ipfs-peer":            {"ipfs-clusterpeer", "filecoin-node"},
+	"ipfs-clusterpeer":     {"ipfs-clusterpeer"},
+}
+
+// ReconcileNetworkPolicy creates node network policy if node has network policy, otherwise deletes it
+// network policy has the same name as the node
+func ReconcileNetworkPolicy(ctx context.Context, c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, node client.Object, descriptor *NodeDescriptor) error {
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      node.GetName(),
+			Namespace: node.GetNamespace(),
+		},
+	}
+
+	if descriptor.NetworkPolicy == nil {
+		return client.IgnoreNotFound(c.Delete(ctx, policy))
+	}
+
+	op, err := ctrl.CreateOrUpdate(ctx, c, policy, func() error {
+		if err := ctrl.SetControllerReference(node, policy, scheme); err != nil {
+			return err
+		}
+		SpecNetworkPolicy(node, policy, descriptor)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	RecordOperation(recorder, node, "NetworkPolicy", policy.Name, op)
+
+	return nil
+}
+
+// SpecNetworkPolicy updates node network policy spec
+// peer to peer and metrics ports are open to everyone, other service ports are node API ports
+// API ports are open to pods allowed by node network policy and kotal nodes consuming node API
+func SpecNetworkPolicy(node client.Object, policy *networkingv1.NetworkPolicy, descriptor *NodeDescriptor) {
+	open := map[string]bool{}
+	for _, name := range descriptor.P2PPorts {
+		open[name] = true
+	}
+	if descriptor.Metrics != nil {
+		open[descriptor.Metrics.Port] = true
+	}
+
+	openPorts := []networkingv1.NetworkPolicyPort{}
+	apiPorts := []networkingv1.NetworkPolicyPort{}
+
+	for _, port := range descriptor.Ports {
+		if open[port.Name] {
+			openPorts = append(openPorts, networkPolicyPort(port))
+		} else {
+			apiPorts = append(apiPorts, networkPolicyPort(port))
+		}
+	}
+
+	rules := []networkingv1.NetworkPolicyIngressRule{}
+
+	if len(openPorts) != 0 {
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{Ports: openPorts})
+	}
+
+	// rule without peers allows everyone, API ports are closed if no peers are allowed
+	if peers := apiPeers(node, descriptor.NetworkPolicy); len(apiPorts) != 0 && len(peers) != 0 {
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+			Ports: apiPorts,
+			From:  peers,
+		})
+	}
+
+	policy.ObjectMeta.Labels = node.GetLabels()
+	policy.Spec = networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: node.GetLabels(),
+		},
+		PolicyTypes: []networkingv1.PolicyType{
+			networkingv1.PolicyTypeIngress,
+		},
+		Ingress: rules,
+	}
+}
+
+// apiPeers returns peers allowed to access node API ports
+// kotal nodes consuming node API are allowed from all namespaces
+func apiPeers(node client.Object, policy *sharedAPI.NetworkPolicy) []networkingv1.NetworkPolicyPeer {
+	peers := []networkingv1.NetworkPolicyPeer{}
+
+	for _, peer := range policy.From {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			PodSelector:       peer.PodSelector.DeepCopy(),
+			NamespaceSelector: peer.NamespaceSelector.DeepCopy(),
+		})
+	}
+
+	for _, component := range apiConsumers[node.GetLabels()["app.kubernetes.io/component"]] {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/component":  component,
+					"app.kubernetes.io/managed-by": "kotal",
+				},
+			},
+			NamespaceSelector: &metav1.LabelSelector{},
+		})
+	}
+
+	return peers
+}
+
+// networkPolicyPort returns network policy port of service port
+// network policy ports are matched against pod ports, so service target port is used
+func networkPolicyPort(port corev1.ServicePort) networkingv1.NetworkPolicyPort {
+	target := port.TargetPort
+	if target.IntValue() == 0 && target.Type == intstr.Int {
+		target = intstr.FromInt(int(port.Port))
+	}
+
+	protocol := port.Protocol
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+
+	return networkingv1.NetworkPolicyPort{
+		Protocol: &protocol,
+		Port:     &target,
+	}
+}
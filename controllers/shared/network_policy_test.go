@@ -0,0 +1,91 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+var _ = Describe("Network policy", func() {
+
+	It("Should restrict API ports to allowed pods and API consumers", func() {
+		node := testNode()
+		node.Labels["app.kubernetes.io/component"] = "ethereum-node"
+
+		descriptor := testDescriptor()
+		descriptor.Ports = append(descriptor.Ports,
+			corev1.ServicePort{Name: "json-rpc", Port: 8545, TargetPort: intstr.FromInt(8545), Protocol: corev1.ProtocolTCP},
+			corev1.ServicePort{Name: "metrics", Port: 6060, TargetPort: intstr.FromInt(6060), Protocol: corev1.ProtocolTCP},
+		)
+		descriptor.Metrics = &MetricsEndpoint{Port: "metrics", Path: "/metrics"}
+		descriptor.NetworkPolicy = &sharedAPI.NetworkPolicy{
+			From: []sharedAPI.NetworkPolicyPeer{
+				{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "dapp"}}},
+			},
+		}
+
+		policy := &networkingv1.NetworkPolicy{}
+		SpecNetworkPolicy(node, policy, descriptor)
+
+		Expect(policy.Spec.PodSelector.MatchLabels).To(Equal(node.Labels))
+
+		rules := policy.Spec.Ingress
+		Expect(rules).To(HaveLen(2))
+
+		// peer to peer and metrics ports are open to everyone
+		Expect(rules[0].From).To(BeEmpty())
+		Expect(rules[0].Ports).To(HaveLen(2))
+		Expect(rules[0].Ports[0].Port.IntValue()).To(Equal(30303))
+
+		// API ports are open to allowed pods and kotal nodes consuming ethereum node API
+		Expect(rules[1].Ports).To(HaveLen(1))
+		Expect(rules[1].Ports[0].Port.IntValue()).To(Equal(8545))
+
+		components := []string{}
+		for _, peer := range rules[1].From[1:] {
+			components = append(components, peer.PodSelector.MatchLabels["app.kubernetes.io/component"])
+		}
+		Expect(components).To(Equal([]string{"ethereum2-beaconnode", "chainlink-node"}))
+		Expect(rules[1].From[0].PodSelector.MatchLabels["app"]).To(Equal("dapp"))
+	})
+
+	It("Should close API ports without allowed peers", func() {
+		node := testNode()
+
+		descriptor := testDescriptor()
+		descriptor.Ports = append(descriptor.Ports, corev1.ServicePort{Name: "json-rpc", Port: 8545})
+		descriptor.NetworkPolicy = &sharedAPI.NetworkPolicy{}
+
+		policy := &networkingv1.NetworkPolicy{}
+		SpecNetworkPolicy(node, policy, descriptor)
+
+		// rule without peers allows everyone, so API ports aren't listed in any rule
+		Expect(policy.Spec.Ingress).To(HaveLen(1))
+		for _, port := range policy.Spec.Ingress[0].Ports {
+			Expect(port.Port.IntValue()).NotTo(Equal(8545))
+		}
+	})
+
+	It("Should open cluster peers API ports to each other", func() {
+		node := testNode()
+		node.Labels["app.kubernetes.io/component"] = "ipfs-clusterpeer"
+
+		descriptor := testDescriptor()
+		descriptor.Ports = append(descriptor.Ports, corev1.ServicePort{Name: "rest-api", Port: 9094})
+		descriptor.NetworkPolicy = &sharedAPI.NetworkPolicy{}
+
+		policy := &networkingv1.NetworkPolicy{}
+		SpecNetworkPolicy(node, policy, descriptor)
+
+		// cluster peers consume each other API, so API ports are open to them without allowed pods
+		rules := policy.Spec.Ingress
+		Expect(rules).To(HaveLen(2))
+		Expect(rules[1].From[0].PodSelector.MatchLabels["app.kubernetes.io/component"]).To(Equal("ipfs-clusterpeer"))
+	})
+
+})
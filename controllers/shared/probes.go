@@ -0,0 +1,39 @@
+package shared
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+// probe creates container probe from client health check and user thresholds
+func probe(healthCheck *corev1.ProbeHandler, thresholds sharedAPI.Probe) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler:        *healthCheck.DeepCopy(),
+		InitialDelaySeconds: thresholds.InitialDelaySeconds,
+		PeriodSeconds:       thresholds.PeriodSeconds,
+		TimeoutSeconds:      thresholds.TimeoutSeconds,
+		FailureThreshold:    thresholds.FailureThreshold,
+	}
+}
+
+// Probes returns readiness, liveness and startup probes using client health check
+// readiness probe uses client readiness check if it's not nil
+// all probes are nil if the client has no health check
+func Probes(healthCheck, readinessCheck *corev1.ProbeHandler, probes sharedAPI.Probes) (readiness, liveness, startup *corev1.Probe) {
+	if healthCheck == nil {
+		return
+	}
+
+	probes.Default()
+
+	if readinessCheck == nil {
+		readinessCheck = healthCheck
+	}
+
+	readiness = probe(readinessCheck, probes.Readiness)
+	liveness = probe(healthCheck, probes.Liveness)
+	startup = probe(healthCheck, probes.Startup)
+
+	return
+}
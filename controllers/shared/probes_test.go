@@ -0,0 +1,67 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+var _ = Describe("Probes", func() {
+
+	It("Should not create probes for client without health check", func() {
+		readiness, liveness, startup := Probes(nil, nil, sharedAPI.Probes{})
+		Expect(readiness).To(BeNil())
+		Expect(liveness).To(BeNil())
+		Expect(startup).To(BeNil())
+	})
+
+	It("Should create probes from client health check", func() {
+		healthCheck := &corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(8545),
+			},
+		}
+
+		probes := sharedAPI.Probes{
+			Liveness: sharedAPI.Probe{
+				FailureThreshold: 10,
+			},
+		}
+
+		readiness, liveness, startup := Probes(healthCheck, nil, probes)
+
+		for _, probe := range []*corev1.Probe{readiness, liveness, startup} {
+			Expect(probe.TCPSocket).NotTo(BeNil())
+			Expect(probe.TCPSocket.Port.IntValue()).To(Equal(8545))
+		}
+
+		Expect(liveness.FailureThreshold).To(Equal(int32(10)))
+		Expect(readiness.PeriodSeconds).To(Equal(int32(sharedAPI.DefaultReadinessPeriodSeconds)))
+		Expect(startup.FailureThreshold).To(Equal(int32(sharedAPI.DefaultStartupFailureThreshold)))
+	})
+
+	It("Should create readiness probe from client readiness check", func() {
+		healthCheck := &corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(8545),
+			},
+		}
+		readinessCheck := &corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: "/",
+				Port: intstr.FromInt(8545),
+			},
+		}
+
+		readiness, liveness, startup := Probes(healthCheck, readinessCheck, sharedAPI.Probes{})
+
+		Expect(readiness.HTTPGet).To(Equal(readinessCheck.HTTPGet))
+		Expect(readiness.TCPSocket).To(BeNil())
+		Expect(liveness.TCPSocket).To(Equal(healthCheck.TCPSocket))
+		Expect(startup.TCPSocket).To(Equal(healthCheck.TCPSocket))
+	})
+
+})
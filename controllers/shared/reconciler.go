@@ -0,0 +1,604 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	"github.com/kotalco/kotal/clients"
+)
+
+// NodeDescriptor describes protocol specific node resources
+// everything else is reconciled the same way for all protocols
+type NodeDescriptor struct {
+	// Client is node client
+	Client clients.Interface
+	// ContainerName is node container name, defaults to node
+	ContainerName string
+	// Resources is node compute and storage resources
+	Resources *sharedAPI.Resources
+	// Scheduling is node pod scheduling constraints and metadata overrides
+	Scheduling *sharedAPI.Scheduling
+	// Probes is node container probes thresholds
+	Probes sharedAPI.Probes
+	// ExtraConfig is extra node container arguments and environment variables
+	ExtraConfig *sharedAPI.ExtraConfig
+	// Bootstrap is node data bootstrap, snapshot is downloaded before any other init container
+	Bootstrap *sharedAPI.Bootstrap
+	// Ports is node service ports
+	Ports []corev1.ServicePort
+	// Expose is node service exposure outside the cluster
+	Expose *sharedAPI.Expose
+	// P2PPorts is names of service ports node announces to peers
+	P2PPorts []string
+	// DataMountPath is data volume mount path, defaults to client data path
+	DataMountPath string
+	// ConfigFiles is node config files, configmap is created and mounted at client config path if provided
+	ConfigFiles map[string]string
+	// Volumes is node pod volumes besides data and config volumes
+	Volumes []corev1.Volume
+	// VolumeMounts is node container volume mounts besides data and config volume mounts
+	VolumeMounts []corev1.VolumeMount
+	// InitContainers is containers run before node container
+	InitContainers []corev1.Container
+	// Sidecars is containers run alongside node container
+	Sidecars []corev1.Container
+	// RPCGateway is JSON-RPC gateway sidecar in front of node RPC port
+	RPCGateway *sharedAPI.RPCGateway
+	// RPCPort is node RPC port proxied by RPC gateway
+	RPCPort uint
+	// WSPort is node websocket port proxied by RPC gateway, zero if websocket server is disabled
+	WSPort uint
+	// Replicas is node pods count behind node service, each replica has its own data volume
+	Replicas uint
+	// UpdateStrategy is node pods update strategy
+	UpdateStrategy *sharedAPI.UpdateStrategy
+	// Rollout is canary rollout of node client image planned by PlanRollout
+	Rollout *Rollout
+	// Disruption is node pods voluntary disruption policy
+	Disruption sharedAPI.DisruptionPolicy
+	// Critical is whether node produces or signs blocks, its pods are protected from voluntary disruptions by default
+	Critical bool
+	// Affinity is node pod affinity, overridden by scheduling affinity if provided
+	Affinity *corev1.Affinity
+	// Metrics is node metrics endpoint scraped by prometheus, service monitor is deleted if nil
+	Metrics *MetricsEndpoint
+	// Ingress is node API endpoints exposure through ingress or gateway API HTTP route
+	Ingress *sharedAPI.Ingress
+	// IngressEndpoints is node API endpoints that can be exposed by ingress
+	IngressEndpoints []IngressEndpoint
+	// NetworkPolicy restricts access to node API ports, network policy is deleted if nil
+	NetworkPolicy *sharedAPI.NetworkPolicy
+	// Hooks is protocol specific steps run by ReconcileNode
+	Hooks NodeHooks
+}
+
+// NodeHooks are protocol specific steps run by ReconcileNode alongside shared ones
+type NodeHooks struct {
+	// SpecConfigmap updates node configmap spec instead of SpecConfigmap
+	SpecConfigmap func(configmap *corev1.ConfigMap)
+	// Service is called with reconciled node service
+	Service func(svc *corev1.Service)
+	// Secret reconciles node secret after node statefulset is reconciled
+	Secret func(ctx context.Context) error
+}
+
+// ReconcileNode reconciles node configmap, persistent volume claim, service, prometheus service monitor,
+// ingress, pod disruption budget, network policy, statefulset and node hooks from node descriptor
+// node events are recorded using recorder, errors are annotated with the phase they occurred in
+func ReconcileNode(ctx context.Context, c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, node client.Object, descriptor *NodeDescriptor) error {
+	if err := ReconcileConfigmap(ctx, c, scheme, recorder, node, descriptor); err != nil {
+		return &PhaseError{Phase: PhaseConfigmap, Err: err}
+	}
+
+	if err := ReconcilePVC(ctx, c, scheme, recorder, node, descriptor); err != nil {
+		return &PhaseError{Phase: PhasePVC, Err: err}
+	}
+
+	svc, err := ReconcileService(ctx, c, scheme, recorder, node, descriptor)
+	if err != nil {
+		return &PhaseError{Phase: PhaseService, Err: err}
+	}
+	if svc != nil && descriptor.Hooks.Service != nil {
+		descriptor.Hooks.Service(svc)
+	}
+
+	if err := ReconcileServiceMonitor(ctx, c, scheme, recorder, node, descriptor.Metrics); err != nil {
+		return &PhaseError{Phase: PhaseServiceMonitor, Err: err}
+	}
+
+	if err := ReconcileIngress(ctx, c, scheme, recorder, node, descriptor.Ingress, descriptor.IngressEndpoints); err != nil {
+		return &PhaseError{Phase: PhaseIngress, Err: err}
+	}
+
+	if err := ReconcilePodDisruptionBudget(ctx, c, scheme, recorder, node, descriptor); err != nil {
+		return &PhaseError{Phase: PhasePodDisruptionBudget, Err: err}
+	}
+
+	if err := ReconcileNetworkPolicy(ctx, c, scheme, recorder, node, descriptor); err != nil {
+		return &PhaseError{Phase: PhaseNetworkPolicy, Err: err}
+	}
+
+	if err := ReconcileStatefulSet(ctx, c, scheme, recorder, node, descriptor); err != nil {
+		return &PhaseError{Phase: PhaseStatefulSet, Err: err}
+	}
+
+	if descriptor.Hooks.Secret != nil {
+		if err := descriptor.Hooks.Secret(ctx); err != nil {
+			return &PhaseError{Phase: PhaseSecret, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// ReconcileConfigmap reconciles node configmap if node has config files
+func ReconcileConfigmap(ctx context.Context, c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, node client.Object, descriptor *NodeDescriptor) error {
+	if descriptor.ConfigFiles == nil {
+		return nil
+	}
+
+	configmap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      node.GetName(),
+			Namespace: node.GetNamespace(),
+		},
+	}
+
+	op, err := ctrl.CreateOrUpdate(ctx, c, configmap, func() error {
+		if err := ctrl.SetControllerReference(node, configmap, scheme); err != nil {
+			return err
+		}
+		SpecConfigmap(node, configmap, descriptor)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	RecordOperation(recorder, node, "ConfigMap", configmap.Name, op)
+
+	return nil
+}
+
+// SpecConfigmap updates node configmap spec
+func SpecConfigmap(node client.Object, configmap *corev1.ConfigMap, descriptor *NodeDescriptor) {
+	if descriptor.Hooks.SpecConfigmap != nil {
+		descriptor.Hooks.SpecConfigmap(configmap)
+		return
+	}
+
+	configmap.ObjectMeta.Labels = node.GetLabels()
+
+	if configmap.Data == nil {
+		configmap.Data = map[string]string{}
+	}
+
+	for file, content := range descriptor.ConfigFiles {
+		configmap.Data[file] = content
+	}
+}
+
+// ReconcilePVC reconciles node persistent volume claim
+// replicated nodes have a persistent volume claim per replica, named after statefulset volume claim template
+// replica claims are created and resized by the node, claims of removed replicas are kept like statefulsets do
+func ReconcilePVC(ctx context.Context, c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, node client.Object, descriptor *NodeDescriptor) error {
+	for _, name := range PVCNames(node, descriptor.Replicas) {
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: node.GetNamespace(),
+			},
+		}
+
+		op, err := ctrl.CreateOrUpdate(ctx, c, pvc, func() error {
+			if err := ctrl.SetControllerReference(node, pvc, scheme); err != nil {
+				return err
+			}
+			SpecPVC(node, pvc, descriptor)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		RecordOperation(recorder, node, "PersistentVolumeClaim", pvc.Name, op)
+	}
+
+	return nil
+}
+
+// IsReplicated returns true if node has more than one replica
+func IsReplicated(descriptor *NodeDescriptor) bool {
+	return descriptor.Replicas > 1
+}
+
+// PVCNames returns node persistent volume claim names
+// node persistent volume claim has the same name as the node, replicated nodes have a claim per replica
+func PVCNames(node client.Object, replicas uint) []string {
+	if replicas > 1 {
+		return ReplicaPVCNames(node, replicas)
+	}
+	return []string{node.GetName()}
+}
+
+// ReplicaPVCNames returns persistent volume claim names of node replicas
+// statefulset names claims as <volume claim template>-<statefulset>-<ordinal>
+func ReplicaPVCNames(node client.Object, replicas uint) []string {
+	names := make([]string, 0, replicas)
+	for i := uint(0); i < replicas; i++ {
+		names = append(names, fmt.Sprintf("data-%s-%d", node.GetName(), i))
+	}
+	return names
+}
+
+// SpecPVC updates node persistent volume claim spec
+func SpecPVC(node client.Object, pvc *corev1.PersistentVolumeClaim, descriptor *NodeDescriptor) {
+	request := corev1.ResourceList{
+		corev1.ResourceStorage: resource.MustParse(descriptor.Resources.Storage),
+	}
+
+	// spec is immutable after creation except resources.requests for bound claims
+	if !pvc.CreationTimestamp.IsZero() {
+		pvc.Spec.Resources.Requests = request
+		return
+	}
+
+	pvc.ObjectMeta.Labels = node.GetLabels()
+	pvc.Spec = corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{
+			corev1.ReadWriteOnce,
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: request,
+		},
+		StorageClassName: descriptor.Resources.StorageClass,
+		DataSource:       PVCDataSource(node),
+	}
+}
+
+// ReconcileService reconciles node service
+// service is deleted if node has no ports, and nil service is returned
+func ReconcileService(ctx context.Context, c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, node client.Object, descriptor *NodeDescriptor) (*corev1.Service, error) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      node.GetName(),
+			Namespace: node.GetNamespace(),
+		},
+	}
+
+	if len(descriptor.Ports) == 0 {
+		return nil, client.IgnoreNotFound(c.Delete(ctx, svc))
+	}
+
+	op, err := ctrl.CreateOrUpdate(ctx, c, svc, func() error {
+		if err := ctrl.SetControllerReference(node, svc, scheme); err != nil {
+			return err
+		}
+		SpecService(node, svc, descriptor)
+		return nil
+	})
+	if err != nil {
+		return svc, err
+	}
+
+	RecordOperation(recorder, node, "Service", svc.Name, op)
+
+	return svc, nil
+}
+
+// SpecService updates node service spec
+func SpecService(node client.Object, svc *corev1.Service, descriptor *NodeDescriptor) {
+	labels := node.GetLabels()
+
+	svc.ObjectMeta.Labels = labels
+	svc.Spec.Ports = descriptor.Ports
+	svc.Spec.Selector = labels
+
+	ExposeService(svc, descriptor.Expose, descriptor.P2PPorts...)
+}
+
+// ReconcileStatefulSet reconciles node statefulset
+func ReconcileStatefulSet(ctx context.Context, c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, node client.Object, descriptor *NodeDescriptor) error {
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      node.GetName(),
+			Namespace: node.GetNamespace(),
+		},
+	}
+
+	// pods are rolled when secrets referenced by node spec change
+	secretsHash, err := SecretsHash(ctx, c, node)
+	if err != nil {
+		return err
+	}
+
+	// statefulset volume claim templates are immutable, statefulset is recreated if node switches between
+	// a single replica and replicas, pods are orphaned and adopted by the recreated statefulset
+	if recreating, err := recreateStatefulSet(ctx, c, recorder, node, descriptor); err != nil || recreating {
+		return err
+	}
+
+	// pod template as stored by the api server, before it's mutated
+	var template *corev1.PodTemplateSpec
+
+	op, err := ctrl.CreateOrUpdate(ctx, c, sts, func() error {
+		if err := ctrl.SetControllerReference(node, sts, scheme); err != nil {
+			return err
+		}
+		template = sts.Spec.Template.DeepCopy()
+		SpecStatefulSet(node, sts, descriptor)
+		AnnotateSecretsHash(&sts.Spec.Template, secretsHash)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	RecordOperation(recorder, node, "StatefulSet", sts.Name, op)
+
+	// updated statefulset is defaulted by the api server, pods are rolled only if the template has changed
+	if op == controllerutil.OperationResultUpdated && !equality.Semantic.DeepEqual(template, &sts.Spec.Template) {
+		Eventf(recorder, node, corev1.EventTypeNormal, ReasonRestarting, "Restarting node pods to apply changes")
+	}
+
+	return nil
+}
+
+// recreateStatefulSet deletes node statefulset orphaning its pods if its volume claim templates don't match node replicas
+// it returns true if statefulset is being deleted, node is reconciled again once it's deleted
+func recreateStatefulSet(ctx context.Context, c client.Client, recorder record.EventRecorder, node client.Object, descriptor *NodeDescriptor) (bool, error) {
+	sts := &appsv1.StatefulSet{}
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(node), sts); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	if !sts.DeletionTimestamp.IsZero() {
+		return true, nil
+	}
+
+	if (len(sts.Spec.VolumeClaimTemplates) != 0) == IsReplicated(descriptor) {
+		return false, nil
+	}
+
+	if err := c.Delete(ctx, sts, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	Eventf(recorder, node, corev1.EventTypeNormal, ReasonRestarting, "Recreating statefulset to change data volumes of %d replicas", *Replicas(node, descriptor.Replicas))
+
+	return true, nil
+}
+
+// NodeVolumeClaimTemplates returns node statefulset volume claim templates
+// replicated nodes data volume is claimed per replica, claims are created by ReconcilePVC
+func NodeVolumeClaimTemplates(node client.Object, descriptor *NodeDescriptor) []corev1.PersistentVolumeClaim {
+	if !IsReplicated(descriptor) {
+		return nil
+	}
+
+	pvc := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "data",
+		},
+	}
+	SpecPVC(node, &pvc, descriptor)
+
+	return []corev1.PersistentVolumeClaim{pvc}
+}
+
+// NodeVolumes returns node pod volumes
+// replicated nodes data volume is provided by statefulset volume claim templates
+func NodeVolumes(node client.Object, descriptor *NodeDescriptor) []corev1.Volume {
+	volumes := []corev1.Volume{}
+
+	if !IsReplicated(descriptor) {
+		volumes = append(volumes, corev1.Volume{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: node.GetName(),
+				},
+			},
+		})
+	}
+
+	if descriptor.ConfigFiles != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: node.GetName(),
+					},
+				},
+			},
+		})
+	}
+
+	volumes = append(volumes, descriptor.Volumes...)
+
+	if gatewayVolume := RPCGatewayVolume(descriptor.RPCGateway); gatewayVolume != nil {
+		volumes = append(volumes, *gatewayVolume)
+	}
+
+	return volumes
+}
+
+// NodeVolumeMounts returns node container volume mounts
+func NodeVolumeMounts(descriptor *NodeDescriptor) []corev1.VolumeMount {
+	homeDir := descriptor.Client.HomeDir()
+
+	dataPath := descriptor.DataMountPath
+	if dataPath == "" {
+		dataPath = PathData(homeDir)
+	}
+
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      "data",
+			MountPath: dataPath,
+		},
+	}
+
+	if descriptor.ConfigFiles != nil {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "config",
+			MountPath: PathConfig(homeDir),
+		})
+	}
+
+	return append(mounts, descriptor.VolumeMounts...)
+}
+
+// SpecStatefulSet updates node statefulset spec
+func SpecStatefulSet(node client.Object, sts *appsv1.StatefulSet, descriptor *NodeDescriptor) {
+	labels := node.GetLabels()
+	client := descriptor.Client
+	resources := descriptor.Resources
+
+	var readinessCheck *corev1.ProbeHandler
+	if checker, ok := client.(clients.ReadinessChecker); ok {
+		readinessCheck = checker.ReadinessCheck()
+	}
+	readiness, liveness, startup := Probes(client.HealthCheck(), readinessCheck, descriptor.Probes)
+
+	containerName := nodeContainerName(descriptor)
+
+	// statefulset service name and volume claim templates are immutable after creation
+	serviceName := node.GetName()
+	claimTemplates := NodeVolumeClaimTemplates(node, descriptor)
+	if !sts.CreationTimestamp.IsZero() {
+		serviceName = sts.Spec.ServiceName
+		claimTemplates = sts.Spec.VolumeClaimTemplates
+	}
+
+	var initContainers []corev1.Container
+
+	if bootstrap := BootstrapContainer(descriptor.Bootstrap, client.HomeDir()); bootstrap != nil {
+		initContainers = append(initContainers, *bootstrap)
+	}
+	initContainers = append(initContainers, descriptor.InitContainers...)
+
+	args := client.Args()
+	env := client.Env()
+
+	if extra := descriptor.ExtraConfig; extra != nil {
+		args = append(args, extra.ExtraArgs...)
+		env = append(env, extra.ExtraEnv...)
+	}
+
+	containers := []corev1.Container{
+		{
+			Name:         containerName,
+			Image:        client.Image(),
+			Command:      client.Command(),
+			Args:         args,
+			Env:          env,
+			VolumeMounts: NodeVolumeMounts(descriptor),
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(resources.CPU),
+					corev1.ResourceMemory: resource.MustParse(resources.Memory),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(resources.CPULimit),
+					corev1.ResourceMemory: resource.MustParse(resources.MemoryLimit),
+				},
+			},
+			ReadinessProbe: readiness,
+			LivenessProbe:  liveness,
+			StartupProbe:   startup,
+		},
+	}
+	containers = append(containers, descriptor.Sidecars...)
+
+	if gateway := RPCGatewayContainer(descriptor.RPCGateway, descriptor.RPCPort, descriptor.WSPort); gateway != nil {
+		containers = append(containers, *gateway)
+	}
+
+	sts.ObjectMeta.Labels = labels
+	sts.Spec = appsv1.StatefulSetSpec{
+		Replicas: Replicas(node, descriptor.Replicas),
+		Selector: &metav1.LabelSelector{
+			MatchLabels: labels,
+		},
+		ServiceName: serviceName,
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: labels,
+			},
+			Spec: corev1.PodSpec{
+				SecurityContext: SecurityContext(),
+				InitContainers:  initContainers,
+				Containers:      containers,
+				Volumes:         NodeVolumes(node, descriptor),
+				Affinity:        descriptor.Affinity,
+			},
+		},
+		VolumeClaimTemplates: claimTemplates,
+	}
+
+	if descriptor.Scheduling != nil {
+		ApplyScheduling(&sts.Spec.Template, descriptor.Scheduling)
+	}
+
+	if descriptor.Rollout != nil {
+		ApplyRollout(sts, client.Image(), descriptor.Rollout)
+	} else {
+		delete(sts.Annotations, StableImageAnnotation)
+		delete(sts.Annotations, FailedImageAnnotation)
+	}
+}
+
+// NodeControllerManagedBy returns controller builder of the node watching resources owned by the node,
+// node pods, and secrets referenced by node spec if node is secret referrer
+func NodeControllerManagedBy(mgr ctrl.Manager, node client.Object) (*builder.Builder, error) {
+	podMapFn, err := NodeOfPod(mgr.GetScheme(), node)
+	if err != nil {
+		return nil, err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(node).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.Service{}).
+		Owns(&corev1.PersistentVolumeClaim{}).
+		Owns(&networkingv1.Ingress{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Watches(&source.Kind{Type: &corev1.Pod{}}, handler.EnqueueRequestsFromMapFunc(podMapFn))
+
+	if _, ok := node.(SecretReferrer); !ok {
+		return b, nil
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), node, SecretsIndexKey, IndexSecretNames); err != nil {
+		return nil, err
+	}
+
+	mapFn, err := NodesReferencingSecret(mgr.GetClient(), mgr.GetScheme(), node)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(mapFn)), nil
+}
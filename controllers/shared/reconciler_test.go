@@ -0,0 +1,239 @@
+package shared
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeClient is node client used by reconciler tests
+type fakeClient struct{}
+
+func (c *fakeClient) Args() []string                    { return []string{"--datadir=/home/node/kotal-data"} }
+func (c *fakeClient) Command() []string                 { return nil }
+func (c *fakeClient) Env() []corev1.EnvVar              { return nil }
+func (c *fakeClient) HomeDir() string                   { return "/home/node" }
+func (c *fakeClient) Image() string                     { return "kotalco/node:v1.0.0" }
+func (c *fakeClient) HealthCheck() *corev1.ProbeHandler { return nil }
+func (c *fakeClient) MetricsPath() string               { return "/metrics" }
+
+func testNode() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-node",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app.kubernetes.io/instance": "my-node",
+			},
+		},
+	}
+}
+
+func testDescriptor() *NodeDescriptor {
+	return &NodeDescriptor{
+		Client: &fakeClient{},
+		Resources: &sharedAPI.Resources{
+			CPU:         "1",
+			CPULimit:    "2",
+			Memory:      "1Gi",
+			MemoryLimit: "2Gi",
+			Storage:     "100Gi",
+		},
+		Ports: []corev1.ServicePort{
+			{
+				Name:       "p2p",
+				Port:       30303,
+				TargetPort: intstr.FromInt(30303),
+				Protocol:   corev1.ProtocolTCP,
+			},
+		},
+		P2PPorts: []string{"p2p"},
+	}
+}
+
+var _ = Describe("Node reconciler", func() {
+
+	It("Should update configmap data", func() {
+		descriptor := testDescriptor()
+		descriptor.ConfigFiles = map[string]string{"config.toml": "[node]"}
+
+		configmap := &corev1.ConfigMap{Data: map[string]string{"other": "kept"}}
+		SpecConfigmap(testNode(), configmap, descriptor)
+
+		Expect(configmap.Data).To(Equal(map[string]string{"config.toml": "[node]", "other": "kept"}))
+	})
+
+	It("Should only update storage request of created pvc", func() {
+		node := testNode()
+		descriptor := testDescriptor()
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		SpecPVC(node, pvc, descriptor)
+
+		storage := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		Expect(storage.String()).To(Equal("100Gi"))
+
+		// only storage request can be updated after creation
+		pvc.CreationTimestamp = metav1.Now()
+		descriptor.Resources.Storage = "200Gi"
+		descriptor.Resources.StorageClass = new(string)
+		SpecPVC(node, pvc, descriptor)
+
+		storage = pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		Expect(storage.String()).To(Equal("200Gi"))
+		Expect(pvc.Spec.StorageClassName).To(BeNil())
+	})
+
+	It("Should spec service", func() {
+		node := testNode()
+		descriptor := testDescriptor()
+
+		svc := &corev1.Service{}
+		SpecService(node, svc, descriptor)
+
+		Expect(svc.Spec.Ports).To(Equal(descriptor.Ports))
+		Expect(svc.Spec.Selector).To(Equal(node.Labels))
+	})
+
+	It("Should spec statefulset", func() {
+		node := testNode()
+		descriptor := testDescriptor()
+		descriptor.ConfigFiles = map[string]string{"config.toml": "[node]"}
+		descriptor.InitContainers = []corev1.Container{{Name: "init-node"}}
+		descriptor.Sidecars = []corev1.Container{{Name: "exporter"}}
+		descriptor.RPCGateway = &sharedAPI.RPCGateway{Port: 8080, SecretName: "rpc-keys"}
+		descriptor.RPCPort = 8545
+
+		sts := &appsv1.StatefulSet{}
+		SpecStatefulSet(node, sts, descriptor)
+
+		spec := sts.Spec.Template.Spec
+
+		containers := []string{}
+		for _, container := range spec.Containers {
+			containers = append(containers, container.Name)
+		}
+		Expect(containers).To(Equal([]string{"node", "exporter", RPCGatewayContainerName}))
+
+		Expect(spec.InitContainers).To(HaveLen(1))
+		Expect(spec.InitContainers[0].Name).To(Equal("init-node"))
+
+		volumes := []string{}
+		for _, volume := range spec.Volumes {
+			volumes = append(volumes, volume.Name)
+		}
+		Expect(volumes).To(Equal([]string{"data", "config", RPCGatewayVolumeName}))
+
+		Expect(spec.Containers[0].VolumeMounts).To(Equal([]corev1.VolumeMount{
+			{Name: "data", MountPath: "/home/node/kotal-data"},
+			{Name: "config", MountPath: "/home/node/kotal-config"},
+		}))
+
+		Expect(sts.Spec.ServiceName).To(Equal(node.Name))
+
+		// service name is immutable after creation
+		sts.CreationTimestamp = metav1.Now()
+		sts.Spec.ServiceName = ""
+		SpecStatefulSet(node, sts, descriptor)
+		Expect(sts.Spec.ServiceName).To(BeEmpty())
+	})
+
+	It("Should spec replicated statefulset", func() {
+		node := testNode()
+		descriptor := testDescriptor()
+		descriptor.Replicas = 3
+
+		sts := &appsv1.StatefulSet{}
+		SpecStatefulSet(node, sts, descriptor)
+
+		Expect(*sts.Spec.Replicas).To(Equal(int32(3)))
+
+		// data volume is provided by volume claim templates
+		for _, volume := range sts.Spec.Template.Spec.Volumes {
+			Expect(volume.Name).NotTo(Equal("data"))
+		}
+
+		templates := sts.Spec.VolumeClaimTemplates
+		Expect(templates).To(HaveLen(1))
+		Expect(templates[0].Name).To(Equal("data"))
+		storage := templates[0].Spec.Resources.Requests[corev1.ResourceStorage]
+		Expect(storage.String()).To(Equal("100Gi"))
+
+		// volume claim templates are immutable after creation
+		sts.CreationTimestamp = metav1.Now()
+		descriptor.Resources.Storage = "200Gi"
+		SpecStatefulSet(node, sts, descriptor)
+		storage = sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage]
+		Expect(storage.String()).To(Equal("100Gi"))
+
+		// suspended replicated nodes are scaled down to zero
+		node.Annotations = map[string]string{SuspendAnnotation: "my-backup"}
+		SpecStatefulSet(node, sts, descriptor)
+		Expect(*sts.Spec.Replicas).To(Equal(int32(0)))
+	})
+
+	It("Should return replica pvc names", func() {
+		Expect(ReplicaPVCNames(testNode(), 2)).To(Equal([]string{"data-my-node-0", "data-my-node-1"}))
+	})
+
+	It("Should return node volume mounts", func() {
+		descriptor := testDescriptor()
+		descriptor.DataMountPath = "/home/node"
+		descriptor.VolumeMounts = []corev1.VolumeMount{{Name: "secrets", MountPath: "/home/node/kotal-secrets"}}
+
+		Expect(NodeVolumeMounts(descriptor)).To(Equal([]corev1.VolumeMount{
+			{Name: "data", MountPath: "/home/node"},
+			{Name: "secrets", MountPath: "/home/node/kotal-secrets"},
+		}))
+	})
+
+	It("Should run node hooks", func() {
+		// node is a pod, so it's not replaced by its own service
+		node := &corev1.Pod{ObjectMeta: testNode().ObjectMeta}
+		descriptor := testDescriptor()
+		descriptor.ConfigFiles = map[string]string{"config.toml": "[node]"}
+		c, scheme := NewRenderClient(func(*runtime.Scheme) error { return nil }, node)
+		ctx := context.Background()
+
+		hooks := []string{}
+		descriptor.Hooks = NodeHooks{
+			SpecConfigmap: func(configmap *corev1.ConfigMap) {
+				hooks = append(hooks, "configmap")
+				configmap.Data = map[string]string{"static-nodes.json": "[]"}
+			},
+			Service: func(svc *corev1.Service) {
+				hooks = append(hooks, "service "+svc.Name)
+			},
+			Secret: func(ctx context.Context) error {
+				hooks = append(hooks, "secret")
+				return nil
+			},
+		}
+
+		Expect(ReconcileNode(ctx, c, scheme, nil, node, descriptor)).To(Succeed())
+		Expect(hooks).To(Equal([]string{"configmap", "service my-node", "secret"}))
+
+		configmap := &corev1.ConfigMap{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(node), configmap)).To(Succeed())
+		Expect(configmap.Data).To(Equal(map[string]string{"static-nodes.json": "[]"}))
+
+		// node without ports has no service
+		hooks = []string{}
+		descriptor.Ports = nil
+		Expect(ReconcileNode(ctx, c, scheme, nil, node, descriptor)).To(Succeed())
+		Expect(hooks).To(Equal([]string{"configmap", "secret"}))
+		err := c.Get(ctx, client.ObjectKeyFromObject(node), &corev1.Service{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+})
@@ -0,0 +1,149 @@
+package shared
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+// NewRenderClient returns in-memory client holding objects referenced by node spec like secrets
+// it's used by controllers to render node child resources without contacting the api server
+func NewRenderClient(addToScheme func(*runtime.Scheme) error, objects ...client.Object) (client.Client, *runtime.Scheme) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = addToScheme(scheme)
+
+	// objects of other protocols can't be referenced by the node
+	known := []client.Object{}
+	for _, obj := range objects {
+		if _, _, err := scheme.ObjectKinds(obj); err != nil {
+			continue
+		}
+		// merge secret string data into data the same way api server does
+		if secret, ok := obj.(*corev1.Secret); ok && len(secret.StringData) != 0 {
+			secret = secret.DeepCopy()
+			if secret.Data == nil {
+				secret.Data = map[string][]byte{}
+			}
+			for key, value := range secret.StringData {
+				secret.Data[key] = []byte(value)
+			}
+			secret.StringData = nil
+			obj = secret
+		}
+		known = append(known, obj)
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(known...).Build(), scheme
+}
+
+// RenderNode returns node child resources as they would be created by the reconciler
+// without contacting the api server, secrets referenced by the node are read from the render client
+// service monitor is rendered even though it's created only if prometheus operator is installed
+func RenderNode(ctx context.Context, c client.Client, node client.Object, descriptor *NodeDescriptor) ([]client.Object, error) {
+	meta := func() metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:      node.GetName(),
+			Namespace: node.GetNamespace(),
+		}
+	}
+
+	objects := []client.Object{}
+
+	if descriptor.ConfigFiles != nil {
+		configmap := &corev1.ConfigMap{ObjectMeta: meta()}
+		SpecConfigmap(node, configmap, descriptor)
+		objects = append(objects, configmap)
+	}
+
+	// replicated nodes data volumes are claimed by statefulset volume claim templates
+	if !IsReplicated(descriptor) {
+		pvc := &corev1.PersistentVolumeClaim{ObjectMeta: meta()}
+		SpecPVC(node, pvc, descriptor)
+		objects = append(objects, pvc)
+	}
+
+	if len(descriptor.Ports) != 0 {
+		svc := &corev1.Service{ObjectMeta: meta()}
+		SpecService(node, svc, descriptor)
+		objects = append(objects, svc)
+	}
+
+	if descriptor.Metrics != nil {
+		sm := NewServiceMonitor(node)
+		if err := SpecServiceMonitor(node, sm, descriptor.Metrics); err != nil {
+			return nil, err
+		}
+		objects = append(objects, sm)
+	}
+
+	ingressObjects, err := renderIngress(node, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	objects = append(objects, ingressObjects...)
+
+	if IsProtected(descriptor) {
+		pdb := &policyv1.PodDisruptionBudget{ObjectMeta: meta()}
+		SpecPodDisruptionBudget(node, pdb)
+		objects = append(objects, pdb)
+	}
+
+	if descriptor.NetworkPolicy != nil {
+		policy := &networkingv1.NetworkPolicy{ObjectMeta: meta()}
+		SpecNetworkPolicy(node, policy, descriptor)
+		objects = append(objects, policy)
+	}
+
+	secretsHash, err := SecretsHash(ctx, c, node)
+	if err != nil {
+		return nil, err
+	}
+
+	sts := &appsv1.StatefulSet{ObjectMeta: meta()}
+	SpecStatefulSet(node, sts, descriptor)
+	AnnotateSecretsHash(&sts.Spec.Template, secretsHash)
+
+	return append(objects, sts), nil
+}
+
+// renderIngress returns ingress or HTTP routes routing hostnames to node API endpoints
+func renderIngress(node client.Object, descriptor *NodeDescriptor) ([]client.Object, error) {
+	routes, err := IngressRoutes(descriptor.Ingress, descriptor.IngressEndpoints)
+	if err != nil || len(routes) == 0 {
+		return nil, err
+	}
+
+	if IngressKind(descriptor.Ingress) == sharedAPI.IngressKindIngress {
+		ing := &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      node.GetName(),
+				Namespace: node.GetNamespace(),
+			},
+		}
+		SpecIngress(node, ing, descriptor.Ingress, routes)
+		return []client.Object{ing}, nil
+	}
+
+	objects := []client.Object{}
+	endpoints, hostnames := EndpointHostnames(routes)
+	for _, endpoint := range endpoints {
+		route := NewHTTPRoute(node, endpoint)
+		if err := SpecHTTPRoute(node, route, descriptor.Ingress, endpoint, hostnames[endpoint]); err != nil {
+			return nil, err
+		}
+		objects = append(objects, route)
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,113 @@
+package shared
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+var _ = Describe("Rendering", func() {
+
+	It("Should create render client with secrets", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "rpc-password",
+				Namespace: "default",
+			},
+			StringData: map[string]string{"password": "secret"},
+		}
+
+		addToScheme := func(*runtime.Scheme) error { return nil }
+		c, _ := NewRenderClient(addToScheme, secret)
+
+		// secret string data is merged into data
+		password, err := GetSecret(context.Background(), c, types.NamespacedName{Name: "rpc-password", Namespace: "default"}, "password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(password).To(Equal("secret"))
+
+		// input secret isn't modified
+		Expect(secret.Data).To(BeNil())
+	})
+
+	It("Should render node resources", func() {
+		node := testNode()
+		c, _ := NewRenderClient(func(*runtime.Scheme) error { return nil })
+
+		// pvc, service and statefulset
+		descriptor := testDescriptor()
+		objects, err := RenderNode(context.Background(), c, node, descriptor)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(3))
+
+		// configmap, pvc, service, service monitor, ingress and statefulset
+		descriptor.ConfigFiles = map[string]string{"config.toml": "[node]"}
+		descriptor.Metrics = &MetricsEndpoint{Port: "metrics", Path: "/metrics"}
+		descriptor.Ingress = &sharedAPI.Ingress{
+			Hosts: []sharedAPI.IngressHost{{Hostname: "rpc.example.com"}},
+		}
+		descriptor.IngressEndpoints = ingressEndpoints
+		objects, err = RenderNode(context.Background(), c, node, descriptor)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(6))
+		Expect(objects[3].GetObjectKind().GroupVersionKind()).To(Equal(ServiceMonitorGVK))
+		Expect(objects[4]).To(BeAssignableToTypeOf(&networkingv1.Ingress{}))
+
+		for _, object := range objects {
+			Expect(object.GetName()).To(Equal(node.Name))
+			Expect(object.GetNamespace()).To(Equal(node.Namespace))
+		}
+
+		// an HTTP route for each routed endpoint instead of ingress
+		descriptor.Ingress = &sharedAPI.Ingress{
+			Kind:    sharedAPI.IngressKindHTTPRoute,
+			Gateway: "gateways/public",
+			Hosts: []sharedAPI.IngressHost{
+				{Hostname: "rpc.example.com"},
+				{Hostname: "ws.example.com", Endpoint: "ws"},
+			},
+		}
+		objects, err = RenderNode(context.Background(), c, node, descriptor)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(7))
+		Expect(objects[4].GetName()).To(Equal("my-node-rpc"))
+		Expect(objects[5].GetName()).To(Equal("my-node-ws"))
+	})
+
+	It("Should render statefulset with secrets hash", func() {
+		node := &testSecretReferrer{Service: testNode(), secrets: []string{"rpc-password"}}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "rpc-password",
+				Namespace: "default",
+			},
+			StringData: map[string]string{"password": "secret"},
+		}
+		c, _ := NewRenderClient(func(*runtime.Scheme) error { return nil }, secret)
+
+		objects, err := RenderNode(context.Background(), c, node, testDescriptor())
+		Expect(err).NotTo(HaveOccurred())
+
+		sts := objects[len(objects)-1].(*appsv1.StatefulSet)
+		Expect(sts.Spec.Template.Annotations).To(HaveKeyWithValue(SecretsHashAnnotation, Not(BeEmpty())))
+	})
+
+})
+
+// testSecretReferrer is test node referencing secrets
+type testSecretReferrer struct {
+	*corev1.Service
+	secrets []string
+}
+
+func (r *testSecretReferrer) SecretNames() []string {
+	return r.secrets
+}
@@ -0,0 +1,167 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+// RetentionFinalizer is the finalizer used to apply node storage retention policy on node deletion
+const RetentionFinalizer = "kotal.io/retention-policy"
+
+// VolumeSnapshotGVK is CSI volume snapshot group, version and kind
+var VolumeSnapshotGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshot",
+}
+
+// snapshotRequeueAfter is how long to wait before checking volume snapshot readiness again
+const snapshotRequeueAfter = 10 * time.Second
+
+// EnsureRetentionFinalizer adds retention finalizer to the node if it doesn't exist
+func EnsureRetentionFinalizer(ctx context.Context, c client.Client, node client.Object) error {
+	if controllerutil.ContainsFinalizer(node, RetentionFinalizer) {
+		return nil
+	}
+
+	controllerutil.AddFinalizer(node, RetentionFinalizer)
+
+	return keepGVK(c, node, func() error {
+		return c.Update(ctx, node)
+	})
+}
+
+// FinalizeStorage applies node storage retention policy and removes retention finalizer from the node
+// node persistent volume claim has the same name as the node, replicated nodes have a claim per replica
+// retained claims are orphaned, other claims are garbage collected with the node
+func FinalizeStorage(ctx context.Context, c client.Client, node client.Object, policy sharedAPI.RetentionPolicy, replicas uint) (result ctrl.Result, err error) {
+	if !controllerutil.ContainsFinalizer(node, RetentionFinalizer) {
+		return
+	}
+
+	for _, claim := range PVCNames(node, replicas) {
+		switch policy {
+		case sharedAPI.RetainPolicy:
+			if err = orphanPVC(ctx, c, node, claim); err != nil {
+				return
+			}
+		case sharedAPI.SnapshotPolicy:
+			var ready bool
+			if ready, err = snapshotPVC(ctx, c, node, claim); err != nil {
+				return
+			}
+			// keep the claims until snapshots are ready to use
+			if !ready {
+				result.RequeueAfter = snapshotRequeueAfter
+			}
+		}
+	}
+
+	if result.RequeueAfter != 0 {
+		return
+	}
+
+	controllerutil.RemoveFinalizer(node, RetentionFinalizer)
+	err = c.Update(ctx, node)
+
+	return
+}
+
+// orphanPVC removes node owner reference from node persistent volume claim
+func orphanPVC(ctx context.Context, c client.Client, node client.Object, claim string) error {
+	pvc := &corev1.PersistentVolumeClaim{}
+
+	key := types.NamespacedName{Name: claim, Namespace: node.GetNamespace()}
+	if err := c.Get(ctx, key, pvc); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	refs := []metav1.OwnerReference{}
+	for _, ref := range pvc.OwnerReferences {
+		if ref.UID != node.GetUID() {
+			refs = append(refs, ref)
+		}
+	}
+
+	if len(refs) == len(pvc.OwnerReferences) {
+		return nil
+	}
+
+	pvc.OwnerReferences = refs
+
+	log.FromContext(ctx).Info("retaining persistent volume claim", "name", pvc.Name)
+
+	return c.Update(ctx, pvc)
+}
+
+// SnapshotName returns the name of the volume snapshot of node persistent volume claim taken on node deletion
+func SnapshotName(node client.Object, claim string) string {
+	return fmt.Sprintf("%s-%d", claim, node.GetDeletionTimestamp().Unix())
+}
+
+// snapshotPVC takes a volume snapshot of node persistent volume claim
+// it returns true if the snapshot is ready to use
+// failed snapshots are deleted to be taken again
+func snapshotPVC(ctx context.Context, c client.Client, node client.Object, claim string) (bool, error) {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+
+	key := types.NamespacedName{Name: SnapshotName(node, claim), Namespace: node.GetNamespace()}
+	err := c.Get(ctx, key, snapshot)
+
+	if meta.IsNoMatchError(err) {
+		return false, fmt.Errorf("volume snapshots are not supported in this cluster: %w", err)
+	}
+
+	if client.IgnoreNotFound(err) != nil {
+		return false, err
+	}
+
+	// snapshot doesn't exist
+	if err != nil {
+		SpecVolumeSnapshot(node, claim, snapshot)
+		log.FromContext(ctx).Info("taking volume snapshot", "name", snapshot.GetName())
+		return false, c.Create(ctx, snapshot)
+	}
+
+	if msg, found, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message"); found {
+		log.FromContext(ctx).Info("deleting failed volume snapshot", "name", snapshot.GetName())
+		if err := c.Delete(ctx, snapshot); client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+		return false, fmt.Errorf("volume snapshot %s failed: %s", snapshot.GetName(), msg)
+	}
+
+	ready, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+
+	return ready, nil
+}
+
+// SpecVolumeSnapshot updates volume snapshot of node persistent volume claim
+// snapshot is not owned by the node, so it outlives it
+func SpecVolumeSnapshot(node client.Object, claim string, snapshot *unstructured.Unstructured) {
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	snapshot.SetName(SnapshotName(node, claim))
+	snapshot.SetNamespace(node.GetNamespace())
+	snapshot.SetLabels(node.GetLabels())
+
+	snapshot.Object["spec"] = map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": claim,
+		},
+	}
+}
@@ -0,0 +1,162 @@
+package shared
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+var _ = Describe("Storage retention", func() {
+
+	// owned returns persistent volume claim owned by the node
+	owned := func(name string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "v1",
+						Kind:       "ConfigMap",
+						Name:       "my-node",
+						UID:        "node-uid",
+					},
+				},
+			},
+		}
+	}
+
+	It("Should spec volume snapshot of node data volume", func() {
+		deletion := metav1.Unix(1650000000, 0)
+
+		node := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "my-node",
+				Namespace:         "default",
+				DeletionTimestamp: &deletion,
+				Labels: map[string]string{
+					"app.kubernetes.io/instance": "my-node",
+				},
+			},
+		}
+
+		snapshot := &unstructured.Unstructured{}
+		SpecVolumeSnapshot(node, "my-node", snapshot)
+
+		Expect(snapshot.GetName()).To(Equal("my-node-1650000000"))
+		Expect(snapshot.GetNamespace()).To(Equal("default"))
+		Expect(snapshot.GetOwnerReferences()).To(BeEmpty())
+
+		source, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName")
+		Expect(source).To(Equal("my-node"))
+	})
+
+	It("Should add retention finalizer without resetting node kind", func() {
+		node := testNode()
+		c := fake.NewClientBuilder().WithObjects(node.DeepCopy()).Build()
+
+		Expect(EnsureRetentionFinalizer(context.Background(), c, node)).To(Succeed())
+		Expect(node.Finalizers).To(ContainElement(RetentionFinalizer))
+
+		// labels and owner references of node resources are computed from node kind
+		Expect(node.GroupVersionKind()).To(Equal(corev1.SchemeGroupVersion.WithKind("Service")))
+	})
+
+	It("Should orphan retained persistent volume claim", func() {
+		deletion := metav1.Now()
+
+		node := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "my-node",
+				Namespace:         "default",
+				UID:               "node-uid",
+				DeletionTimestamp: &deletion,
+				Finalizers:        []string{RetentionFinalizer},
+			},
+		}
+
+		c := fake.NewClientBuilder().WithObjects(node, owned("my-node")).Build()
+
+		_, err := FinalizeStorage(context.Background(), c, node, sharedAPI.RetainPolicy, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(node.Finalizers).To(BeEmpty())
+
+		retained := &corev1.PersistentVolumeClaim{}
+		Expect(c.Get(context.Background(), types.NamespacedName{Name: "my-node", Namespace: "default"}, retained)).To(Succeed())
+		Expect(retained.OwnerReferences).To(BeEmpty())
+	})
+
+	It("Should orphan retained persistent volume claims of all replicas", func() {
+		deletion := metav1.Now()
+
+		node := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "my-node",
+				Namespace:         "default",
+				UID:               "node-uid",
+				DeletionTimestamp: &deletion,
+				Finalizers:        []string{RetentionFinalizer},
+			},
+		}
+
+		objects := []client.Object{node}
+		for _, name := range ReplicaPVCNames(node, 2) {
+			objects = append(objects, owned(name))
+		}
+
+		c := fake.NewClientBuilder().WithObjects(objects...).Build()
+
+		_, err := FinalizeStorage(context.Background(), c, node, sharedAPI.RetainPolicy, 2)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, name := range ReplicaPVCNames(node, 2) {
+			retained := &corev1.PersistentVolumeClaim{}
+			Expect(c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, retained)).To(Succeed())
+			Expect(retained.OwnerReferences).To(BeEmpty(), "persistent volume claim %s", name)
+		}
+	})
+
+	It("Should delete failed volume snapshot to be taken again", func() {
+		deletion := metav1.Unix(1650000000, 0)
+
+		node := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "my-node",
+				Namespace:         "default",
+				DeletionTimestamp: &deletion,
+				Finalizers:        []string{RetentionFinalizer},
+			},
+		}
+
+		snapshot := &unstructured.Unstructured{}
+		SpecVolumeSnapshot(node, "my-node", snapshot)
+		unstructured.SetNestedField(snapshot.Object, "snapshot class not found", "status", "error", "message")
+
+		scheme := runtime.NewScheme()
+		corev1.AddToScheme(scheme)
+		scheme.AddKnownTypeWithName(VolumeSnapshotGVK, &unstructured.Unstructured{})
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(node, snapshot).Build()
+
+		_, err := FinalizeStorage(context.Background(), c, node, sharedAPI.SnapshotPolicy, 1)
+		Expect(err).To(HaveOccurred())
+		Expect(node.Finalizers).To(HaveLen(1))
+
+		failed := &unstructured.Unstructured{}
+		failed.SetGroupVersionKind(VolumeSnapshotGVK)
+		err = c.Get(context.Background(), types.NamespacedName{Name: "my-node-1650000000", Namespace: "default"}, failed)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+})
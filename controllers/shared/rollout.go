@@ -0,0 +1,221 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+const (
+	// StableImageAnnotation is node statefulset annotation holding the client image all replicas were updated to
+	StableImageAnnotation = "kotal.io/stable-image"
+	// FailedImageAnnotation is node statefulset annotation holding the canary client image that was rolled back
+	FailedImageAnnotation = "kotal.io/failed-image"
+)
+
+// rolloutInterval is how often canary pod is checked during soak period
+const rolloutInterval = 30 * time.Second
+
+// Rollout is canary rollout of node client image
+type Rollout struct {
+	// Image is client image used by node pod template, it's the stable image if the canary failed
+	Image string
+	// Partition is statefulset rolling update partition, only pods with greater or equal ordinal are updated
+	Partition int32
+	// StableImage is the client image all replicas were updated to
+	StableImage string
+	// FailedImage is the canary client image that was rolled back
+	FailedImage string
+}
+
+// IsCanary returns true if node client image updates are rolled out to a canary pod first
+func IsCanary(descriptor *NodeDescriptor) bool {
+	return descriptor.UpdateStrategy.IsCanary() && IsReplicated(descriptor)
+}
+
+// PlanRollout plans canary rollout of node client image and sets it in node descriptor
+// new client image is rolled out to the pod with the highest ordinal, other replicas keep the stable image
+// remaining replicas are updated once the canary pod is ready and node isn't syncing for soak period without restarts
+// stable image is restored if canary containers restart or fail, failed image isn't rolled out again
+// it returns how long to wait before checking the canary again
+func PlanRollout(ctx context.Context, c client.Client, recorder record.EventRecorder, node client.Object, status *sharedAPI.Status, descriptor *NodeDescriptor) (requeueAfter time.Duration, err error) {
+	if !IsCanary(descriptor) {
+		return
+	}
+
+	image := descriptor.Client.Image()
+
+	sts := &appsv1.StatefulSet{}
+	if err = c.Get(ctx, client.ObjectKeyFromObject(node), sts); err != nil {
+		if err = client.IgnoreNotFound(err); err == nil {
+			descriptor.Rollout = &Rollout{Image: image, StableImage: image}
+		}
+		return
+	}
+
+	// statefulsets created before canary updates are enabled don't have stable image annotation
+	stable := sts.Annotations[StableImageAnnotation]
+	if stable == "" {
+		stable = containerImage(&sts.Spec.Template, nodeContainerName(descriptor))
+	}
+	failed := sts.Annotations[FailedImageAnnotation]
+
+	switch {
+	case image == stable:
+		descriptor.Rollout = &Rollout{Image: image, StableImage: stable}
+		return
+	case image == failed, IsSuspended(node):
+		descriptor.Rollout = &Rollout{Image: stable, StableImage: stable, FailedImage: failed}
+		return
+	}
+
+	replicas := *Replicas(node, descriptor.Replicas)
+	canary := replicas - 1
+	descriptor.Rollout = &Rollout{Image: image, Partition: canary, StableImage: stable}
+	requeueAfter = rolloutInterval
+
+	pod := &corev1.Pod{}
+	podName := types.NamespacedName{Name: fmt.Sprintf("%s-%d", node.GetName(), canary), Namespace: node.GetNamespace()}
+	if err = c.Get(ctx, podName, pod); err != nil {
+		err = client.IgnoreNotFound(err)
+		return
+	}
+
+	// canary pod hasn't been updated yet
+	if containerImage(&corev1.PodTemplateSpec{Spec: pod.Spec}, nodeContainerName(descriptor)) != image {
+		return
+	}
+
+	if reason := canaryFailure(pod); reason != "" {
+		descriptor.Rollout = &Rollout{Image: stable, StableImage: stable, FailedImage: image}
+		requeueAfter = 0
+		Eventf(recorder, node, corev1.EventTypeWarning, ReasonRolledBack, "Canary pod %s %s, rolling back client image from %s to %s", pod.Name, reason, image, stable)
+		return
+	}
+
+	soak := descriptor.UpdateStrategy.SoakDuration()
+	healthy := canaryHealthySince(pod, status)
+	if healthy.IsZero() {
+		return
+	}
+
+	if remaining := soak - time.Since(healthy.Time); remaining > 0 {
+		if remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+		return
+	}
+
+	descriptor.Rollout = &Rollout{Image: image, StableImage: image}
+	requeueAfter = 0
+	Eventf(recorder, node, corev1.EventTypeNormal, ReasonCanaryPromoted, "Canary pod %s kept ready for %s, updating remaining replicas to client image %s", pod.Name, soak, image)
+
+	return
+}
+
+// canaryFailure returns why canary pod failed, empty string is returned if it didn't fail
+// liveness and startup probe failures restart containers, restarts are considered failures
+func canaryFailure(pod *corev1.Pod) string {
+	if pod.Status.Phase == corev1.PodFailed {
+		return "failed"
+	}
+
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+	for _, status := range statuses {
+		if waiting := status.State.Waiting; waiting != nil && degradedReasons[waiting.Reason] {
+			return fmt.Sprintf("container %s is in %s", status.Name, waiting.Reason)
+		}
+		if status.RestartCount > 0 {
+			return fmt.Sprintf("container %s restarted %d times", status.Name, status.RestartCount)
+		}
+	}
+
+	return ""
+}
+
+// canaryHealthySince returns the time since canary pod is ready and node isn't syncing
+// zero time is returned if canary pod isn't ready or node is syncing
+func canaryHealthySince(pod *corev1.Pod, status *sharedAPI.Status) (since metav1.Time) {
+	if pod.Status.Phase != corev1.PodRunning {
+		return
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+			since = condition.LastTransitionTime
+		}
+	}
+	if since.IsZero() {
+		return
+	}
+
+	// node status is updated after the canary pod became ready
+	syncing := meta.FindStatusCondition(status.Conditions, string(sharedAPI.SyncingCondition))
+	if syncing == nil || syncing.Status != metav1.ConditionFalse {
+		return metav1.Time{}
+	}
+	if since.Before(&syncing.LastTransitionTime) {
+		since = syncing.LastTransitionTime
+	}
+
+	return
+}
+
+// nodeContainerName returns node container name
+func nodeContainerName(descriptor *NodeDescriptor) string {
+	if descriptor.ContainerName == "" {
+		return "node"
+	}
+	return descriptor.ContainerName
+}
+
+// containerImage returns image of the container with the given name in pod template
+func containerImage(template *corev1.PodTemplateSpec, name string) string {
+	for _, container := range template.Spec.Containers {
+		if container.Name == name {
+			return container.Image
+		}
+	}
+	return ""
+}
+
+// ApplyRollout applies canary rollout to node statefulset
+// containers using client image are set to rollout image, including init containers using client image
+func ApplyRollout(sts *appsv1.StatefulSet, image string, rollout *Rollout) {
+	setImage := func(containers []corev1.Container) {
+		for i := range containers {
+			if containers[i].Image == image {
+				containers[i].Image = rollout.Image
+			}
+		}
+	}
+	setImage(sts.Spec.Template.Spec.InitContainers)
+	setImage(sts.Spec.Template.Spec.Containers)
+
+	partition := rollout.Partition
+	sts.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition: &partition,
+		},
+	}
+
+	metav1.SetMetaDataAnnotation(&sts.ObjectMeta, StableImageAnnotation, rollout.StableImage)
+	if rollout.FailedImage != "" {
+		metav1.SetMetaDataAnnotation(&sts.ObjectMeta, FailedImageAnnotation, rollout.FailedImage)
+	} else {
+		delete(sts.Annotations, FailedImageAnnotation)
+	}
+}
@@ -0,0 +1,183 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+var _ = Describe("Canary rollout", func() {
+	stable := "kotalco/node:v0.9.0"
+	image := "kotalco/node:v1.0.0"
+
+	sts := func() *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-node",
+				Namespace:   "default",
+				Annotations: map[string]string{StableImageAnnotation: stable},
+			},
+		}
+	}
+
+	canary := func(ready *time.Time, restarts int32) *corev1.Pod {
+		conditions := []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}}
+		if ready != nil {
+			conditions[0] = corev1.PodCondition{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(*ready)}
+		}
+
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-node-2", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "node", Image: image}},
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: conditions,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name:         "node",
+						RestartCount: restarts,
+						State: corev1.ContainerState{
+							Running: &corev1.ContainerStateRunning{},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	// status returns node status with syncing condition changed at the given time
+	status := func(syncing bool, changed time.Time) *sharedAPI.Status {
+		conditionStatus := metav1.ConditionFalse
+		if syncing {
+			conditionStatus = metav1.ConditionTrue
+		}
+		return &sharedAPI.Status{
+			Conditions: []metav1.Condition{
+				{Type: string(sharedAPI.SyncingCondition), Status: conditionStatus, LastTransitionTime: metav1.NewTime(changed)},
+			},
+		}
+	}
+
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour)
+
+	cases := []struct {
+		title   string
+		objects []client.Object
+		status  *sharedAPI.Status
+		rollout Rollout
+		requeue bool
+	}{
+		{
+			title:   "statefulset not created yet",
+			rollout: Rollout{Image: image, StableImage: image},
+		},
+		{
+			title:   "canary pod not updated yet",
+			objects: []client.Object{sts()},
+			rollout: Rollout{Image: image, Partition: 2, StableImage: stable},
+			requeue: true,
+		},
+		{
+			title:   "canary pod is running but not ready",
+			objects: []client.Object{sts(), canary(nil, 0)},
+			status:  status(false, hourAgo),
+			rollout: Rollout{Image: image, Partition: 2, StableImage: stable},
+			requeue: true,
+		},
+		{
+			title:   "canary pod is soaking",
+			objects: []client.Object{sts(), canary(&now, 0)},
+			status:  status(false, now),
+			rollout: Rollout{Image: image, Partition: 2, StableImage: stable},
+			requeue: true,
+		},
+		{
+			title:   "canary pod is ready but node is syncing",
+			objects: []client.Object{sts(), canary(&hourAgo, 0)},
+			status:  status(true, hourAgo),
+			rollout: Rollout{Image: image, Partition: 2, StableImage: stable},
+			requeue: true,
+		},
+		{
+			title:   "node stopped syncing recently",
+			objects: []client.Object{sts(), canary(&hourAgo, 0)},
+			status:  status(false, now),
+			rollout: Rollout{Image: image, Partition: 2, StableImage: stable},
+			requeue: true,
+		},
+		{
+			title:   "canary pod kept ready for soak period",
+			objects: []client.Object{sts(), canary(&hourAgo, 0)},
+			status:  status(false, hourAgo),
+			rollout: Rollout{Image: image, StableImage: image},
+		},
+		{
+			title:   "canary pod restarted",
+			objects: []client.Object{sts(), canary(nil, 1)},
+			rollout: Rollout{Image: stable, StableImage: stable, FailedImage: image},
+		},
+	}
+
+	for _, c := range cases {
+		func() {
+			cc := c
+			It(fmt.Sprintf("Should plan rollout if %s", cc.title), func() {
+				descriptor := testDescriptor()
+				descriptor.Replicas = 3
+				descriptor.UpdateStrategy = &sharedAPI.UpdateStrategy{Type: sharedAPI.CanaryUpdateStrategy, SoakPeriod: "10m"}
+
+				nodeStatus := cc.status
+				if nodeStatus == nil {
+					nodeStatus = &sharedAPI.Status{}
+				}
+
+				k8sClient := fake.NewClientBuilder().WithObjects(cc.objects...).Build()
+
+				requeueAfter, err := PlanRollout(context.Background(), k8sClient, nil, testNode(), nodeStatus, descriptor)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(descriptor.Rollout).To(Equal(&cc.rollout))
+				Expect(requeueAfter != 0).To(Equal(cc.requeue))
+			})
+		}()
+	}
+
+	It("Should apply rollout to statefulset", func() {
+		node := testNode()
+		descriptor := testDescriptor()
+		descriptor.InitContainers = []corev1.Container{{Name: "init-genesis", Image: "kotalco/node:v1.0.0"}}
+		descriptor.Rollout = &Rollout{
+			Image:       "kotalco/node:v0.9.0",
+			StableImage: "kotalco/node:v0.9.0",
+			FailedImage: "kotalco/node:v1.0.0",
+		}
+
+		sts := &appsv1.StatefulSet{}
+		SpecStatefulSet(node, sts, descriptor)
+
+		// node and init containers images are rolled back
+		spec := sts.Spec.Template.Spec
+		Expect(spec.Containers[0].Image).To(Equal("kotalco/node:v0.9.0"))
+		Expect(spec.InitContainers[0].Image).To(Equal("kotalco/node:v0.9.0"))
+		Expect(*sts.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(int32(0)))
+		Expect(sts.Annotations[FailedImageAnnotation]).To(Equal("kotalco/node:v1.0.0"))
+
+		// rollout annotations are removed if canary updates are disabled
+		descriptor.Rollout = nil
+		SpecStatefulSet(node, sts, descriptor)
+		Expect(sts.Annotations).NotTo(HaveKey(StableImageAnnotation))
+	})
+
+})
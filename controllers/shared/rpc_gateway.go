@@ -0,0 +1,100 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+const (
+	// RPCGatewayContainerName is the RPC gateway sidecar container name
+	RPCGatewayContainerName = "rpc-gateway"
+	// RPCGatewayVolumeName is the volume holding RPC gateway credentials
+	RPCGatewayVolumeName = "rpc-gateway-secret"
+	// RPCGatewaySecretDir is where RPC gateway credentials are mounted
+	RPCGatewaySecretDir = "/etc/rpc-gateway"
+)
+
+// RPCGatewayContainer returns RPC gateway sidecar proxying requests to node RPC server on rpcPort
+// and websocket connections to node websocket server on wsPort, zero wsPort rejects websocket connections
+// it returns nil if node has no RPC gateway
+func RPCGatewayContainer(gateway *sharedAPI.RPCGateway, rpcPort, wsPort uint) *corev1.Container {
+	if gateway == nil {
+		return nil
+	}
+
+	args := []string{
+		fmt.Sprintf("--listen=:%d", gateway.Port),
+		fmt.Sprintf("--upstream=http://127.0.0.1:%d", rpcPort),
+		fmt.Sprintf("--auth=%s", gateway.Auth),
+		fmt.Sprintf("--secret-dir=%s", RPCGatewaySecretDir),
+	}
+
+	if wsPort != 0 {
+		args = append(args, fmt.Sprintf("--ws-upstream=ws://127.0.0.1:%d", wsPort))
+	}
+
+	if gateway.RateLimit != 0 {
+		args = append(args,
+			fmt.Sprintf("--rate-limit=%d", gateway.RateLimit),
+			fmt.Sprintf("--burst=%d", gateway.Burst),
+		)
+	}
+
+	if len(gateway.AllowedMethods) != 0 {
+		args = append(args, fmt.Sprintf("--allow=%s", strings.Join(gateway.AllowedMethods, ",")))
+	}
+
+	if len(gateway.DeniedMethods) != 0 {
+		args = append(args, fmt.Sprintf("--deny=%s", strings.Join(gateway.DeniedMethods, ",")))
+	}
+
+	return &corev1.Container{
+		Name:  RPCGatewayContainerName,
+		Image: gateway.Image,
+		Args:  args,
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          RPCGatewayContainerName,
+				ContainerPort: int32(gateway.Port),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      RPCGatewayVolumeName,
+				MountPath: RPCGatewaySecretDir,
+				ReadOnly:  true,
+			},
+		},
+	}
+}
+
+// RPCGatewayVolume returns volume holding RPC gateway credentials secret
+// it returns nil if node has no RPC gateway
+func RPCGatewayVolume(gateway *sharedAPI.RPCGateway) *corev1.Volume {
+	if gateway == nil {
+		return nil
+	}
+
+	return &corev1.Volume{
+		Name: RPCGatewayVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: gateway.SecretName,
+			},
+		},
+	}
+}
+
+// RPCGatewayTargetPort returns service RPC or websocket target port
+// it's the RPC gateway port if node has RPC gateway, otherwise node port
+func RPCGatewayTargetPort(gateway *sharedAPI.RPCGateway, port uint) intstr.IntOrString {
+	if gateway == nil {
+		return intstr.FromInt(int(port))
+	}
+	return intstr.FromInt(int(gateway.Port))
+}
@@ -0,0 +1,57 @@
+package shared
+
+import (
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("RPC gateway", func() {
+
+	It("Should not create container without RPC gateway", func() {
+		Expect(RPCGatewayContainer(nil, 8545, 0)).To(BeNil())
+	})
+
+	It("Should create RPC gateway container", func() {
+		gateway := &sharedAPI.RPCGateway{
+			Port:           8080,
+			Auth:           sharedAPI.JWTAuth,
+			SecretName:     "rpc-jwt",
+			RateLimit:      5,
+			Burst:          10,
+			AllowedMethods: []string{"eth_*", "net_version"},
+			DeniedMethods:  []string{"eth_sign"},
+			Image:          sharedAPI.DefaultRPCGatewayImage,
+		}
+
+		container := RPCGatewayContainer(gateway, 8545, 8546)
+
+		Expect(container.Args).To(Equal([]string{
+			"--listen=:8080",
+			"--upstream=http://127.0.0.1:8545",
+			"--auth=JWT",
+			"--secret-dir=/etc/rpc-gateway",
+			"--ws-upstream=ws://127.0.0.1:8546",
+			"--rate-limit=5",
+			"--burst=10",
+			"--allow=eth_*,net_version",
+			"--deny=eth_sign",
+		}))
+		Expect(container.Ports[0].ContainerPort).To(Equal(int32(8080)))
+		Expect(container.VolumeMounts[0].Name).To(Equal(RPCGatewayVolumeName))
+	})
+
+	It("Should mount RPC gateway credentials secret", func() {
+		Expect(RPCGatewayVolume(nil)).To(BeNil())
+
+		volume := RPCGatewayVolume(&sharedAPI.RPCGateway{SecretName: "rpc-keys"})
+		Expect(volume.Secret.SecretName).To(Equal("rpc-keys"))
+	})
+
+	It("Should route service port to RPC gateway", func() {
+		Expect(RPCGatewayTargetPort(nil, 8545)).To(Equal(intstr.FromInt(8545)))
+		Expect(RPCGatewayTargetPort(&sharedAPI.RPCGateway{Port: 8080}, 8545)).To(Equal(intstr.FromInt(8080)))
+	})
+
+})
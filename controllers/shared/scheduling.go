@@ -0,0 +1,41 @@
+package shared
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+// ApplyScheduling updates node pod template with scheduling constraints and metadata overrides
+// extra pod labels can't override node labels, because they're used by the statefulset selector
+func ApplyScheduling(template *corev1.PodTemplateSpec, scheduling *sharedAPI.Scheduling) {
+	labels := map[string]string{}
+	for k, v := range scheduling.PodLabels {
+		labels[k] = v
+	}
+	for k, v := range template.Labels {
+		labels[k] = v
+	}
+	template.Labels = labels
+
+	if len(scheduling.PodAnnotations) != 0 {
+		annotations := map[string]string{}
+		for k, v := range template.Annotations {
+			annotations[k] = v
+		}
+		for k, v := range scheduling.PodAnnotations {
+			annotations[k] = v
+		}
+		template.Annotations = annotations
+	}
+
+	template.Spec.NodeSelector = scheduling.NodeSelector
+	template.Spec.Tolerations = scheduling.Tolerations
+	template.Spec.TopologySpreadConstraints = scheduling.TopologySpreadConstraints
+	template.Spec.PriorityClassName = scheduling.PriorityClassName
+
+	// explicit affinity overrides any affinity set by the node controller
+	if scheduling.Affinity != nil {
+		template.Spec.Affinity = scheduling.Affinity
+	}
+}
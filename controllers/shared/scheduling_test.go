@@ -0,0 +1,78 @@
+package shared
+
+import (
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Pod scheduling", func() {
+
+	It("Should apply pod scheduling and metadata overrides", func() {
+		nodeLabels := map[string]string{
+			"app.kubernetes.io/instance": "my-node",
+		}
+
+		nodeAffinity := &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{},
+		}
+
+		template := corev1.PodTemplateSpec{}
+		template.Labels = nodeLabels
+		template.Spec.Affinity = nodeAffinity
+
+		scheduling := sharedAPI.Scheduling{
+			NodeSelector: map[string]string{
+				"kubernetes.io/arch": "amd64",
+			},
+			Tolerations: []corev1.Toleration{
+				{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "blockchain",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+				{
+					MaxSkew:           1,
+					TopologyKey:       "topology.kubernetes.io/zone",
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+				},
+			},
+			PriorityClassName: "blockchain-critical",
+			PodLabels: map[string]string{
+				"team":                       "infra",
+				"app.kubernetes.io/instance": "another-node",
+			},
+			PodAnnotations: map[string]string{
+				"cluster-autoscaler.kubernetes.io/safe-to-evict": "false",
+			},
+		}
+
+		ApplyScheduling(&template, &scheduling)
+
+		// node labels can't be overridden
+		Expect(template.Labels).To(Equal(map[string]string{
+			"app.kubernetes.io/instance": "my-node",
+			"team":                       "infra",
+		}))
+		Expect(nodeLabels).To(HaveLen(1))
+		Expect(template.Annotations).To(Equal(scheduling.PodAnnotations))
+		Expect(template.Spec.NodeSelector).To(Equal(scheduling.NodeSelector))
+		Expect(template.Spec.Tolerations).To(Equal(scheduling.Tolerations))
+		Expect(template.Spec.TopologySpreadConstraints).To(Equal(scheduling.TopologySpreadConstraints))
+		Expect(template.Spec.PriorityClassName).To(Equal(scheduling.PriorityClassName))
+
+		// node controller affinity is kept if no affinity is provided
+		Expect(template.Spec.Affinity).To(BeIdenticalTo(nodeAffinity))
+
+		affinity := &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{},
+		}
+		ApplyScheduling(&template, &sharedAPI.Scheduling{Affinity: affinity})
+		Expect(template.Spec.Affinity).To(BeIdenticalTo(affinity))
+	})
+
+})
@@ -2,18 +2,62 @@ package shared
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
 
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 )
 
+const (
+	// SecretsIndexKey is field index of secrets referenced by node spec
+	SecretsIndexKey = ".spec.secretNames"
+	// SecretsHashAnnotation is pod template annotation holding hash of secrets referenced by node spec
+	SecretsHashAnnotation = "kotal.io/secrets-hash"
+)
+
+// SecretReferrer is node referencing k8s secrets by name in its spec
+type SecretReferrer interface {
+	client.Object
+	// SecretNames returns names of secrets referenced by node spec
+	SecretNames() []string
+}
+
+// SecretNotFoundError is returned if node spec references a secret that doesn't exist
+type SecretNotFoundError struct {
+	// Name is secret namespaced name
+	Name types.NamespacedName
+	// Err is the api server not found error
+	Err error
+}
+
+func (e *SecretNotFoundError) Error() string {
+	return fmt.Sprintf("secret %s not found", e.Name)
+}
+
+func (e *SecretNotFoundError) Unwrap() error {
+	return e.Err
+}
+
 // GetSecret returns k8s secret stored at key
 func GetSecret(ctx context.Context, client client.Client, name types.NamespacedName, key string) (value string, err error) {
 	secret := &corev1.Secret{}
 
 	if err = client.Get(ctx, name, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			err = &SecretNotFoundError{Name: name, Err: err}
+		}
 		return
 	}
 
@@ -21,3 +65,104 @@ func GetSecret(ctx context.Context, client client.Client, name types.NamespacedN
 
 	return
 }
+
+// HashSecrets returns hash of secrets names and data
+func HashSecrets(secrets []corev1.Secret) string {
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+
+	hash := sha256.New()
+
+	for _, secret := range secrets {
+		keys := make([]string, 0, len(secret.Data))
+		for key := range secret.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		fmt.Fprintf(hash, "%s\n", secret.Name)
+		for _, key := range keys {
+			fmt.Fprintf(hash, "%s=%x\n", key, secret.Data[key])
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// SecretsHash returns hash of secrets referenced by node spec
+// empty hash is returned if node doesn't reference secrets, missing secrets are skipped
+func SecretsHash(ctx context.Context, c client.Client, node client.Object) (string, error) {
+	referrer, ok := node.(SecretReferrer)
+	if !ok {
+		return "", nil
+	}
+
+	names := referrer.SecretNames()
+	if len(names) == 0 {
+		return "", nil
+	}
+
+	secrets := []corev1.Secret{}
+
+	for _, name := range names {
+		secret := corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: node.GetNamespace()}, &secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", err
+		}
+		secrets = append(secrets, secret)
+	}
+
+	return HashSecrets(secrets), nil
+}
+
+// AnnotateSecretsHash sets secrets hash annotation on pod template if node references secrets
+func AnnotateSecretsHash(template *corev1.PodTemplateSpec, hash string) {
+	if hash == "" {
+		return
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[SecretsHashAnnotation] = hash
+}
+
+// IndexSecretNames indexes nodes by names of secrets referenced by their spec
+func IndexSecretNames(obj client.Object) []string {
+	return obj.(SecretReferrer).SecretNames()
+}
+
+// NodesReferencingSecret returns map function enqueuing nodes referencing secret
+// nodes are listed from the cache using secrets field index
+func NodesReferencingSecret(c client.Client, scheme *runtime.Scheme, node client.Object) (handler.MapFunc, error) {
+	gvk, err := apiutil.GVKForObject(node, scheme)
+	if err != nil {
+		return nil, err
+	}
+	gvk.Kind += "List"
+
+	return func(secret client.Object) (requests []reconcile.Request) {
+		obj, err := scheme.New(gvk)
+		if err != nil {
+			return
+		}
+		list := obj.(client.ObjectList)
+
+		if err := c.List(context.Background(), list, client.InNamespace(secret.GetNamespace()), client.MatchingFields{SecretsIndexKey: secret.GetName()}); err != nil {
+			ctrl.Log.WithName("secrets").Error(err, "unable to list nodes referencing secret", "secret", client.ObjectKeyFromObject(secret))
+			return
+		}
+
+		meta.EachListItem(list, func(item runtime.Object) error {
+			if o, ok := item.(client.Object); ok {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(o)})
+			}
+			return nil
+		})
+
+		return
+	}, nil
+}
@@ -0,0 +1,56 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testSecret(name string, data map[string]string) corev1.Secret {
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Data:       map[string][]byte{},
+	}
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+	return secret
+}
+
+var _ = Describe("Secrets hash", func() {
+
+	It("Should hash secrets", func() {
+		cert := testSecret("cert", map[string]string{"tls.crt": "certificate", "tls.key": "key"})
+		password := testSecret("password", map[string]string{"password": "secret"})
+
+		hash := HashSecrets([]corev1.Secret{cert, password})
+
+		// hash is independent of secrets order
+		Expect(HashSecrets([]corev1.Secret{password, cert})).To(Equal(hash))
+
+		// hash changes when secret data changes
+		rotated := testSecret("cert", map[string]string{"tls.crt": "renewed certificate", "tls.key": "key"})
+		Expect(HashSecrets([]corev1.Secret{rotated, password})).NotTo(Equal(hash))
+
+		// same data held by different secret names
+		renamed := testSecret("renamed", map[string]string{"password": "secret"})
+		Expect(HashSecrets([]corev1.Secret{renamed})).NotTo(Equal(HashSecrets([]corev1.Secret{password})))
+	})
+
+	It("Should annotate pod template with secrets hash", func() {
+		template := &corev1.PodTemplateSpec{}
+
+		// nodes without secrets
+		AnnotateSecretsHash(template, "")
+		Expect(template.Annotations).To(BeNil())
+
+		template.Annotations = map[string]string{"prometheus.io/scrape": "true"}
+		AnnotateSecretsHash(template, "abc")
+
+		Expect(template.Annotations[SecretsHashAnnotation]).To(Equal("abc"))
+		// pod annotations are preserved
+		Expect(template.Annotations["prometheus.io/scrape"]).To(Equal("true"))
+	})
+
+})
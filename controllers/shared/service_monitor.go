@@ -0,0 +1,99 @@
+package shared
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ServiceMonitorGVK is prometheus operator service monitor group, version and kind
+var ServiceMonitorGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "ServiceMonitor",
+}
+
+// MetricsEndpoint is node service port and path serving prometheus metrics
+type MetricsEndpoint struct {
+	// Port is node service port name
+	Port string
+	// Path is metrics HTTP path
+	Path string
+}
+
+// IsServiceMonitorInstalled checks if prometheus operator ServiceMonitor CRD exists in the cluster
+func IsServiceMonitorInstalled(c client.Client) (bool, error) {
+	_, err := c.RESTMapper().RESTMapping(ServiceMonitorGVK.GroupKind(), ServiceMonitorGVK.Version)
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ReconcileServiceMonitor creates node service monitor if endpoint is provided, otherwise deletes it
+// service monitor has the same name as the node, and it's skipped if prometheus operator is not installed
+func ReconcileServiceMonitor(ctx context.Context, c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, node client.Object, endpoint *MetricsEndpoint) error {
+	installed, err := IsServiceMonitorInstalled(c)
+	if err != nil || !installed {
+		return err
+	}
+
+	sm := NewServiceMonitor(node)
+
+	if endpoint == nil {
+		return client.IgnoreNotFound(c.Delete(ctx, sm))
+	}
+
+	op, err := ctrl.CreateOrUpdate(ctx, c, sm, func() error {
+		if err := ctrl.SetControllerReference(node, sm, scheme); err != nil {
+			return err
+		}
+		return SpecServiceMonitor(node, sm, endpoint)
+	})
+	if err != nil {
+		return err
+	}
+
+	RecordOperation(recorder, node, "ServiceMonitor", sm.GetName(), op)
+
+	return nil
+}
+
+// NewServiceMonitor returns empty node service monitor
+func NewServiceMonitor(node client.Object) *unstructured.Unstructured {
+	sm := &unstructured.Unstructured{}
+	sm.SetGroupVersionKind(ServiceMonitorGVK)
+	sm.SetName(node.GetName())
+	sm.SetNamespace(node.GetNamespace())
+	return sm
+}
+
+// SpecServiceMonitor updates service monitor spec to scrape node service metrics endpoint
+func SpecServiceMonitor(node client.Object, sm *unstructured.Unstructured, endpoint *MetricsEndpoint) error {
+	labels := map[string]interface{}{}
+	for k, v := range node.GetLabels() {
+		labels[k] = v
+	}
+
+	sm.SetLabels(node.GetLabels())
+
+	spec := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": labels,
+		},
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"port": endpoint.Port,
+				"path": endpoint.Path,
+			},
+		},
+	}
+
+	return unstructured.SetNestedField(sm.Object, spec, "spec")
+}
@@ -0,0 +1,44 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Service monitor", func() {
+
+	It("Should scrape node metrics endpoint", func() {
+		labels := map[string]string{
+			"app.kubernetes.io/name":     "geth",
+			"app.kubernetes.io/instance": "my-node",
+		}
+
+		node := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-node",
+				Namespace: "default",
+				Labels:    labels,
+			},
+		}
+
+		sm := &unstructured.Unstructured{}
+		sm.SetGroupVersionKind(ServiceMonitorGVK)
+
+		endpoint := &MetricsEndpoint{Port: "metrics", Path: "/debug/metrics/prometheus"}
+		Expect(SpecServiceMonitor(node, sm, endpoint)).To(Succeed())
+
+		Expect(sm.GetLabels()).To(Equal(labels))
+
+		selector, _, _ := unstructured.NestedStringMap(sm.Object, "spec", "selector", "matchLabels")
+		Expect(selector).To(Equal(labels))
+
+		endpoints, _, _ := unstructured.NestedSlice(sm.Object, "spec", "endpoints")
+		Expect(endpoints).To(HaveLen(1))
+		Expect(endpoints[0]).To(HaveKeyWithValue("port", "metrics"))
+		Expect(endpoints[0]).To(HaveKeyWithValue("path", "/debug/metrics/prometheus"))
+	})
+
+})
@@ -0,0 +1,212 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+// degradedReasons are container waiting reasons that won't recover without intervention
+var degradedReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// UpdateStatus derives node status conditions and phase from node statefulset and its pods
+// statefulset is expected to have the same name and namespace as the node
+func UpdateStatus(ctx context.Context, c client.Client, node client.Object, status *sharedAPI.Status) error {
+	sts := &appsv1.StatefulSet{}
+	key := types.NamespacedName{Name: node.GetName(), Namespace: node.GetNamespace()}
+
+	if err := c.Get(ctx, key, sts); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		SetStatus(status, node.GetGeneration(), nil, nil)
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	if sts.Spec.Selector != nil {
+		opts := []client.ListOption{
+			client.InNamespace(sts.Namespace),
+			client.MatchingLabels(sts.Spec.Selector.MatchLabels),
+		}
+		if err := c.List(ctx, pods, opts...); err != nil {
+			return err
+		}
+	}
+
+	SetStatus(status, node.GetGeneration(), sts, pods.Items)
+
+	return nil
+}
+
+// SetStatus sets status conditions and phase from statefulset and its pods
+func SetStatus(status *sharedAPI.Status, generation int64, sts *appsv1.StatefulSet, pods []corev1.Pod) {
+	status.ObservedGeneration = generation
+	status.ReadyReplicas = readyReplicas(pods)
+
+	condition := func(conditionType sharedAPI.ConditionType, value bool, reason, message string) {
+		conditionStatus := metav1.ConditionFalse
+		if value {
+			conditionStatus = metav1.ConditionTrue
+		}
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               string(conditionType),
+			Status:             conditionStatus,
+			ObservedGeneration: generation,
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+
+	if sts == nil {
+		condition(sharedAPI.ReadyCondition, false, "StatefulSetNotFound", "node statefulset has not been created yet")
+		condition(sharedAPI.ProgressingCondition, true, "StatefulSetNotFound", "node statefulset has not been created yet")
+		condition(sharedAPI.DegradedCondition, false, "StatefulSetNotFound", "node statefulset has not been created yet")
+		condition(sharedAPI.SyncingCondition, false, "StatefulSetNotFound", "node statefulset has not been created yet")
+		status.Phase = sharedAPI.PendingPhase
+		return
+	}
+
+	var desired int32 = 1
+	if sts.Spec.Replicas != nil {
+		desired = *sts.Spec.Replicas
+	}
+
+	replicas := fmt.Sprintf("%d/%d replicas are ready", sts.Status.ReadyReplicas, desired)
+
+	// ready
+	ready := desired > 0 && sts.Status.ReadyReplicas >= desired
+	if ready {
+		condition(sharedAPI.ReadyCondition, true, "PodsReady", replicas)
+	} else {
+		condition(sharedAPI.ReadyCondition, false, "PodsNotReady", replicas)
+	}
+
+	// progressing
+	progressing := sts.Status.ObservedGeneration < sts.Generation ||
+		sts.Status.UpdatedReplicas < desired ||
+		(sts.Status.UpdateRevision != "" && sts.Status.CurrentRevision != sts.Status.UpdateRevision)
+	if progressing {
+		message := fmt.Sprintf("%d/%d replicas are updated", sts.Status.UpdatedReplicas, desired)
+		condition(sharedAPI.ProgressingCondition, true, "RollingUpdate", message)
+	} else {
+		condition(sharedAPI.ProgressingCondition, false, "RolloutComplete", "all replicas are updated")
+	}
+
+	// degraded
+	reason, message := degradedReason(pods)
+	degraded := reason != ""
+	if degraded {
+		condition(sharedAPI.DegradedCondition, true, reason, message)
+	} else {
+		condition(sharedAPI.DegradedCondition, false, "NoFailures", "no failing containers")
+	}
+
+	// syncing
+	running := false
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodRunning {
+			running = true
+			break
+		}
+	}
+
+	syncing := running && !ready && !degraded
+	if syncing {
+		condition(sharedAPI.SyncingCondition, true, "PodsRunning", "node is running but not ready yet")
+	} else {
+		condition(sharedAPI.SyncingCondition, false, "NotSyncing", "node is not running or already ready")
+	}
+
+	switch {
+	case degraded:
+		status.Phase = sharedAPI.DegradedPhase
+	case ready:
+		status.Phase = sharedAPI.ReadyPhase
+	case syncing:
+		status.Phase = sharedAPI.SyncingPhase
+	default:
+		status.Phase = sharedAPI.PendingPhase
+	}
+}
+
+// NodeOfPod returns map function enqueuing the node owning the pod
+// pods carry node labels, pods failing before they were ever ready don't change node statefulset status
+func NodeOfPod(scheme *runtime.Scheme, node client.Object) (handler.MapFunc, error) {
+	gvk, err := apiutil.GVKForObject(node, scheme)
+	if err != nil {
+		return nil, err
+	}
+	createdBy := fmt.Sprintf("%s-controller", component(gvk))
+
+	return func(pod client.Object) []reconcile.Request {
+		labels := pod.GetLabels()
+		if labels["app.kubernetes.io/managed-by"] != "kotal" || labels["app.kubernetes.io/created-by"] != createdBy {
+			return nil
+		}
+
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Name:      labels["app.kubernetes.io/instance"],
+					Namespace: pod.GetNamespace(),
+				},
+			},
+		}
+	}, nil
+}
+
+// readyReplicas returns sorted names of ready pods
+func readyReplicas(pods []corev1.Pod) (names []string) {
+	for _, pod := range pods {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				names = append(names, pod.Name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return
+}
+
+// degradedReason returns the reason and message of the first failing container
+func degradedReason(pods []corev1.Pod) (reason, message string) {
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodFailed {
+			return "PodFailed", fmt.Sprintf("pod %s has failed", pod.Name)
+		}
+
+		statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+		for _, status := range statuses {
+			if waiting := status.State.Waiting; waiting != nil && degradedReasons[waiting.Reason] {
+				return waiting.Reason, fmt.Sprintf("container %s in pod %s: %s", status.Name, pod.Name, waiting.Message)
+			}
+		}
+	}
+	return
+}
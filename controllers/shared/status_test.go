@@ -0,0 +1,184 @@
+package shared
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+)
+
+var _ = Describe("Node status", func() {
+
+	var replicas int32 = 1
+
+	sts := func(ready int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			Spec: appsv1.StatefulSetSpec{Replicas: &replicas},
+			Status: appsv1.StatefulSetStatus{
+				ReadyReplicas:   ready,
+				UpdatedReplicas: replicas,
+			},
+		}
+	}
+
+	running := corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodRunning}}
+	crashing := corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "node",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		title      string
+		sts        *appsv1.StatefulSet
+		pods       []corev1.Pod
+		phase      sharedAPI.Phase
+		conditions map[sharedAPI.ConditionType]bool
+	}{
+		{
+			title: "statefulset not created yet",
+			phase: sharedAPI.PendingPhase,
+			conditions: map[sharedAPI.ConditionType]bool{
+				sharedAPI.ReadyCondition:       false,
+				sharedAPI.ProgressingCondition: true,
+			},
+		},
+		{
+			title: "pods are not running yet",
+			sts:   sts(0),
+			phase: sharedAPI.PendingPhase,
+			conditions: map[sharedAPI.ConditionType]bool{
+				sharedAPI.ReadyCondition:   false,
+				sharedAPI.SyncingCondition: false,
+			},
+		},
+		{
+			title: "pods are running but not ready",
+			sts:   sts(0),
+			pods:  []corev1.Pod{running},
+			phase: sharedAPI.SyncingPhase,
+			conditions: map[sharedAPI.ConditionType]bool{
+				sharedAPI.ReadyCondition:    false,
+				sharedAPI.SyncingCondition:  true,
+				sharedAPI.DegradedCondition: false,
+			},
+		},
+		{
+			title: "pods are running and ready",
+			sts:   sts(1),
+			pods:  []corev1.Pod{running},
+			phase: sharedAPI.ReadyPhase,
+			conditions: map[sharedAPI.ConditionType]bool{
+				sharedAPI.ReadyCondition:       true,
+				sharedAPI.ProgressingCondition: false,
+				sharedAPI.SyncingCondition:     false,
+			},
+		},
+		{
+			title: "pods are crash-looping",
+			sts:   sts(0),
+			pods:  []corev1.Pod{crashing},
+			phase: sharedAPI.DegradedPhase,
+			conditions: map[sharedAPI.ConditionType]bool{
+				sharedAPI.ReadyCondition:    false,
+				sharedAPI.DegradedCondition: true,
+				sharedAPI.SyncingCondition:  false,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		func() {
+			cc := c
+			It(fmt.Sprintf("Should set status if %s", cc.title), func() {
+				status := sharedAPI.Status{}
+				SetStatus(&status, 3, cc.sts, cc.pods)
+
+				Expect(status.ObservedGeneration).To(Equal(int64(3)))
+				Expect(status.ReadyReplicas).To(BeEmpty())
+				Expect(status.Phase).To(Equal(cc.phase))
+
+				for conditionType, expected := range cc.conditions {
+					Expect(meta.IsStatusConditionTrue(status.Conditions, string(conditionType))).To(Equal(expected), "condition %s", conditionType)
+				}
+			})
+		}()
+	}
+
+	It("Should list sorted ready replicas", func() {
+		pod := func(name string, ready corev1.ConditionStatus) corev1.Pod {
+			return corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status: corev1.PodStatus{
+					Phase:      corev1.PodRunning,
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+				},
+			}
+		}
+
+		var replicas int32 = 3
+		sts := &appsv1.StatefulSet{
+			Spec:   appsv1.StatefulSetSpec{Replicas: &replicas},
+			Status: appsv1.StatefulSetStatus{ReadyReplicas: 2, UpdatedReplicas: replicas},
+		}
+		pods := []corev1.Pod{
+			pod("my-node-2", corev1.ConditionTrue),
+			pod("my-node-1", corev1.ConditionFalse),
+			pod("my-node-0", corev1.ConditionTrue),
+		}
+
+		status := sharedAPI.Status{}
+		SetStatus(&status, 1, sts, pods)
+
+		Expect(status.ReadyReplicas).To(Equal([]string{"my-node-0", "my-node-2"}))
+		Expect(status.Phase).To(Equal(sharedAPI.SyncingPhase))
+	})
+
+	It("Should enqueue node owning the pod", func() {
+		scheme := runtime.NewScheme()
+		Expect(ethereumv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		mapFn, err := NodeOfPod(scheme, &ethereumv1alpha1.Node{})
+		Expect(err).NotTo(HaveOccurred())
+
+		labels := func(createdBy string) map[string]string {
+			return map[string]string{
+				"app.kubernetes.io/instance":   "my-node",
+				"app.kubernetes.io/managed-by": "kotal",
+				"app.kubernetes.io/created-by": createdBy,
+			}
+		}
+
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-node-0", Namespace: "default", Labels: labels("ethereum-node-controller")}}
+		Expect(mapFn(pod)).To(Equal([]reconcile.Request{
+			{NamespacedName: types.NamespacedName{Name: "my-node", Namespace: "default"}},
+		}))
+
+		// pods of other kinds of nodes
+		pod.Labels = labels("ethereum2-validator-controller")
+		Expect(mapFn(pod)).To(BeEmpty())
+
+		// pods not managed by kotal
+		pod.Labels = map[string]string{"app.kubernetes.io/instance": "my-node"}
+		Expect(mapFn(pod)).To(BeEmpty())
+	})
+
+})
@@ -0,0 +1,52 @@
+package shared
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SuspendAnnotation is node annotation used to scale node statefulset down to zero
+	// its value is the name of the backup or restore suspending the node
+	SuspendAnnotation = "kotal.io/suspended-by"
+	// RestoreSnapshotAnnotation is node annotation holding the volume snapshot to restore node storage from
+	RestoreSnapshotAnnotation = "kotal.io/restore-snapshot"
+)
+
+// IsSuspended returns true if the node is suspended
+func IsSuspended(node client.Object) bool {
+	_, suspended := node.GetAnnotations()[SuspendAnnotation]
+	return suspended
+}
+
+// Replicas returns node statefulset replicas, it's zero if the node is suspended
+// replicas isn't defaulted yet if webhooks are disabled, node has a single replica if it's zero
+func Replicas(node client.Object, replicas uint) *int32 {
+	count := int32(replicas)
+
+	if count == 0 {
+		count = 1
+	}
+
+	if IsSuspended(node) {
+		count = 0
+	}
+
+	return &count
+}
+
+// PVCDataSource returns the volume snapshot to create node persistent volume claim from if any
+func PVCDataSource(node client.Object) *corev1.TypedLocalObjectReference {
+	snapshot, ok := node.GetAnnotations()[RestoreSnapshotAnnotation]
+	if !ok {
+		return nil
+	}
+
+	apiGroup := VolumeSnapshotGVK.Group
+
+	return &corev1.TypedLocalObjectReference{
+		APIGroup: &apiGroup,
+		Kind:     VolumeSnapshotGVK.Kind,
+		Name:     snapshot,
+	}
+}
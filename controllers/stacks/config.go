@@ -27,10 +27,12 @@ type Node struct {
 	WorkingDir      string `toml:"working_dir"`
 	RPCBind         string `toml:"rpc_bind"`
 	P2PBind         string `toml:"p2p_bind"`
+	P2PAddress      string `toml:"p2p_address,omitempty"`
 	Seed            string `toml:"seed,omitempty"`
 	LocalPeerSeed   string `toml:"local_peer_seed"`
 	Miner           bool   `toml:"miner"`
 	MineMicroblocks bool   `toml:"mine_microblocks,omitempty"`
+	PrometheusBind  string `toml:"prometheus_bind,omitempty"`
 }
 
 type Config struct {
@@ -49,6 +51,14 @@ func ConfigFromSpec(node *stacksv1alpha1.Node, client client.Client) (config str
 		Miner:      node.Spec.Miner,
 	}
 
+	if externalIP := node.Spec.Expose.GetExternalIP(); externalIP != "" {
+		c.Node.P2PAddress = fmt.Sprintf("%s:%d", externalIP, node.Spec.Expose.AnnouncedPort(node.Spec.P2PPort))
+	}
+
+	if node.Spec.Metrics.Enabled {
+		c.Node.PrometheusBind = fmt.Sprintf("%s:%d", node.Spec.Metrics.Host, node.Spec.Metrics.Port)
+	}
+
 	if node.Spec.Miner {
 		var seedPrivateKey string
 		name := types.NamespacedName{
@@ -104,7 +114,7 @@ func ConfigFromSpec(node *stacksv1alpha1.Node, client client.Client) (config str
 		return
 	}
 
-	config = buff.String()
+	config, err = shared.MergeTOML(buff.String(), node.Spec.ConfigOverrides)
 
 	return
 }
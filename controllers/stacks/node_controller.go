@@ -4,12 +4,10 @@ import (
 	"context"
 
 	stacksClients "github.com/kotalco/kotal/clients/stacks"
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -21,13 +19,25 @@ import (
 // NodeReconciler reconciles a Node object
 type NodeReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=stacks.kotal.io,resources=nodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=stacks.kotal.io,resources=nodes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=stacks.kotal.io,resources=nodes/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
-// +kubebuilder:rbac:groups=core,resources=services;configmaps,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=services;configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=watch;get;list
+// +kubebuilder:rbac:groups=core,resources=nodes/proxy,verbs=get
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=watch;get;list
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	var node stacksv1alpha1.Node
@@ -37,26 +47,35 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
-	// default the node if webhooks are disabled
-	if !shared.IsWebhookEnabled() {
-		node.Default()
-	}
+	defer func() { shared.RecordError(r.Recorder, &node, err) }()
+	defer func() { shared.ObserveReconcile(r.Scheme, &node, string(node.Spec.Network), err) }()
 
-	shared.UpdateLabels(&node, "stacks-node")
+	if !node.DeletionTimestamp.IsZero() {
+		result, err = shared.FinalizeStorage(ctx, r.Client, &node, node.Spec.RetentionPolicy, 1)
+		return
+	}
 
-	if err = r.reconcileConfigmap(ctx, &node); err != nil {
+	if err = shared.EnsureRetentionFinalizer(ctx, r.Client, &node); err != nil {
 		return
 	}
 
-	if err = r.reconcilePVC(ctx, &node); err != nil {
+	if result.RequeueAfter, err = shared.AutoExpandStorage(ctx, r.Client, r.Recorder, &node, &node.Spec.Resources); err != nil {
 		return
 	}
 
-	if err = r.reconcileService(ctx, &node); err != nil {
+	// default the node if webhooks are disabled
+	if !shared.IsWebhookEnabled() {
+		node.Default()
+	}
+
+	shared.UpdateLabels(&node, "stacks-node")
+
+	descriptor, err := r.Descriptor(&node)
+	if err != nil {
 		return
 	}
 
-	if err = r.reconcileStatefulset(ctx, &node); err != nil {
+	if err = shared.ReconcileNode(ctx, r.Client, r.Scheme, r.Recorder, &node, descriptor); err != nil {
 		return
 	}
 
@@ -71,6 +90,10 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 func (r *NodeReconciler) updateStatus(ctx context.Context, node *stacksv1alpha1.Node) error {
 	node.Status.Client = "stacks"
 
+	if err := shared.UpdateStatus(ctx, r.Client, node, &node.Status.Status); err != nil {
+		return err
+	}
+
 	if err := r.Status().Update(ctx, node); err != nil {
 		log.FromContext(ctx).Error(err, "unable to update node status")
 		return err
@@ -79,117 +102,17 @@ func (r *NodeReconciler) updateStatus(ctx context.Context, node *stacksv1alpha1.
 	return nil
 }
 
-// specConfigmap updates node statefulset spec
-func (r *NodeReconciler) specConfigmap(node *stacksv1alpha1.Node, configmap *corev1.ConfigMap, configToml string) {
-	configmap.ObjectMeta.Labels = node.Labels
-
-	if configmap.Data == nil {
-		configmap.Data = map[string]string{}
-	}
-
-	configmap.Data["config.toml"] = configToml
-
-}
-
-// reconcileConfigmap reconciles node configmap
-func (r *NodeReconciler) reconcileConfigmap(ctx context.Context, node *stacksv1alpha1.Node) error {
-
-	configmap := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
+// Descriptor returns Stacks node resources that are reconciled by the shared node reconciler
+func (r *NodeReconciler) Descriptor(node *stacksv1alpha1.Node) (*shared.NodeDescriptor, error) {
+	client := stacksClients.NewClient(node)
 
-	// filecoin generates config.toml file from node spec
+	// stacks generates config.toml file from node spec
 	configToml, err := ConfigFromSpec(node, r.Client)
 	if err != nil {
-		return err
-	}
-
-	_, err = ctrl.CreateOrUpdate(ctx, r.Client, configmap, func() error {
-		if err := ctrl.SetControllerReference(node, configmap, r.Scheme); err != nil {
-			return err
-		}
-		r.specConfigmap(node, configmap, configToml)
-		return nil
-	})
-
-	return err
-}
-
-// reconcilePVC reconciles Stacks node persistent volume claim
-func (r *NodeReconciler) reconcilePVC(ctx context.Context, node *stacksv1alpha1.Node) error {
-	pvc := &corev1.PersistentVolumeClaim{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, pvc, func() error {
-		if err := ctrl.SetControllerReference(node, pvc, r.Scheme); err != nil {
-			return err
-		}
-
-		r.specPVC(node, pvc)
-
-		return nil
-	})
-
-	return err
-}
-
-// specPVC updates Stacks node persistent volume claim
-func (r *NodeReconciler) specPVC(node *stacksv1alpha1.Node, pvc *corev1.PersistentVolumeClaim) {
-	request := corev1.ResourceList{
-		corev1.ResourceStorage: resource.MustParse(node.Spec.Storage),
-	}
-
-	// spec is immutable after creation except resources.requests for bound claims
-	if !pvc.CreationTimestamp.IsZero() {
-		pvc.Spec.Resources.Requests = request
-		return
+		return nil, err
 	}
 
-	pvc.ObjectMeta.Labels = node.Labels
-	pvc.Spec = corev1.PersistentVolumeClaimSpec{
-		AccessModes: []corev1.PersistentVolumeAccessMode{
-			corev1.ReadWriteOnce,
-		},
-		Resources: corev1.ResourceRequirements{
-			Requests: request,
-		},
-	}
-}
-
-// reconcileService reconciles Bitcoin node service
-func (r *NodeReconciler) reconcileService(ctx context.Context, node *stacksv1alpha1.Node) error {
-	svc := &corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, svc, func() error {
-		if err := ctrl.SetControllerReference(node, svc, r.Scheme); err != nil {
-			return err
-		}
-		r.specService(node, svc)
-		return nil
-	})
-
-	return err
-}
-
-// specService updates Bitcoin node service spec
-func (r *NodeReconciler) specService(node *stacksv1alpha1.Node, svc *corev1.Service) {
-	labels := node.Labels
-
-	svc.ObjectMeta.Labels = labels
-
-	svc.Spec.Ports = []corev1.ServicePort{
+	ports := []corev1.ServicePort{
 		{
 			Name:       "p2p",
 			Port:       int32(node.Spec.P2PPort),
@@ -204,117 +127,49 @@ func (r *NodeReconciler) specService(node *stacksv1alpha1.Node, svc *corev1.Serv
 		},
 	}
 
-	svc.Spec.Selector = labels
-}
+	var metrics *shared.MetricsEndpoint
 
-// reconcileStatefulset reconciles node statefulset
-func (r *NodeReconciler) reconcileStatefulset(ctx context.Context, node *stacksv1alpha1.Node) error {
-	sts := &appsv1.StatefulSet{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	client := stacksClients.NewClient(node)
-
-	img := client.Image()
-	homeDir := client.HomeDir()
-	cmd := client.Command()
-	args := client.Args()
-	env := client.Env()
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, sts, func() error {
-		if err := ctrl.SetControllerReference(node, sts, r.Scheme); err != nil {
-			return err
-		}
-		if err := r.specStatefulSet(node, sts, img, homeDir, env, cmd, args); err != nil {
-			return err
+	if node.Spec.Metrics.Enabled {
+		ports = append(ports, corev1.ServicePort{
+			Name:       "metrics",
+			Port:       int32(node.Spec.Metrics.Port),
+			TargetPort: intstr.FromInt(int(node.Spec.Metrics.Port)),
+			Protocol:   corev1.ProtocolTCP,
+		})
+		metrics = &shared.MetricsEndpoint{
+			Port: "metrics",
+			Path: client.MetricsPath(),
 		}
-		return nil
-	})
-
-	return err
-}
-
-// specStatefulSet updates node statefulset spec
-func (r *NodeReconciler) specStatefulSet(node *stacksv1alpha1.Node, sts *appsv1.StatefulSet, img, homeDir string, env []corev1.EnvVar, cmd, args []string) error {
-
-	sts.ObjectMeta.Labels = node.Labels
+	}
 
-	sts.Spec = appsv1.StatefulSetSpec{
-		Selector: &metav1.LabelSelector{
-			MatchLabels: node.Labels,
+	return &shared.NodeDescriptor{
+		Client:        client,
+		Resources:     &node.Spec.Resources,
+		Scheduling:    &node.Spec.Scheduling,
+		ExtraConfig:   &node.Spec.ExtraConfig,
+		Disruption:    node.Spec.Disruption,
+		NetworkPolicy: node.Spec.NetworkPolicy,
+		Critical:      node.Spec.Miner,
+		Probes:        node.Spec.Probes,
+		Ports:         ports,
+		Expose:        node.Spec.Expose,
+		P2PPorts:      []string{"p2p"},
+		ConfigFiles: map[string]string{
+			"config.toml": configToml,
 		},
-		ServiceName: node.Name,
-		Template: corev1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: node.Labels,
-			},
-			Spec: corev1.PodSpec{
-				SecurityContext: shared.SecurityContext(),
-				Containers: []corev1.Container{
-					{
-						Name:    "node",
-						Image:   img,
-						Command: cmd,
-						Args:    args,
-						Env:     env,
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Spec.CPU),
-								corev1.ResourceMemory: resource.MustParse(node.Spec.Memory),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Spec.CPULimit),
-								corev1.ResourceMemory: resource.MustParse(node.Spec.MemoryLimit),
-							},
-						},
-						VolumeMounts: []corev1.VolumeMount{
-							{
-								Name:      "data",
-								MountPath: shared.PathData(homeDir),
-							},
-							{
-								Name:      "config",
-								ReadOnly:  true,
-								MountPath: shared.PathConfig(homeDir),
-							},
-						},
-					},
-				},
-				Volumes: []corev1.Volume{
-					{
-						Name: "config",
-						VolumeSource: corev1.VolumeSource{
-							ConfigMap: &corev1.ConfigMapVolumeSource{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: node.Name,
-								},
-							},
-						},
-					},
-					{
-						Name: "data",
-						VolumeSource: corev1.VolumeSource{
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-								ClaimName: node.Name,
-							},
-						},
-					},
-				},
-			},
+		Metrics: metrics,
+		Ingress: node.Spec.Ingress,
+		IngressEndpoints: []shared.IngressEndpoint{
+			{Name: "rpc", Port: node.Spec.RPCPort},
 		},
-	}
-
-	return nil
+	}, nil
 }
 
 func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&stacksv1alpha1.Node{}).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&corev1.Service{}).
-		Complete(r)
+	b, err := shared.NodeControllerManagedBy(mgr, &stacksv1alpha1.Node{})
+	if err != nil {
+		return err
+	}
+
+	return b.Complete(r)
 }
@@ -0,0 +1,180 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron schedule
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar are true if day of month or day of week is *
+	domStar, dowStar bool
+}
+
+// cronField is cron schedule field bounds
+type cronField struct {
+	name     string
+	min, max uint
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// cronMacros are predefined cron schedules
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses standard cron schedule "minute hour day-of-month month day-of-week"
+// fields support *, lists, ranges and steps
+func Parse(spec string) (*Schedule, error) {
+	if macro, ok := cronMacros[spec]; ok {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("expected %d fields, found %d", len(cronFields), len(fields))
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		b, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		bits[i] = b
+	}
+
+	// sunday is either 0 or 7
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	return &Schedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField parses comma separated cron field into bit set
+func parseCronField(field string, bounds cronField) (bits uint64, err error) {
+	for _, expr := range strings.Split(field, ",") {
+		start, end, step := bounds.min, bounds.max, uint(1)
+
+		rangeExpr := expr
+		if i := strings.Index(expr, "/"); i != -1 {
+			rangeExpr = expr[:i]
+			if step, err = parseCronNumber(expr[i+1:], bounds.name); err != nil {
+				return
+			}
+			if step == 0 {
+				return 0, fmt.Errorf("%s step must be greater than 0", bounds.name)
+			}
+		}
+
+		if rangeExpr != "*" {
+			if i := strings.Index(rangeExpr, "-"); i != -1 {
+				if start, err = parseCronNumber(rangeExpr[:i], bounds.name); err != nil {
+					return
+				}
+				if end, err = parseCronNumber(rangeExpr[i+1:], bounds.name); err != nil {
+					return
+				}
+			} else {
+				if start, err = parseCronNumber(rangeExpr, bounds.name); err != nil {
+					return
+				}
+				end = start
+				// a/n means from a to max every n
+				if step != 1 {
+					end = bounds.max
+				}
+			}
+		}
+
+		if start < bounds.min || end > bounds.max || start > end {
+			return 0, fmt.Errorf("%s %s is out of range %d-%d", bounds.name, expr, bounds.min, bounds.max)
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << v
+		}
+	}
+
+	return
+}
+
+// parseCronNumber parses cron field number
+func parseCronNumber(s, name string) (uint, error) {
+	n, err := strconv.ParseUint(s, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", name, s)
+	}
+	return uint(n), nil
+}
+
+// dayMatches returns true if t matches schedule day of month and day of week
+// if both fields are restricted, it's enough for one of them to match
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+// Next returns the next schedule activation time after t
+// it returns zero time if no activation time is found within 5 years
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + 5
+
+	for t.Year() <= limit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron schedule", func() {
+
+	invalid := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	}
+
+	for _, spec := range invalid {
+		func() {
+			s := spec
+			It(fmt.Sprintf("Should reject invalid schedule %q", s), func() {
+				_, err := Parse(s)
+				Expect(err).To(HaveOccurred())
+			})
+		}()
+	}
+
+	// Friday
+	from := time.Date(2022, time.April, 15, 10, 30, 45, 0, time.UTC)
+
+	cases := []struct {
+		spec string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2022, time.April, 15, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2022, time.April, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 */6 * * *", time.Date(2022, time.April, 15, 12, 0, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2022, time.April, 16, 2, 30, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2022, time.April, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2022, time.April, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 1-5", time.Date(2022, time.April, 18, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2022, time.May, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2022, time.May, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2022, time.April, 16, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2022, time.May, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, c := range cases {
+		func() {
+			cc := c
+			It(fmt.Sprintf("Should get next activation of %q", cc.spec), func() {
+				schedule, err := Parse(cc.spec)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(schedule.Next(from)).To(BeTemporally("==", cc.next))
+			})
+		}()
+	}
+
+})
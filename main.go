@@ -3,31 +3,36 @@ package main
 import (
 	"flag"
 	"os"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	backupv1alpha1 "github.com/kotalco/kotal/apis/backup/v1alpha1"
 	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	bitcoinv1beta1 "github.com/kotalco/kotal/apis/bitcoin/v1beta1"
 	chainlinkv1alpha1 "github.com/kotalco/kotal/apis/chainlink/v1alpha1"
+	chainlinkv1beta1 "github.com/kotalco/kotal/apis/chainlink/v1beta1"
 	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
 	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+	ethereum2v1beta1 "github.com/kotalco/kotal/apis/ethereum2/v1beta1"
 	filecoinv1alpha1 "github.com/kotalco/kotal/apis/filecoin/v1alpha1"
+	filecoinv1beta1 "github.com/kotalco/kotal/apis/filecoin/v1beta1"
 	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	ipfsv1beta1 "github.com/kotalco/kotal/apis/ipfs/v1beta1"
 	nearv1alpha1 "github.com/kotalco/kotal/apis/near/v1alpha1"
+	nearv1beta1 "github.com/kotalco/kotal/apis/near/v1beta1"
 	polkadotv1alpha1 "github.com/kotalco/kotal/apis/polkadot/v1alpha1"
+	polkadotv1beta1 "github.com/kotalco/kotal/apis/polkadot/v1beta1"
 	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
-	bitcoincontroller "github.com/kotalco/kotal/controllers/bitcoin"
-	chainlinkcontroller "github.com/kotalco/kotal/controllers/chainlink"
-	ethereumcontroller "github.com/kotalco/kotal/controllers/ethereum"
-	ethereum2controller "github.com/kotalco/kotal/controllers/ethereum2"
-	filecoincontroller "github.com/kotalco/kotal/controllers/filecoin"
-	ipfscontroller "github.com/kotalco/kotal/controllers/ipfs"
-	nearcontroller "github.com/kotalco/kotal/controllers/near"
-	polkadotcontroller "github.com/kotalco/kotal/controllers/polkadot"
-	stackscontroller "github.com/kotalco/kotal/controllers/stacks"
+	stacksv1beta1 "github.com/kotalco/kotal/apis/stacks/v1beta1"
+	"github.com/kotalco/kotal/controllers/shared"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -41,191 +46,97 @@ func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
 
 	_ = ethereumv1alpha1.AddToScheme(scheme)
+	_ = ethereumv1beta1.AddToScheme(scheme)
 	_ = ethereum2v1alpha1.AddToScheme(scheme)
+	_ = ethereum2v1beta1.AddToScheme(scheme)
 	_ = ipfsv1alpha1.AddToScheme(scheme)
+	_ = ipfsv1beta1.AddToScheme(scheme)
 	_ = filecoinv1alpha1.AddToScheme(scheme)
+	_ = filecoinv1beta1.AddToScheme(scheme)
 	_ = polkadotv1alpha1.AddToScheme(scheme)
+	_ = polkadotv1beta1.AddToScheme(scheme)
 	_ = chainlinkv1alpha1.AddToScheme(scheme)
+	_ = chainlinkv1beta1.AddToScheme(scheme)
 	_ = nearv1alpha1.AddToScheme(scheme)
+	_ = nearv1beta1.AddToScheme(scheme)
 	_ = bitcoinv1alpha1.AddToScheme(scheme)
+	_ = bitcoinv1beta1.AddToScheme(scheme)
 	_ = stacksv1alpha1.AddToScheme(scheme)
+	_ = stacksv1beta1.AddToScheme(scheme)
+	_ = backupv1alpha1.AddToScheme(scheme)
 	// +kubebuilder:scaffold:scheme
 }
 
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var protocolsFlag string
+	var namespacesFlag string
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&protocolsFlag, "protocols", strings.Join(allProtocols(), ","),
+		"Comma separated protocols whose controllers are run by the manager, webhooks of all protocols are always run.")
+	flag.StringVar(&namespacesFlag, "namespaces", "",
+		"Comma separated namespaces watched by the manager, all namespaces are watched if empty.")
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	enabledProtocols, err := parseProtocols(protocolsFlag)
+	if err != nil {
+		setupLog.Error(err, "invalid protocols")
+		os.Exit(1)
+	}
+
+	options := ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr,
 		Port:               9443,
 		LeaderElection:     enableLeaderElection,
 		LeaderElectionID:   "2b1fce2f.kotal.io",
-	})
-	if err != nil {
-		setupLog.Error(err, "unable to start manager")
-		os.Exit(1)
-	}
-
-	if err = (&filecoincontroller.NodeReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Node")
-		os.Exit(1)
-	}
-	if enableWebhooks {
-		if err = (&filecoinv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
-			os.Exit(1)
-		}
-	}
-
-	if err = (&ethereumcontroller.NodeReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Node")
-		os.Exit(1)
-	}
-	if enableWebhooks {
-		if err = (&ethereumv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
-			os.Exit(1)
-		}
-	}
-
-	if err = (&ethereum2controller.BeaconNodeReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "BeaconNode")
-		os.Exit(1)
-	}
-	if enableWebhooks {
-		if err = (&ethereum2v1alpha1.BeaconNode{}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
-			os.Exit(1)
-		}
-	}
-
-	if err = (&ethereum2controller.ValidatorReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Validator")
-		os.Exit(1)
-	}
-	if enableWebhooks {
-		if err = (&ethereum2v1alpha1.Validator{}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, "unable to create webhook", "webhook", "Validator")
-			os.Exit(1)
-		}
 	}
 
-	if err = (&ipfscontroller.PeerReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Peer")
-		os.Exit(1)
-	}
-	if enableWebhooks {
-		if err = (&ipfsv1alpha1.Peer{}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, "unable to create webhook", "webhook", "Peer")
-			os.Exit(1)
-		}
-	}
-
-	if err = (&ipfscontroller.ClusterPeerReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ClusterPeer")
-		os.Exit(1)
-	}
-	if enableWebhooks {
-		if err = (&ipfsv1alpha1.ClusterPeer{}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterPeer")
-			os.Exit(1)
-		}
+	// limit the cache to the given namespaces so the manager can run with namespaced RBAC
+	namespaces := parseNamespaces(namespacesFlag)
+	if len(namespaces) == 1 {
+		options.Namespace = namespaces[0]
+	} else if len(namespaces) > 1 {
+		options.NewCache = cache.MultiNamespacedCacheBuilder(namespaces)
 	}
 
-	if err = (&polkadotcontroller.NodeReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Node")
-		os.Exit(1)
-	}
-	if enableWebhooks {
-		if err = (&polkadotv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
-			os.Exit(1)
-		}
-	}
+	setupLog.Info("setting up manager", "protocols", enabledProtocols, "namespaces", namespaces)
 
-	if err = (&chainlinkcontroller.NodeReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Node")
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
+	if err != nil {
+		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
-	if enableWebhooks {
-		if err = (&chainlinkv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
-			os.Exit(1)
-		}
-	}
 
-	if err = (&nearcontroller.NodeReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Node")
+	// node data volume usage is read from kubelet to expand storage automatically
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create kubernetes clientset")
 		os.Exit(1)
 	}
-	if enableWebhooks {
-		if err = (&nearv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
-			os.Exit(1)
-		}
-	}
+	shared.SetVolumeStatsReader(&shared.KubeletVolumeStats{Client: clientset.CoreV1().RESTClient()})
 
-	if err = (&bitcoincontroller.NodeReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Node")
-		os.Exit(1)
-	}
-	if enableWebhooks {
-		if err = (&bitcoinv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
+	for _, protocol := range enabledProtocols {
+		if err = protocols[protocol].controllers(mgr); err != nil {
+			setupLog.Error(err, "unable to setup protocol controllers", "protocol", protocol)
 			os.Exit(1)
 		}
 	}
 
-	if err = (&stackscontroller.NodeReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Node")
-		os.Exit(1)
-	}
+	// webhooks of all protocols are registered because webhook configurations are installed for all of them
+	// webhooks of disabled protocols would otherwise reject their resources
 	if enableWebhooks {
-		if err = (&stacksv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
-			os.Exit(1)
+		for _, protocol := range allProtocols() {
+			if err = protocols[protocol].webhooks(mgr); err != nil {
+				setupLog.Error(err, "unable to setup protocol webhooks", "protocol", protocol)
+				os.Exit(1)
+			}
 		}
 	}
 	// +kubebuilder:scaffold:builder
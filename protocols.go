@@ -0,0 +1,388 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	backupv1alpha1 "github.com/kotalco/kotal/apis/backup/v1alpha1"
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	bitcoinv1beta1 "github.com/kotalco/kotal/apis/bitcoin/v1beta1"
+	chainlinkv1alpha1 "github.com/kotalco/kotal/apis/chainlink/v1alpha1"
+	chainlinkv1beta1 "github.com/kotalco/kotal/apis/chainlink/v1beta1"
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+	ethereum2v1beta1 "github.com/kotalco/kotal/apis/ethereum2/v1beta1"
+	filecoinv1alpha1 "github.com/kotalco/kotal/apis/filecoin/v1alpha1"
+	filecoinv1beta1 "github.com/kotalco/kotal/apis/filecoin/v1beta1"
+	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	ipfsv1beta1 "github.com/kotalco/kotal/apis/ipfs/v1beta1"
+	nearv1alpha1 "github.com/kotalco/kotal/apis/near/v1alpha1"
+	nearv1beta1 "github.com/kotalco/kotal/apis/near/v1beta1"
+	polkadotv1alpha1 "github.com/kotalco/kotal/apis/polkadot/v1alpha1"
+	polkadotv1beta1 "github.com/kotalco/kotal/apis/polkadot/v1beta1"
+	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+	stacksv1beta1 "github.com/kotalco/kotal/apis/stacks/v1beta1"
+	backupcontroller "github.com/kotalco/kotal/controllers/backup"
+	bitcoincontroller "github.com/kotalco/kotal/controllers/bitcoin"
+	chainlinkcontroller "github.com/kotalco/kotal/controllers/chainlink"
+	ethereumcontroller "github.com/kotalco/kotal/controllers/ethereum"
+	ethereum2controller "github.com/kotalco/kotal/controllers/ethereum2"
+	filecoincontroller "github.com/kotalco/kotal/controllers/filecoin"
+	ipfscontroller "github.com/kotalco/kotal/controllers/ipfs"
+	nearcontroller "github.com/kotalco/kotal/controllers/near"
+	polkadotcontroller "github.com/kotalco/kotal/controllers/polkadot"
+	stackscontroller "github.com/kotalco/kotal/controllers/stacks"
+)
+
+// protocol is controllers and webhooks setup of a protocol
+type protocol struct {
+	// controllers sets up protocol controllers
+	controllers func(mgr ctrl.Manager) error
+	// webhooks sets up protocol conversion, defaulting and validation webhooks
+	webhooks func(mgr ctrl.Manager) error
+}
+
+// protocols is the controllers and webhooks setup of every protocol the operator can run
+var protocols = map[string]protocol{
+	"backup":    {setupBackupControllers, setupBackupWebhooks},
+	"bitcoin":   {setupBitcoinControllers, setupBitcoinWebhooks},
+	"chainlink": {setupChainlinkControllers, setupChainlinkWebhooks},
+	"ethereum":  {setupEthereumControllers, setupEthereumWebhooks},
+	"ethereum2": {setupEthereum2Controllers, setupEthereum2Webhooks},
+	"filecoin":  {setupFilecoinControllers, setupFilecoinWebhooks},
+	"ipfs":      {setupIPFSControllers, setupIPFSWebhooks},
+	"near":      {setupNearControllers, setupNearWebhooks},
+	"polkadot":  {setupPolkadotControllers, setupPolkadotWebhooks},
+	"stacks":    {setupStacksControllers, setupStacksWebhooks},
+}
+
+// allProtocols returns sorted names of all protocols
+func allProtocols() []string {
+	names := []string{}
+	for name := range protocols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseProtocols parses comma separated protocols, all protocols are returned if value is empty
+func parseProtocols(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return allProtocols(), nil
+	}
+
+	names := []string{}
+	seen := map[string]bool{}
+
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		if _, ok := protocols[name]; !ok {
+			return nil, fmt.Errorf("unsupported protocol %s, supported protocols are %s", name, strings.Join(allProtocols(), ","))
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// parseNamespaces parses comma separated namespaces, empty list means all namespaces
+func parseNamespaces(value string) (namespaces []string) {
+	for _, namespace := range strings.Split(value, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return
+}
+
+// setupBitcoinControllers sets up Bitcoin node controllers
+func setupBitcoinControllers(mgr ctrl.Manager) error {
+	if err := (&bitcoincontroller.NodeReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("bitcoin-node-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node controller: %w", err)
+	}
+
+	return nil
+}
+
+// setupBitcoinWebhooks sets up Bitcoin node webhooks
+func setupBitcoinWebhooks(mgr ctrl.Manager) error {
+	if err := (&bitcoinv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node v1alpha1 webhook: %w", err)
+	}
+	if err := (&bitcoinv1beta1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node v1beta1 webhook: %w", err)
+	}
+
+	return nil
+}
+
+// setupChainlinkControllers sets up Chainlink node controllers
+func setupChainlinkControllers(mgr ctrl.Manager) error {
+	if err := (&chainlinkcontroller.NodeReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("chainlink-node-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node controller: %w", err)
+	}
+
+	return nil
+}
+
+// setupChainlinkWebhooks sets up Chainlink node webhooks
+func setupChainlinkWebhooks(mgr ctrl.Manager) error {
+	if err := (&chainlinkv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node v1alpha1 webhook: %w", err)
+	}
+	if err := (&chainlinkv1beta1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node v1beta1 webhook: %w", err)
+	}
+
+	return nil
+}
+
+// setupEthereumControllers sets up Ethereum node controllers
+func setupEthereumControllers(mgr ctrl.Manager) error {
+	if err := (&ethereumcontroller.NodeReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("ethereum-node-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node controller: %w", err)
+	}
+
+	return nil
+}
+
+// setupEthereumWebhooks sets up Ethereum node webhooks
+func setupEthereumWebhooks(mgr ctrl.Manager) error {
+	if err := (&ethereumv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node v1alpha1 webhook: %w", err)
+	}
+	if err := (&ethereumv1beta1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node v1beta1 webhook: %w", err)
+	}
+
+	return nil
+}
+
+// setupEthereum2Controllers sets up Ethereum 2.0 beacon node and validator controllers
+func setupEthereum2Controllers(mgr ctrl.Manager) error {
+	if err := (&ethereum2controller.BeaconNodeReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("ethereum2-beacon-node-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create BeaconNode controller: %w", err)
+	}
+	if err := (&ethereum2controller.ValidatorReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("ethereum2-validator-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Validator controller: %w", err)
+	}
+
+	return nil
+}
+
+// setupEthereum2Webhooks sets up Ethereum 2.0 beacon node and validator webhooks
+func setupEthereum2Webhooks(mgr ctrl.Manager) error {
+	if err := (&ethereum2v1alpha1.BeaconNode{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create BeaconNode v1alpha1 webhook: %w", err)
+	}
+	if err := (&ethereum2v1beta1.BeaconNode{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create BeaconNode v1beta1 webhook: %w", err)
+	}
+	if err := (&ethereum2v1alpha1.Validator{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Validator v1alpha1 webhook: %w", err)
+	}
+	if err := (&ethereum2v1beta1.Validator{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Validator v1beta1 webhook: %w", err)
+	}
+
+	return nil
+}
+
+// setupFilecoinControllers sets up Filecoin node controllers
+func setupFilecoinControllers(mgr ctrl.Manager) error {
+	if err := (&filecoincontroller.NodeReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("filecoin-node-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node controller: %w", err)
+	}
+
+	return nil
+}
+
+// setupFilecoinWebhooks sets up Filecoin node webhooks
+func setupFilecoinWebhooks(mgr ctrl.Manager) error {
+	if err := (&filecoinv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node v1alpha1 webhook: %w", err)
+	}
+	if err := (&filecoinv1beta1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node v1beta1 webhook: %w", err)
+	}
+
+	return nil
+}
+
+// setupIPFSControllers sets up IPFS peer and cluster peer controllers
+func setupIPFSControllers(mgr ctrl.Manager) error {
+	if err := (&ipfscontroller.PeerReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("ipfs-peer-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Peer controller: %w", err)
+	}
+	if err := (&ipfscontroller.ClusterPeerReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("ipfs-cluster-peer-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create ClusterPeer controller: %w", err)
+	}
+
+	return nil
+}
+
+// setupIPFSWebhooks sets up IPFS peer and cluster peer webhooks
+func setupIPFSWebhooks(mgr ctrl.Manager) error {
+	if err := (&ipfsv1alpha1.Peer{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Peer v1alpha1 webhook: %w", err)
+	}
+	if err := (&ipfsv1beta1.Peer{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Peer v1beta1 webhook: %w", err)
+	}
+	if err := (&ipfsv1alpha1.ClusterPeer{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create ClusterPeer v1alpha1 webhook: %w", err)
+	}
+	if err := (&ipfsv1beta1.ClusterPeer{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create ClusterPeer v1beta1 webhook: %w", err)
+	}
+
+	return nil
+}
+
+// setupNearControllers sets up NEAR node controllers
+func setupNearControllers(mgr ctrl.Manager) error {
+	if err := (&nearcontroller.NodeReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("near-node-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node controller: %w", err)
+	}
+
+	return nil
+}
+
+// setupNearWebhooks sets up NEAR node webhooks
+func setupNearWebhooks(mgr ctrl.Manager) error {
+	if err := (&nearv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node v1alpha1 webhook: %w", err)
+	}
+	if err := (&nearv1beta1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node v1beta1 webhook: %w", err)
+	}
+
+	return nil
+}
+
+// setupPolkadotControllers sets up Polkadot node controllers
+func setupPolkadotControllers(mgr ctrl.Manager) error {
+	if err := (&polkadotcontroller.NodeReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("polkadot-node-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node controller: %w", err)
+	}
+
+	return nil
+}
+
+// setupPolkadotWebhooks sets up Polkadot node webhooks
+func setupPolkadotWebhooks(mgr ctrl.Manager) error {
+	if err := (&polkadotv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node v1alpha1 webhook: %w", err)
+	}
+	if err := (&polkadotv1beta1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node v1beta1 webhook: %w", err)
+	}
+
+	return nil
+}
+
+// setupStacksControllers sets up Stacks node controllers
+func setupStacksControllers(mgr ctrl.Manager) error {
+	if err := (&stackscontroller.NodeReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("stacks-node-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node controller: %w", err)
+	}
+
+	return nil
+}
+
+// setupStacksWebhooks sets up Stacks node webhooks
+func setupStacksWebhooks(mgr ctrl.Manager) error {
+	if err := (&stacksv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node v1alpha1 webhook: %w", err)
+	}
+	if err := (&stacksv1beta1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node v1beta1 webhook: %w", err)
+	}
+
+	return nil
+}
+
+// setupBackupControllers sets up backup and restore controllers
+func setupBackupControllers(mgr ctrl.Manager) error {
+	if err := (&backupcontroller.BackupReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("backup-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Backup controller: %w", err)
+	}
+	if err := (&backupcontroller.RestoreReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("backup-restore-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Restore controller: %w", err)
+	}
+
+	return nil
+}
+
+// setupBackupWebhooks sets up backup and restore webhooks
+func setupBackupWebhooks(mgr ctrl.Manager) error {
+	if err := (&backupv1alpha1.Backup{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Backup v1alpha1 webhook: %w", err)
+	}
+	if err := (&backupv1alpha1.Restore{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Restore v1alpha1 webhook: %w", err)
+	}
+
+	return nil
+}
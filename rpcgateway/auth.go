@@ -0,0 +1,199 @@
+package rpcgateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// HeaderAPIKey is the header used by clients to send API key
+	HeaderAPIKey = "X-API-Key"
+	// HeaderAuthorization is the header used by clients to send JWT bearer token
+	HeaderAuthorization = "Authorization"
+)
+
+var (
+	// ErrMissingCredentials is returned if request has no API key or bearer token
+	ErrMissingCredentials = errors.New("missing credentials")
+	// ErrInvalidCredentials is returned if API key is unknown or token can't be verified
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrExpiredToken is returned if JWT is expired or not valid yet
+	ErrExpiredToken = errors.New("expired token")
+)
+
+// Authenticator authenticates request and returns client identity used for rate limiting
+type Authenticator interface {
+	Authenticate(r *http.Request) (identity string, err error)
+}
+
+// APIKeyAuthenticator authenticates clients by API key sent in X-API-Key header
+type APIKeyAuthenticator struct {
+	keys [][]byte
+}
+
+// NewAPIKeyAuthenticator creates API key authenticator from newline separated keys
+// empty lines and lines starting with # are ignored
+func NewAPIKeyAuthenticator(keys string) *APIKeyAuthenticator {
+	a := &APIKeyAuthenticator{}
+	for _, line := range strings.Split(keys, "\n") {
+		key := strings.TrimSpace(line)
+		if key == "" || strings.HasPrefix(key, "#") {
+			continue
+		}
+		a.keys = append(a.keys, []byte(key))
+	}
+	return a
+}
+
+// Authenticate authenticates request by API key, the key itself is the client identity
+func (a *APIKeyAuthenticator) Authenticate(r *http.Request) (string, error) {
+	key := r.Header.Get(HeaderAPIKey)
+	if key == "" {
+		return "", ErrMissingCredentials
+	}
+
+	for _, k := range a.keys {
+		if subtle.ConstantTimeCompare(k, []byte(key)) == 1 {
+			// API key shouldn't be forwarded to the node
+			r.Header.Del(HeaderAPIKey)
+			return key, nil
+		}
+	}
+
+	return "", ErrInvalidCredentials
+}
+
+// JWTAuthenticator authenticates clients by HS256 JWT sent as Authorization bearer token
+type JWTAuthenticator struct {
+	secret []byte
+	now    func() time.Time
+}
+
+// NewJWTAuthenticator creates JWT authenticator verifying tokens using HMAC secret
+func NewJWTAuthenticator(secret string) *JWTAuthenticator {
+	return &JWTAuthenticator{
+		secret: []byte(strings.TrimSpace(secret)),
+		now:    time.Now,
+	}
+}
+
+// claims is JWT registered claims used by the gateway
+type claims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+	NotBefore int64  `json:"nbf"`
+}
+
+// Authenticate authenticates request by JWT, token subject is the client identity
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (string, error) {
+	authorization := r.Header.Get(HeaderAuthorization)
+	token := strings.TrimPrefix(authorization, "Bearer ")
+	if authorization == "" || token == authorization {
+		return "", ErrMissingCredentials
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", ErrInvalidCredentials
+	}
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	var h struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(header, &h); err != nil || h.Alg != "HS256" {
+		return "", ErrInvalidCredentials
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return "", ErrInvalidCredentials
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	now := a.now().Unix()
+	if (c.ExpiresAt != 0 && now >= c.ExpiresAt) || (c.NotBefore != 0 && now < c.NotBefore) {
+		return "", ErrExpiredToken
+	}
+
+	// bearer token shouldn't be forwarded to the node
+	r.Header.Del(HeaderAuthorization)
+
+	if c.Subject != "" {
+		return c.Subject, nil
+	}
+
+	return token, nil
+}
+
+// FileAuthenticator authenticates clients using credentials file that can change at runtime
+// like k8s secret mounted as a volume
+type FileAuthenticator struct {
+	path  string
+	build func(credentials string) Authenticator
+
+	mu            sync.RWMutex
+	authenticator Authenticator
+}
+
+// NewFileAuthenticator creates authenticator from credentials file
+func NewFileAuthenticator(path string, build func(credentials string) Authenticator) (*FileAuthenticator, error) {
+	a := &FileAuthenticator{path: path, build: build}
+	if err := a.Reload(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Reload reads credentials file again
+func (a *FileAuthenticator) Reload() error {
+	credentials, err := os.ReadFile(a.path)
+	if err != nil {
+		return err
+	}
+
+	authenticator := a.build(string(credentials))
+
+	a.mu.Lock()
+	a.authenticator = authenticator
+	a.mu.Unlock()
+
+	return nil
+}
+
+// Authenticate authenticates request using current credentials
+func (a *FileAuthenticator) Authenticate(r *http.Request) (string, error) {
+	a.mu.RLock()
+	authenticator := a.authenticator
+	a.mu.RUnlock()
+
+	return authenticator.Authenticate(r)
+}
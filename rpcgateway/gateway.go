@@ -0,0 +1,147 @@
+// Package rpcgateway is authenticating and rate-limiting JSON-RPC reverse proxy
+// deployed as a sidecar in front of node RPC port
+package rpcgateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+)
+
+// maxBodySize is the maximum JSON-RPC request body size
+const maxBodySize = 5 * 1024 * 1024
+
+const (
+	// codeInvalidRequest is JSON-RPC invalid request error code
+	codeInvalidRequest = -32600
+	// codeMethodNotAllowed is JSON-RPC server error code used for denied methods
+	codeMethodNotAllowed = -32001
+	// codeUnauthorized is JSON-RPC server error code used for failed authentication
+	codeUnauthorized = -32002
+	// codeRateLimited is JSON-RPC server error code used for rate limited clients
+	codeRateLimited = -32003
+)
+
+// Gateway authenticates, rate-limits and filters JSON-RPC requests before proxying them to the node
+type Gateway struct {
+	// Authenticator authenticates clients
+	Authenticator Authenticator
+	// RateLimiter limits requests per client, requests aren't limited if nil
+	RateLimiter *RateLimiter
+	// Filter allows or denies JSON-RPC methods
+	Filter MethodFilter
+
+	proxy *httputil.ReverseProxy
+	// wsUpstream is node websocket server, websocket connections are rejected if nil
+	wsUpstream *url.URL
+}
+
+// NewGateway creates JSON-RPC gateway proxying requests to upstream node RPC server
+// and websocket connections to wsUpstream node websocket server if provided
+func NewGateway(upstream, wsUpstream *url.URL, authenticator Authenticator, limiter *RateLimiter, filter MethodFilter) *Gateway {
+	return &Gateway{
+		Authenticator: authenticator,
+		RateLimiter:   limiter,
+		Filter:        filter,
+		proxy:         httputil.NewSingleHostReverseProxy(upstream),
+		wsUpstream:    wsUpstream,
+	}
+}
+
+// rpcError is JSON-RPC error response
+type rpcError struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// newError creates JSON-RPC error response
+func newError(id json.RawMessage, code int, message string) rpcError {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	resp := rpcError{JSONRPC: "2.0", ID: id}
+	resp.Error.Code = code
+	resp.Error.Message = message
+	return resp
+}
+
+// writeError writes JSON-RPC error response with the given http status code
+func writeError(w http.ResponseWriter, status int, id json.RawMessage, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(newError(id, code, message))
+}
+
+// denied returns the first request method that isn't allowed and its request id
+// request id is omitted for batch requests
+func (g *Gateway) denied(requests []Request, batch bool) (id json.RawMessage, method string, denied bool) {
+	for _, request := range requests {
+		if !g.Filter.Allow(request.Method) {
+			if !batch {
+				id = request.ID
+			}
+			return id, request.Method, true
+		}
+	}
+	return
+}
+
+// ServeHTTP implements http.Handler
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	identity, err := g.Authenticator.Authenticate(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, nil, codeUnauthorized, err.Error())
+		return
+	}
+
+	// websocket messages are rate limited and filtered after connection
+	if isWebsocket(r) {
+		if g.RateLimiter != nil && !g.RateLimiter.Allow(identity) {
+			writeError(w, http.StatusTooManyRequests, nil, codeRateLimited, "rate limit exceeded")
+			return
+		}
+		g.proxyWebsocket(w, r, identity)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, nil, codeInvalidRequest, "only POST requests are supported")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil || len(body) > maxBodySize {
+		writeError(w, http.StatusRequestEntityTooLarge, nil, codeInvalidRequest, "request body is too large")
+		return
+	}
+
+	requests, batch, err := ParseRequests(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, nil, codeInvalidRequest, err.Error())
+		return
+	}
+
+	// every call in a batch is rate limited like a single request
+	if g.RateLimiter != nil && !g.RateLimiter.AllowN(identity, len(requests)) {
+		writeError(w, http.StatusTooManyRequests, nil, codeRateLimited, "rate limit exceeded")
+		return
+	}
+
+	if id, method, denied := g.denied(requests, batch); denied {
+		writeError(w, http.StatusForbidden, id, codeMethodNotAllowed, fmt.Sprintf("method %s is not allowed", method))
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+
+	g.proxy.ServeHTTP(w, r)
+}
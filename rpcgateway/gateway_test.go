@@ -0,0 +1,354 @@
+package rpcgateway
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// signJWT returns HS256 JWT of the given payload
+func signJWT(secret, payload string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	body := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(header + "." + body))
+	return header + "." + body + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+var _ = Describe("Authenticators", func() {
+
+	It("Should authenticate API keys", func() {
+		authenticator := NewAPIKeyAuthenticator("# team keys\nkey-1\n\n  key-2  \n")
+
+		tests := []struct {
+			key      string
+			identity string
+			err      error
+		}{
+			{"", "", ErrMissingCredentials},
+			{"key-3", "", ErrInvalidCredentials},
+			{"key-2", "key-2", nil},
+		}
+
+		for _, test := range tests {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			if test.key != "" {
+				r.Header.Set(HeaderAPIKey, test.key)
+			}
+			identity, err := authenticator.Authenticate(r)
+			Expect(identity).To(Equal(test.identity), "key %q", test.key)
+			if test.err == nil {
+				Expect(err).NotTo(HaveOccurred(), "key %q", test.key)
+			} else {
+				Expect(err).To(Equal(test.err), "key %q", test.key)
+			}
+			if err == nil {
+				// API key isn't proxied to the node
+				Expect(r.Header.Get(HeaderAPIKey)).To(BeEmpty())
+			}
+		}
+	})
+
+	It("Should authenticate JWTs", func() {
+		authenticator := NewJWTAuthenticator("secret\n")
+		authenticator.now = func() time.Time { return time.Unix(1000, 0) }
+
+		tests := []struct {
+			token    string
+			identity string
+			err      error
+		}{
+			{"", "", ErrMissingCredentials},
+			{"not.a.jwt", "", ErrInvalidCredentials},
+			{signJWT("another-secret", `{"sub":"alice"}`), "", ErrInvalidCredentials},
+			{signJWT("secret", `{"sub":"alice","exp":999}`), "", ErrExpiredToken},
+			{signJWT("secret", `{"sub":"alice","nbf":1001}`), "", ErrExpiredToken},
+			{signJWT("secret", `{"sub":"alice","exp":1001}`), "alice", nil},
+		}
+
+		for _, test := range tests {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			if test.token != "" {
+				r.Header.Set(HeaderAuthorization, "Bearer "+test.token)
+			}
+			identity, err := authenticator.Authenticate(r)
+			Expect(identity).To(Equal(test.identity), "token %q", test.token)
+			if test.err == nil {
+				Expect(err).NotTo(HaveOccurred(), "token %q", test.token)
+			} else {
+				Expect(err).To(Equal(test.err), "token %q", test.token)
+			}
+		}
+	})
+
+	It("Should reload credentials file", func() {
+		dir, err := os.MkdirTemp("", "rpc-gateway")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "api-keys")
+		Expect(os.WriteFile(path, []byte("key-1"), 0600)).To(Succeed())
+
+		authenticator, err := NewFileAuthenticator(path, func(keys string) Authenticator {
+			return NewAPIKeyAuthenticator(keys)
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		authenticate := func(key string) error {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			r.Header.Set(HeaderAPIKey, key)
+			_, err := authenticator.Authenticate(r)
+			return err
+		}
+
+		Expect(authenticate("key-1")).To(Succeed())
+
+		Expect(os.WriteFile(path, []byte("key-2"), 0600)).To(Succeed())
+		Expect(authenticator.Reload()).To(Succeed())
+
+		Expect(authenticate("key-1")).To(Equal(ErrInvalidCredentials))
+	})
+
+})
+
+var _ = Describe("Rate limiter", func() {
+
+	It("Should limit requests per identity", func() {
+		now := time.Unix(0, 0)
+		limiter := NewRateLimiter(1, 2)
+		limiter.now = func() time.Time { return now }
+
+		Expect(limiter.Allow("alice")).To(BeTrue())
+		Expect(limiter.Allow("alice")).To(BeTrue())
+		Expect(limiter.Allow("alice")).To(BeFalse())
+
+		// bob has his own bucket
+		Expect(limiter.Allow("bob")).To(BeTrue())
+
+		// alice bucket is refilled after a second
+		now = now.Add(time.Second)
+		Expect(limiter.Allow("alice")).To(BeTrue())
+	})
+
+})
+
+var _ = Describe("Method filter", func() {
+
+	filter := MethodFilter{
+		Allowed: []string{"eth_*", "net_version", "debug_traceTransaction"},
+		Denied:  []string{"debug_*", "eth_sign"},
+	}
+
+	tests := map[string]bool{
+		"eth_blockNumber":        true,
+		"eth_sign":               false,
+		"net_version":            true,
+		"net_peerCount":          false,
+		"admin_addPeer":          false,
+		"debug_traceTransaction": false,
+	}
+
+	for method, allowed := range tests {
+		func() {
+			m, a := method, allowed
+			It(fmt.Sprintf("Should filter method %s", m), func() {
+				Expect(filter.Allow(m)).To(Equal(a))
+			})
+		}()
+	}
+
+	It("Should allow all methods without allowed and denied methods", func() {
+		Expect((&MethodFilter{}).Allow("admin_addPeer")).To(BeTrue())
+	})
+
+	It("Should parse batch requests", func() {
+		requests, batch, err := ParseRequests([]byte(` [{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"method":"admin_peers"}]`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(batch).To(BeTrue())
+		Expect(requests).To(HaveLen(2))
+		Expect(requests[1].Method).To(Equal("admin_peers"))
+	})
+
+	It("Should reject invalid requests", func() {
+		for _, body := range []string{"", "[]", "not json", `{"id":1}`, `{"method":1}`, `{"method":"eth_chainId"} {}`} {
+			_, _, err := ParseRequests([]byte(body))
+			Expect(err).To(Equal(ErrInvalidRequest), "body %q", body)
+		}
+	})
+
+	// nodes may decode the method of these calls differently from the gateway
+	It("Should reject calls without exactly one method member", func() {
+		calls := []string{
+			`{"jsonrpc":"2.0","id":1,"Method":"admin_peers"}`,
+			`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","METHOD":"admin_peers"}`,
+			`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","method":"admin_peers"}`,
+			`[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"mEthod":"admin_peers"}]`,
+		}
+		for _, call := range calls {
+			_, _, err := ParseRequests([]byte(call))
+			Expect(err).To(Equal(ErrInvalidRequest), "call %s", call)
+		}
+	})
+
+})
+
+var _ = Describe("Gateway", func() {
+
+	It("Should authenticate, rate limit and filter requests", func() {
+		forwardedKeys := []string{}
+		node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			forwardedKeys = append(forwardedKeys, r.Header.Get(HeaderAPIKey))
+			body, _ := io.ReadAll(r.Body)
+			w.Write(body)
+		}))
+		defer node.Close()
+
+		upstream, _ := url.Parse(node.URL)
+		gateway := NewGateway(upstream, nil, NewAPIKeyAuthenticator("key-1"), NewRateLimiter(1, 2), MethodFilter{Denied: []string{"admin_*"}})
+
+		tests := []struct {
+			key    string
+			body   string
+			status int
+		}{
+			{"", `{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`, http.StatusUnauthorized},
+			{"key-1", `{"jsonrpc":"2.0","id":1,"method":"admin_peers"}`, http.StatusForbidden},
+			{"key-1", `{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`, http.StatusOK},
+			{"key-1", `{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`, http.StatusTooManyRequests},
+		}
+
+		for _, test := range tests {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+			if test.key != "" {
+				r.Header.Set(HeaderAPIKey, test.key)
+			}
+			w := httptest.NewRecorder()
+			gateway.ServeHTTP(w, r)
+
+			Expect(w.Code).To(Equal(test.status), "request %s: %s", test.body, w.Body.String())
+			if test.status == http.StatusOK {
+				Expect(w.Body.String()).To(Equal(test.body))
+			}
+		}
+
+		// API key isn't forwarded to the node
+		Expect(forwardedKeys).To(Equal([]string{""}))
+	})
+
+	It("Should rate limit every call in a batch", func() {
+		node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.Copy(w, r.Body)
+		}))
+		defer node.Close()
+
+		upstream, _ := url.Parse(node.URL)
+		gateway := NewGateway(upstream, nil, NewAPIKeyAuthenticator("key-1"), NewRateLimiter(1, 2), MethodFilter{})
+
+		tests := []struct {
+			body   string
+			status int
+		}{
+			{`[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"method":"eth_chainId"},{"jsonrpc":"2.0","id":3,"method":"eth_chainId"}]`, http.StatusTooManyRequests},
+			{`[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"method":"eth_chainId"}]`, http.StatusOK},
+			{`{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`, http.StatusTooManyRequests},
+		}
+
+		for _, test := range tests {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+			r.Header.Set(HeaderAPIKey, "key-1")
+			w := httptest.NewRecorder()
+			gateway.ServeHTTP(w, r)
+
+			Expect(w.Code).To(Equal(test.status), "request %s: %s", test.body, w.Body.String())
+		}
+	})
+
+	It("Should filter websocket messages", func() {
+		// node websocket server echoing client messages
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		defer listener.Close()
+
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					defer conn.Close()
+					reader := bufio.NewReader(conn)
+					if _, err := http.ReadRequest(reader); err != nil {
+						return
+					}
+					conn.Write([]byte("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n"))
+					for {
+						f, err := readFrame(reader, 0)
+						if err != nil {
+							return
+						}
+						f.masked = false
+						writeFrame(conn, f)
+					}
+				}()
+			}
+		}()
+
+		wsUpstream, _ := url.Parse("ws://" + listener.Addr().String())
+		gateway := httptest.NewServer(NewGateway(wsUpstream, wsUpstream, NewAPIKeyAuthenticator("key-1"), nil, MethodFilter{Denied: []string{"admin_*"}}))
+		defer gateway.Close()
+
+		conn, err := net.Dial("tcp", strings.TrimPrefix(gateway.URL, "http://"))
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close()
+
+		conn.Write([]byte("GET / HTTP/1.1\r\nHost: node\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nX-API-Key: key-1\r\n\r\n"))
+		reader := bufio.NewReader(conn)
+		resp, err := http.ReadResponse(reader, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusSwitchingProtocols))
+
+		tests := []struct {
+			message  string
+			response string
+		}{
+			{`{"jsonrpc":"2.0","id":1,"method":"admin_peers"}`, `{"jsonrpc":"2.0","id":1,"error":{"code":-32001,"message":"method admin_peers is not allowed"}}`},
+			{`{"jsonrpc":"2.0","id":2,"method":"eth_chainId"}`, `{"jsonrpc":"2.0","id":2,"method":"eth_chainId"}`},
+		}
+
+		for _, test := range tests {
+			Expect(writeFrame(conn, &frame{fin: true, opcode: opText, masked: true, payload: []byte(test.message)})).To(Succeed())
+			f, err := readFrame(reader, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(f.payload)).To(Equal(test.response))
+		}
+	})
+
+	It("Should reject websocket connections without node websocket server", func() {
+		upstream, _ := url.Parse("http://127.0.0.1:8545")
+		gateway := NewGateway(upstream, nil, NewAPIKeyAuthenticator("key-1"), nil, MethodFilter{})
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Upgrade", "websocket")
+		r.Header.Set(HeaderAPIKey, "key-1")
+		w := httptest.NewRecorder()
+		gateway.ServeHTTP(w, r)
+
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+
+})
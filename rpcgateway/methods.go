@@ -0,0 +1,124 @@
+package rpcgateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrInvalidRequest is returned if request body is not a JSON-RPC request or batch
+var ErrInvalidRequest = errors.New("invalid JSON-RPC request")
+
+// MethodFilter allows or denies JSON-RPC methods
+// methods can end with * wildcard matching any method with the same prefix
+type MethodFilter struct {
+	Allowed []string
+	Denied  []string
+}
+
+// match reports whether method matches any of the patterns
+func match(method string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix := strings.TrimSuffix(pattern, "*"); prefix != pattern {
+			if strings.HasPrefix(method, prefix) {
+				return true
+			}
+		} else if method == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// Allow reports whether JSON-RPC method is allowed
+// denied methods take precedence over allowed methods, all methods are allowed if no allowed methods
+func (f *MethodFilter) Allow(method string) bool {
+	if match(method, f.Denied) {
+		return false
+	}
+	return len(f.Allowed) == 0 || match(method, f.Allowed)
+}
+
+// Request is JSON-RPC request
+type Request struct {
+	ID     json.RawMessage
+	Method string
+}
+
+// ParseRequests parses JSON-RPC request or batch of requests
+func ParseRequests(body []byte) (requests []Request, batch bool, err error) {
+	body = bytes.TrimSpace(body)
+
+	calls := []json.RawMessage{}
+	if bytes.HasPrefix(body, []byte("[")) {
+		batch = true
+		err = json.Unmarshal(body, &calls)
+	} else {
+		calls = append(calls, body)
+	}
+
+	if err != nil || len(calls) == 0 {
+		return nil, batch, ErrInvalidRequest
+	}
+
+	for _, call := range calls {
+		request, err := parseRequest(call)
+		if err != nil {
+			return nil, batch, err
+		}
+		requests = append(requests, request)
+	}
+
+	return
+}
+
+// parseRequest parses a single JSON-RPC call
+// nodes may match member names case-insensitively and pick any of duplicate members,
+// so the call must have exactly one member named method in any case, spelled exactly method
+func parseRequest(call json.RawMessage) (Request, error) {
+	request := Request{}
+	decoder := json.NewDecoder(bytes.NewReader(call))
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return request, ErrInvalidRequest
+	}
+
+	methods := 0
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return request, ErrInvalidRequest
+		}
+		name, _ := token.(string)
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return request, ErrInvalidRequest
+		}
+
+		switch {
+		case name == "method":
+			if err := json.Unmarshal(value, &request.Method); err != nil {
+				return request, ErrInvalidRequest
+			}
+			methods++
+		case strings.EqualFold(name, "method"):
+			methods++
+		case name == "id":
+			request.ID = value
+		}
+	}
+
+	if _, err := decoder.Token(); err != nil || methods != 1 || request.Method == "" {
+		return request, ErrInvalidRequest
+	}
+
+	// nothing is allowed after the call object
+	if _, err := decoder.Token(); err != io.EOF {
+		return request, ErrInvalidRequest
+	}
+
+	return request, nil
+}
@@ -0,0 +1,82 @@
+package rpcgateway
+
+import (
+	"sync"
+	"time"
+)
+
+// maxBuckets is the number of client buckets after which idle buckets are pruned
+const maxBuckets = 10000
+
+// bucket is token bucket of a single client
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimiter limits requests per second of each client using token buckets
+type RateLimiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewRateLimiter creates rate limiter allowing rate requests per second with burst per client
+func NewRateLimiter(rate, burst uint) *RateLimiter {
+	if burst == 0 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:    float64(rate),
+		burst:   float64(burst),
+		now:     time.Now,
+		buckets: map[string]*bucket{},
+	}
+}
+
+// Allow reports whether client request is allowed, and consumes a token if so
+func (l *RateLimiter) Allow(identity string) bool {
+	return l.AllowN(identity, 1)
+}
+
+// AllowN reports whether n client requests are allowed, and consumes n tokens if so
+func (l *RateLimiter) AllowN(identity string, n int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	b, found := l.buckets[identity]
+	if !found {
+		if len(l.buckets) >= maxBuckets {
+			l.prune(now)
+		}
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[identity] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+
+	if b.tokens < float64(n) {
+		return false
+	}
+
+	b.tokens -= float64(n)
+	return true
+}
+
+// prune removes buckets of clients that have been idle long enough to refill
+func (l *RateLimiter) prune(now time.Time) {
+	for identity, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, identity)
+		}
+	}
+}
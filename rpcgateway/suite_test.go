@@ -0,0 +1,13 @@
+package rpcgateway
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestRPCGateway(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RPC Gateway Suite")
+}
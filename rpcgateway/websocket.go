@@ -0,0 +1,306 @@
+package rpcgateway
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// websocket frame opcodes
+// https://datatracker.ietf.org/doc/html/rfc6455#section-5.2
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opClose        = 0x8
+)
+
+// closeMessageTooBig is websocket close status code used for messages exceeding maximum body size
+const closeMessageTooBig = 1009
+
+// errFrameTooLarge is returned if websocket frame payload exceeds the read limit
+var errFrameTooLarge = errors.New("websocket frame is too large")
+
+// frame is websocket frame with unmasked payload
+type frame struct {
+	fin     bool
+	opcode  byte
+	masked  bool
+	payload []byte
+}
+
+// isWebsocket reports whether request is websocket upgrade request
+func isWebsocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// readFrame reads websocket frame and unmasks its payload
+// frames with payload larger than limit are rejected, zero limit means no limit
+func readFrame(r *bufio.Reader, limit int) (*frame, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	f := &frame{
+		fin:    header[0]&0x80 != 0,
+		opcode: header[0] & 0x0f,
+		masked: header[1]&0x80 != 0,
+	}
+
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+		if _, err := io.ReadFull(r, extended); err != nil {
+			return nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127:
+		extended := make([]byte, 8)
+		if _, err := io.ReadFull(r, extended); err != nil {
+			return nil, err
+		}
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	if limit != 0 && length > uint64(limit) {
+		return nil, errFrameTooLarge
+	}
+
+	var mask [4]byte
+	if f.masked {
+		if _, err := io.ReadFull(r, mask[:]); err != nil {
+			return nil, err
+		}
+	}
+
+	f.payload = make([]byte, length)
+	if _, err := io.ReadFull(r, f.payload); err != nil {
+		return nil, err
+	}
+
+	if f.masked {
+		for i := range f.payload {
+			f.payload[i] ^= mask[i%4]
+		}
+	}
+
+	return f, nil
+}
+
+// writeFrame writes websocket frame, payload is masked with random key if frame is masked
+func writeFrame(w io.Writer, f *frame) error {
+	header := []byte{f.opcode, 0}
+	if f.fin {
+		header[0] |= 0x80
+	}
+
+	length := len(f.payload)
+	switch {
+	case length < 126:
+		header[1] = byte(length)
+	case length <= 0xffff:
+		header[1] = 126
+		extended := make([]byte, 2)
+		binary.BigEndian.PutUint16(extended, uint16(length))
+		header = append(header, extended...)
+	default:
+		header[1] = 127
+		extended := make([]byte, 8)
+		binary.BigEndian.PutUint64(extended, uint64(length))
+		header = append(header, extended...)
+	}
+
+	payload := f.payload
+	if f.masked {
+		header[1] |= 0x80
+		var mask [4]byte
+		if _, err := rand.Read(mask[:]); err != nil {
+			return err
+		}
+		header = append(header, mask[:]...)
+		payload = make([]byte, length)
+		for i := range f.payload {
+			payload[i] = f.payload[i] ^ mask[i%4]
+		}
+	}
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// closeFrame returns websocket close frame with status code and reason
+func closeFrame(code uint16, reason string) *frame {
+	payload := make([]byte, 2)
+	binary.BigEndian.PutUint16(payload, code)
+	return &frame{fin: true, opcode: opClose, payload: append(payload, reason...)}
+}
+
+// checkMessage rate limits and filters JSON-RPC message sent over websocket
+// it returns JSON-RPC error response if the message is rejected, otherwise nil
+func (g *Gateway) checkMessage(identity string, message []byte) []byte {
+	reject := func(id json.RawMessage, code int, msg string) []byte {
+		resp, _ := json.Marshal(newError(id, code, msg))
+		return resp
+	}
+
+	requests, batch, err := ParseRequests(message)
+	if err != nil {
+		return reject(nil, codeInvalidRequest, err.Error())
+	}
+
+	if g.RateLimiter != nil && !g.RateLimiter.AllowN(identity, len(requests)) {
+		return reject(nil, codeRateLimited, "rate limit exceeded")
+	}
+
+	if id, method, denied := g.denied(requests, batch); denied {
+		return reject(id, codeMethodNotAllowed, fmt.Sprintf("method %s is not allowed", method))
+	}
+
+	return nil
+}
+
+// proxyWebsocket proxies websocket connection to node websocket server
+// every JSON-RPC message sent by the client is rate limited and filtered like HTTP requests
+// messages sent by the node are proxied as is
+func (g *Gateway) proxyWebsocket(w http.ResponseWriter, r *http.Request, identity string) {
+	if g.wsUpstream == nil {
+		writeError(w, http.StatusBadRequest, nil, codeInvalidRequest, "websocket connections are not supported")
+		return
+	}
+
+	upstream, err := net.Dial("tcp", g.wsUpstream.Host)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, nil, codeInvalidRequest, "node websocket server is unavailable")
+		return
+	}
+	defer upstream.Close()
+
+	// forward handshake request to node websocket server
+	req := r.Clone(r.Context())
+	req.Host = g.wsUpstream.Host
+	if err := req.Write(upstream); err != nil {
+		writeError(w, http.StatusBadGateway, nil, codeInvalidRequest, "node websocket server is unavailable")
+		return
+	}
+
+	upstreamReader := bufio.NewReader(upstream)
+	resp, err := http.ReadResponse(upstreamReader, req)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, nil, codeInvalidRequest, "node websocket server is unavailable")
+		return
+	}
+	defer resp.Body.Close()
+
+	// node rejected the handshake
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		for key, values := range resp.Header {
+			for _, value := range values {
+				w.Header().Add(key, value)
+			}
+		}
+		w.WriteHeader(resp.StatusCode)
+		io.Copy(w, resp.Body)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, nil, codeInvalidRequest, "websocket connections are not supported")
+		return
+	}
+
+	conn, clientRW, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	if err := resp.Write(conn); err != nil {
+		return
+	}
+
+	// frames are sent to the client by node and gateway
+	var mu sync.Mutex
+	send := func(f *frame) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return writeFrame(conn, f)
+	}
+
+	go func() {
+		// unblock reading client frames once node closes the connection
+		defer conn.Close()
+		for {
+			f, err := readFrame(upstreamReader, 0)
+			if err != nil {
+				return
+			}
+			f.masked = false
+			if err := send(f); err != nil {
+				return
+			}
+		}
+	}()
+
+	var opcode byte
+	message := []byte{}
+
+	for {
+		f, err := readFrame(clientRW.Reader, maxBodySize)
+		if err == errFrameTooLarge {
+			send(closeFrame(closeMessageTooBig, "message is too large"))
+			return
+		}
+		if err != nil {
+			return
+		}
+
+		// control frames can be sent between fragments of a message
+		if f.opcode >= opClose {
+			f.masked = true
+			if err := writeFrame(upstream, f); err != nil {
+				return
+			}
+			continue
+		}
+
+		if f.opcode != opContinuation {
+			opcode = f.opcode
+			message = message[:0]
+		}
+
+		message = append(message, f.payload...)
+		if len(message) > maxBodySize {
+			send(closeFrame(closeMessageTooBig, "message is too large"))
+			return
+		}
+
+		if !f.fin {
+			continue
+		}
+
+		if rejected := g.checkMessage(identity, message); rejected != nil {
+			if err := send(&frame{fin: true, opcode: opText, payload: rejected}); err != nil {
+				return
+			}
+			continue
+		}
+
+		// fragmented messages are sent to the node in a single frame
+		if err := writeFrame(upstream, &frame{fin: true, opcode: opcode, masked: true, payload: message}); err != nil {
+			return
+		}
+	}
+}